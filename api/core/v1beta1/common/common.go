@@ -18,6 +18,7 @@ const (
 	SyncProviderHttp       SyncProviderType = "http"
 	SyncProviderGrpc       SyncProviderType = "grpc"
 	SyncProviderFlagdProxy SyncProviderType = "flagd-proxy"
+	SyncProviderFlagd      SyncProviderType = "flagd"
 )
 
 const (
@@ -28,6 +29,10 @@ const (
 	SocketPathEnvVar            string = "SOCKET_PATH"
 	OfflineFlagSourcePathEnvVar string = "OFFLINE_FLAG_SOURCE_PATH"
 	SelectorEnvVar              string = "SOURCE_SELECTOR"
+	ServerCertPathEnvVar        string = "SERVER_CERT_PATH"
+	ClientCertPathEnvVar        string = "CLIENT_CERT_PATH"
+	ClientKeyPathEnvVar         string = "CLIENT_KEY_PATH"
+	BearerTokenEnvVar           string = "BEARER_TOKEN"
 	CacheEnvVar                 string = "CACHE"
 	CacheMaxSizeEnvVar          string = "MAX_CACHE_SIZE"
 	ResolverEnvVar              string = "RESOLVER"
@@ -53,6 +58,12 @@ const (
 	RPCResolverType             string = "rpc"
 )
 
+// mount paths of the Secrets referenced by the TLS configuration of InProcessConfigurations
+const (
+	InProcessCAMountPath         = "/etc/flagd-tls/ca"
+	InProcessClientCertMountPath = "/etc/flagd-tls/client"
+)
+
 func (s SyncProviderType) IsKubernetes() bool {
 	return s == SyncProviderKubernetes
 }
@@ -85,6 +96,10 @@ func (s SyncProviderType) IsFlagdProxy() bool {
 	return s == SyncProviderFlagdProxy
 }
 
+func (s SyncProviderType) IsFlagd() bool {
+	return s == SyncProviderFlagd
+}
+
 func TrueVal() *bool {
 	b := true
 	return &b
@@ -15,6 +15,7 @@ func Test_FeatureFlagSource_SyncProvider(t *testing.T) {
 	gcs := SyncProviderGcs
 	azureBlob := SyncProviderAzureBlob
 	s3 := SyncProviderS3
+	flagd := SyncProviderFlagd
 
 	require.True(t, k.IsKubernetes())
 	require.True(t, f.IsFilepath())
@@ -23,6 +24,7 @@ func Test_FeatureFlagSource_SyncProvider(t *testing.T) {
 	require.True(t, gcs.IsGcs())
 	require.True(t, azureBlob.IsAzureBlob())
 	require.True(t, s3.IsS3())
+	require.True(t, flagd.IsFlagd())
 
 	require.False(t, f.IsKubernetes())
 	require.False(t, h.IsFilepath())
@@ -32,6 +34,8 @@ func Test_FeatureFlagSource_SyncProvider(t *testing.T) {
 	require.False(t, gcs.IsAzureBlob())
 	require.False(t, s3.IsGcs())
 	require.False(t, azureBlob.IsS3())
+	require.False(t, flagd.IsFlagdProxy())
+	require.False(t, g.IsFlagd())
 }
 
 func Test_FLagSourceConfiguration_EnvVarKey(t *testing.T) {
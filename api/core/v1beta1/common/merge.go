@@ -0,0 +1,53 @@
+package common
+
+import corev1 "k8s.io/api/core/v1"
+
+// The specs of FeatureFlagSources and InProcessConfigurations referenced by a pod are merged in layers. The defaults
+// of the operator form the bottom layer, the referenced resources are merged on top of it in the order of the
+// annotation, so the last resource takes precedence. Each layer is merged field by field:
+//
+//   - A value replaces the value of the lower layers when it is set (MergeValue, MergePointer). Optional fields
+//     declared as pointers are set when they are not nil, so a layer can explicitly reset a value to its zero value
+//     or to the default of the operator. Other fields are set when they differ from their zero value.
+//   - Environment variables are appended, a variable replaces the variable of the same name of the lower layers
+//     (MergeEnvVars).
+//   - Maps are merged key by key, a value replaces the value of the same key of the lower layers (MergeMap).
+//   - Other lists are appended, unless documented otherwise by the merged type.
+
+// MergeValue replaces dst with src when src is not the zero value of its type
+func MergeValue[T comparable](dst *T, src T) {
+	var zero T
+	if src != zero {
+		*dst = src
+	}
+}
+
+// MergePointer replaces dst with a copy of the value of src when src is not nil, the copy is shallow
+func MergePointer[T any](dst **T, src *T) {
+	if src != nil {
+		value := *src
+		*dst = &value
+	}
+}
+
+// MergeMap sets the entries of src in dst, allocating dst if needed
+func MergeMap[M ~map[K]V, K comparable, V any](dst *M, src M) {
+	if len(src) == 0 {
+		return
+	}
+	if *dst == nil {
+		*dst = make(M, len(src))
+	}
+	for k, v := range src {
+		(*dst)[k] = v
+	}
+}
+
+// MergeEnvVars appends the environment variables of src to dst, variables of src replace the variables of dst with
+// the same name
+func MergeEnvVars(dst *[]corev1.EnvVar, src []corev1.EnvVar) {
+	if len(src) == 0 {
+		return
+	}
+	*dst = RemoveDuplicateEnvVars(append(*dst, src...))
+}
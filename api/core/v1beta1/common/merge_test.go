@@ -0,0 +1,101 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergeValue(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  string
+		src  string
+		want string
+	}{
+		{name: "set value replaces", dst: "lower", src: "upper", want: "upper"},
+		{name: "zero value is inherited", dst: "lower", src: "", want: "lower"},
+		{name: "value of an unset lower layer", dst: "", src: "upper", want: "upper"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			MergeValue(&tt.dst, tt.src)
+			require.Equal(t, tt.want, tt.dst)
+		})
+	}
+}
+
+func TestMergePointer(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name string
+		dst  *bool
+		src  *bool
+		want *bool
+	}{
+		{name: "set value replaces", dst: &disabled, src: &enabled, want: &enabled},
+		{name: "zero value replaces", dst: &enabled, src: &disabled, want: &disabled},
+		{name: "nil is inherited", dst: &enabled, src: nil, want: &enabled},
+		{name: "nil on both layers", dst: nil, src: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			MergePointer(&tt.dst, tt.src)
+			require.Equal(t, tt.want, tt.dst)
+		})
+	}
+
+	// the merged value does not alias the upper layer
+	var dst *bool
+	src := true
+	MergePointer(&dst, &src)
+	src = false
+	require.True(t, *dst)
+}
+
+func TestMergeMap(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  map[string]string
+		src  map[string]string
+		want map[string]string
+	}{
+		{
+			name: "keys are merged",
+			dst:  map[string]string{"a": "lower", "b": "lower"},
+			src:  map[string]string{"b": "upper", "c": "upper"},
+			want: map[string]string{"a": "lower", "b": "upper", "c": "upper"},
+		},
+		{
+			name: "nil lower layer",
+			src:  map[string]string{"a": "upper"},
+			want: map[string]string{"a": "upper"},
+		},
+		{
+			name: "empty upper layer",
+			dst:  map[string]string{"a": "lower"},
+			src:  map[string]string{},
+			want: map[string]string{"a": "lower"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			MergeMap(&tt.dst, tt.src)
+			require.Equal(t, tt.want, tt.dst)
+		})
+	}
+}
+
+func TestMergeEnvVars(t *testing.T) {
+	dst := []corev1.EnvVar{{Name: "a", Value: "lower"}, {Name: "b", Value: "lower"}}
+	MergeEnvVars(&dst, []corev1.EnvVar{{Name: "b", Value: "upper"}, {Name: "c", Value: "upper"}})
+
+	require.Len(t, dst, 3)
+	require.Contains(t, dst, corev1.EnvVar{Name: "a", Value: "lower"})
+	require.Contains(t, dst, corev1.EnvVar{Name: "b", Value: "upper"})
+	require.Contains(t, dst, corev1.EnvVar{Name: "c", Value: "upper"})
+
+	MergeEnvVars(&dst, nil)
+	require.Len(t, dst, 3)
+}
@@ -37,7 +37,8 @@ type FeatureFlagSourceSpec struct {
 	// +kubebuilder:default:=8013
 	Port int32 `json:"port"`
 
-	// SocketPath defines the unix socket path to listen on
+	// SocketPath defines the unix socket path to listen on. The directory of the socket is shared between
+	// the sidecar and the application containers through an emptyDir volume
 	// +optional
 	SocketPath string `json:"socketPath"`
 
@@ -93,6 +94,11 @@ type FeatureFlagSourceSpec struct {
 	// +optional
 	OtelCollectorUri string `json:"otelCollectorUri"`
 
+	// Telemetry defines the OpenTelemetry export of the flagd sidecar metrics and traces,
+	// takes precedence over OtelCollectorUri
+	// +optional
+	Telemetry *TelemetrySpec `json:"telemetry,omitempty"`
+
 	// Resources defines flagd sidecar resources. Default to operator sidecar-cpu-* and sidecar-ram-* flags.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources"`
@@ -113,13 +119,43 @@ type FeatureFlagSourceSpec struct {
 	// +optional
 	// +kubebuilder:default:=8016
 	OFREPPort int32 `json:"ofrepPort"`
+
+	// Image allows for the sidecar image to be overridden, defaults to the operator SIDECAR_IMAGE env var
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Tag allows for the sidecar image tag to be overridden, defaults to the operator SIDECAR_TAG env var
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Digest pins the sidecar image to a digest (e.g. sha256:...), takes precedence over Tag
+	// +optional
+	// +kubebuilder:validation:Pattern="^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$"
+	Digest string `json:"digest,omitempty"`
+
+	// ImagePullPolicy defines the pull policy of the sidecar image, defaults to Always
+	// +optional
+	// +kubebuilder:validation:Enum:=Always;IfNotPresent;Never
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImagePullSecrets are added to the pod to pull the sidecar image from a private registry
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Sidecar defines overrides for the security context, probes and lifecycle of the flagd sidecar
+	// +optional
+	Sidecar *SidecarSpec `json:"sidecar,omitempty"`
+
+	// Monitoring creates a PodMonitor scraping the metrics of the flagd sidecars using this FeatureFlagSource
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
 }
 
 type Source struct {
 	// Source is a URI of the flag sources
 	Source string `json:"source"`
 
-	// Provider type - kubernetes, file, http(s), grpc(s), gcs, azblob, s3 or flagd-proxy
+	// Provider type - kubernetes, file, http(s), grpc(s), gcs, azblob, s3, flagd-proxy or flagd
 	// +optional
 	Provider common.SyncProviderType `json:"provider"`
 
@@ -148,6 +184,155 @@ type Source struct {
 	Interval uint32 `json:"interval,omitempty"`
 }
 
+// SidecarSpec defines overrides for the injected flagd sidecar container
+type SidecarSpec struct {
+	// SecurityContext replaces the default security context of the sidecar, e.g. to drop the fixed
+	// user and group IDs on platforms which assign random IDs
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// LivenessProbe overrides the timings of the liveness probe
+	// +optional
+	LivenessProbe *ProbeSpec `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe overrides the timings of the readiness probe
+	// +optional
+	ReadinessProbe *ProbeSpec `json:"readinessProbe,omitempty"`
+
+	// StartupProbe enables a startup probe on the management port with the given timings
+	// +optional
+	StartupProbe *ProbeSpec `json:"startupProbe,omitempty"`
+
+	// PreStop defines a handler which is executed before the sidecar is terminated
+	// +optional
+	PreStop *corev1.LifecycleHandler `json:"preStop,omitempty"`
+
+	// TerminationGracePeriodSeconds defines the minimum termination grace period of the pod, a higher value
+	// defined by the workload is kept
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// ProbeSpec defines the timings and thresholds of a sidecar probe
+type ProbeSpec struct {
+	// InitialDelaySeconds defines the number of seconds after the container has started before the probe is initiated
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds defines how often (in seconds) to perform the probe
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds defines the number of seconds after which the probe times out
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold defines the minimum consecutive failures for the probe to be considered failed
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// SuccessThreshold defines the minimum consecutive successes for the probe to be considered successful,
+	// only applied to the readiness probe, liveness and startup probes require 1
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+}
+
+// MonitoringSpec defines the Prometheus Operator scrape configuration created for flagd
+type MonitoringSpec struct {
+	// Enabled enables/disables the creation of the monitor, requires the Prometheus Operator CRDs to be installed
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Labels are added to the monitor, e.g. to match the monitor selector of a Prometheus instance
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Interval at which the metrics are scraped, defaults to the scrape interval of the Prometheus instance
+	// +optional
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	Interval string `json:"interval,omitempty"`
+}
+
+// TelemetrySpec defines the OpenTelemetry configuration of flagd
+type TelemetrySpec struct {
+	// MetricsEndpoint is the OTLP endpoint metrics are exported to, e.g. otel-collector:4317
+	// +optional
+	MetricsEndpoint string `json:"metricsEndpoint,omitempty"`
+
+	// TracesEndpoint is the OTLP endpoint traces are exported to, defaults to the MetricsEndpoint
+	// +optional
+	TracesEndpoint string `json:"tracesEndpoint,omitempty"`
+
+	// Protocol of the OTLP export, defaults to grpc. The signal paths are appended to the endpoints for http/protobuf
+	// +optional
+	// +kubebuilder:validation:Enum:=grpc;http/protobuf
+	Protocol string `json:"protocol,omitempty"`
+
+	// Headers are added to the OTLP export requests, the values are read from Secrets in the namespace of the pod
+	// +optional
+	Headers []TelemetryHeader `json:"headers,omitempty"`
+
+	// TLS defines the transport security of the OTLP export
+	// +optional
+	TLS *TelemetryTLSSpec `json:"tls,omitempty"`
+
+	// ServiceName sets the service.name resource attribute, defaults to the app.kubernetes.io/name label of the pod
+	// or flagd
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+
+	// ResourceAttributes are added to the k8s.namespace.name and k8s.pod.name resource attributes
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+
+	// Sampler defines the sampling of the exported traces
+	// +optional
+	Sampler *TelemetrySamplerSpec `json:"sampler,omitempty"`
+}
+
+// TelemetryHeader defines an OTLP export header with a value from a Secret
+type TelemetryHeader struct {
+	// Name of the header
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// ValueFrom selects the key of a Secret holding the header value
+	ValueFrom corev1.SecretKeySelector `json:"valueFrom"`
+}
+
+// TelemetryTLSSpec defines the transport security of the OTLP export
+type TelemetryTLSSpec struct {
+	// Insecure disables TLS for the OTLP export, it cannot be combined with SecretName
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// SecretName is the name of a Secret holding the CA certificate (ca.crt) and the optional client
+	// certificate (tls.crt, tls.key), the Secret is mounted into the sidecar
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ClientCertificate enables mutual TLS with the client certificate of the Secret
+	// +optional
+	ClientCertificate bool `json:"clientCertificate,omitempty"`
+}
+
+// TelemetrySamplerSpec defines the sampling of exported traces
+type TelemetrySamplerSpec struct {
+	// Type of the sampler
+	// +kubebuilder:validation:Enum:=always_on;always_off;traceidratio;parentbased_always_on;parentbased_always_off;parentbased_traceidratio
+	Type string `json:"type"`
+
+	// Ratio of sampled traces between 0 and 1, used by the traceidratio samplers
+	// +optional
+	// +kubebuilder:validation:Pattern="^(0(\\.[0-9]+)?|1(\\.0+)?)$"
+	Ratio string `json:"ratio,omitempty"`
+}
+
 // FeatureFlagSourceStatus defines the observed state of FeatureFlagSource
 type FeatureFlagSourceStatus struct{}
 
@@ -178,93 +363,123 @@ func init() {
 	SchemeBuilder.Register(&FeatureFlagSource{}, &FeatureFlagSourceList{})
 }
 
-//nolint:gocyclo
+// Merge merges new on top of the spec following the layered merge of the common package. Sources are appended in
+// their order, sync provider arguments and image pull secrets are appended without duplicates, the CORS origins of new
+// replace the origins of the spec. The image, tag and digest of new replace all three if any of them is set.
 func (fc *FeatureFlagSourceSpec) Merge(new *FeatureFlagSourceSpec) {
 	if new == nil {
 		return
 	}
-	if new.ManagementPort != 0 {
-		fc.ManagementPort = new.ManagementPort
-	}
-	if new.Port != 0 {
-		fc.Port = new.Port
-	}
-	if new.SocketPath != "" {
-		fc.SocketPath = new.SocketPath
-	}
-	if new.Evaluator != "" {
-		fc.Evaluator = new.Evaluator
-	}
+	common.MergeValue(&fc.ManagementPort, new.ManagementPort)
+	common.MergeValue(&fc.Port, new.Port)
+	common.MergeValue(&fc.SocketPath, new.SocketPath)
+	common.MergeValue(&fc.Evaluator, new.Evaluator)
 	if len(new.Sources) != 0 {
 		fc.Sources = append(fc.Sources, new.Sources...)
 	}
-	if len(new.EnvVars) != 0 {
-		fc.EnvVars = append(fc.EnvVars, new.EnvVars...)
-		fc.EnvVars = common.RemoveDuplicateEnvVars(fc.EnvVars)
-	}
+	common.MergeEnvVars(&fc.EnvVars, new.EnvVars)
 	if len(new.SyncProviderArgs) != 0 {
 		fc.SyncProviderArgs = append(fc.SyncProviderArgs, new.SyncProviderArgs...)
 		fc.SyncProviderArgs = common.RemoveDuplicatesFromSlice[string](fc.SyncProviderArgs)
 	}
-	if new.EnvVarPrefix != "" {
-		fc.EnvVarPrefix = new.EnvVarPrefix
+	common.MergeValue(&fc.EnvVarPrefix, new.EnvVarPrefix)
+	common.MergeValue(&fc.DefaultSyncProvider, new.DefaultSyncProvider)
+	common.MergeValue(&fc.LogFormat, new.LogFormat)
+	common.MergePointer(&fc.RolloutOnChange, new.RolloutOnChange)
+	common.MergePointer(&fc.ProbesEnabled, new.ProbesEnabled)
+	common.MergePointer(&fc.DebugLogging, new.DebugLogging)
+	common.MergeValue(&fc.OtelCollectorUri, new.OtelCollectorUri)
+	common.MergeMap(&fc.Resources.Requests, new.Resources.Requests)
+	common.MergeMap(&fc.Resources.Limits, new.Resources.Limits)
+	common.MergeMap(&fc.ContextValues, new.ContextValues)
+	common.MergeMap(&fc.HeaderToContextMappings, new.HeaderToContextMappings)
+	if len(new.CORS) != 0 {
+		fc.CORS = new.CORS
 	}
-	if new.DefaultSyncProvider != "" {
-		fc.DefaultSyncProvider = new.DefaultSyncProvider
+	common.MergeValue(&fc.OFREPPort, new.OFREPPort)
+	// the image, tag and digest form a single reference, a digest or image of a previous spec must not be combined
+	// with the tag or image of the new one
+	if new.Image != "" || new.Tag != "" || new.Digest != "" {
+		fc.Image, fc.Tag, fc.Digest = new.Image, new.Tag, new.Digest
 	}
-	if new.LogFormat != "" {
-		fc.LogFormat = new.LogFormat
+	common.MergeValue(&fc.ImagePullPolicy, new.ImagePullPolicy)
+	if len(new.ImagePullSecrets) != 0 {
+		fc.ImagePullSecrets = append(fc.ImagePullSecrets, new.ImagePullSecrets...)
+		fc.ImagePullSecrets = common.RemoveDuplicatesFromSlice[corev1.LocalObjectReference](fc.ImagePullSecrets)
 	}
-	if new.RolloutOnChange != nil {
-		fc.RolloutOnChange = new.RolloutOnChange
+	if new.Sidecar != nil {
+		if fc.Sidecar == nil {
+			fc.Sidecar = &SidecarSpec{}
+		}
+		fc.Sidecar.Merge(new.Sidecar)
 	}
-	if new.ProbesEnabled != nil {
-		fc.ProbesEnabled = new.ProbesEnabled
+	if new.Monitoring != nil {
+		fc.Monitoring = new.Monitoring.DeepCopy()
 	}
-	if new.DebugLogging != nil {
-		fc.DebugLogging = new.DebugLogging
+	if new.Telemetry != nil {
+		fc.Telemetry = new.Telemetry.DeepCopy()
 	}
-	if new.OtelCollectorUri != "" {
-		fc.OtelCollectorUri = new.OtelCollectorUri
+}
+
+// Merge overrides the sidecar settings which are set in new, probe settings are merged field by field
+func (s *SidecarSpec) Merge(new *SidecarSpec) {
+	if new == nil {
+		return
 	}
-	if len(new.Resources.Requests) != 0 {
-		if fc.Resources.Requests == nil {
-			fc.Resources.Requests = corev1.ResourceList{}
-		}
-		for k, v := range new.Resources.Requests {
-			fc.Resources.Requests[k] = v
-		}
+	if new.SecurityContext != nil {
+		s.SecurityContext = new.SecurityContext
 	}
-	if len(new.Resources.Limits) != 0 {
-		if fc.Resources.Limits == nil {
-			fc.Resources.Limits = corev1.ResourceList{}
-		}
-		for k, v := range new.Resources.Limits {
-			fc.Resources.Limits[k] = v
-		}
+	s.LivenessProbe = mergeProbeSpec(s.LivenessProbe, new.LivenessProbe)
+	s.ReadinessProbe = mergeProbeSpec(s.ReadinessProbe, new.ReadinessProbe)
+	s.StartupProbe = mergeProbeSpec(s.StartupProbe, new.StartupProbe)
+	if new.PreStop != nil {
+		s.PreStop = new.PreStop
 	}
-	if len(new.ContextValues) != 0 {
-		if fc.ContextValues == nil {
-			fc.ContextValues = map[string]string{}
-		}
-		for k, v := range new.ContextValues {
-			fc.ContextValues[k] = v
-		}
+	if new.TerminationGracePeriodSeconds != nil {
+		s.TerminationGracePeriodSeconds = new.TerminationGracePeriodSeconds
 	}
-	if len(new.HeaderToContextMappings) != 0 {
-		if fc.HeaderToContextMappings == nil {
-			fc.HeaderToContextMappings = map[string]string{}
-		}
-		for k, v := range new.HeaderToContextMappings {
-			fc.HeaderToContextMappings[k] = v
-		}
+}
+
+func mergeProbeSpec(old *ProbeSpec, new *ProbeSpec) *ProbeSpec {
+	if new == nil {
+		return old
 	}
-	if len(new.CORS) != 0 {
-		fc.CORS = new.CORS
+	if old == nil {
+		old = &ProbeSpec{}
+	}
+	if new.InitialDelaySeconds != nil {
+		old.InitialDelaySeconds = new.InitialDelaySeconds
+	}
+	if new.PeriodSeconds != nil {
+		old.PeriodSeconds = new.PeriodSeconds
+	}
+	if new.TimeoutSeconds != nil {
+		old.TimeoutSeconds = new.TimeoutSeconds
+	}
+	if new.FailureThreshold != nil {
+		old.FailureThreshold = new.FailureThreshold
+	}
+	if new.SuccessThreshold != nil {
+		old.SuccessThreshold = new.SuccessThreshold
+	}
+	return old
+}
+
+// ImageReference returns the sidecar image reference, using the given image and tag for values which are not set.
+// A Digest takes precedence over the tag.
+func (fc *FeatureFlagSourceSpec) ImageReference(defaultImage string, defaultTag string) string {
+	image := defaultImage
+	if fc.Image != "" {
+		image = fc.Image
+	}
+	if fc.Digest != "" {
+		return fmt.Sprintf("%s@%s", image, fc.Digest)
 	}
-	if new.OFREPPort != 0 {
-		fc.OFREPPort = new.OFREPPort
+	tag := defaultTag
+	if fc.Tag != "" {
+		tag = fc.Tag
 	}
+	return fmt.Sprintf("%s:%s", image, tag)
 }
 
 func (fc *FeatureFlagSourceSpec) decorateEnvVarName(original string) string {
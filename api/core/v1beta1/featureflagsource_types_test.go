@@ -157,8 +157,13 @@ func Test_FLagSourceConfiguration_Merge(t *testing.T) {
 				"X-Tenant": "tenant-override",
 				"X-Region": "region",
 			},
-			CORS:      []string{"https://app.example.com", "https://admin.example.com"},
-			OFREPPort: 9090,
+			CORS:             []string{"https://app.example.com", "https://admin.example.com"},
+			OFREPPort:        9090,
+			Image:            "registry.example.com/flagd",
+			Tag:              "v0.12.0",
+			Digest:           "sha256:0123456789abcdef0123456789abcdef",
+			ImagePullPolicy:  v1.PullIfNotPresent,
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}},
 		},
 	}
 
@@ -224,6 +229,141 @@ func Test_FLagSourceConfiguration_Merge(t *testing.T) {
 
 	// OFREPPort is overridden
 	require.Equal(t, int32(9090), ff_old.Spec.OFREPPort)
+
+	// image settings are overridden, pull secrets are appended
+	require.Equal(t, "registry.example.com/flagd", ff_old.Spec.Image)
+	require.Equal(t, "v0.12.0", ff_old.Spec.Tag)
+	require.Equal(t, "sha256:0123456789abcdef0123456789abcdef", ff_old.Spec.Digest)
+	require.Equal(t, v1.PullIfNotPresent, ff_old.Spec.ImagePullPolicy)
+
+	ff_old.Spec.Merge(&FeatureFlagSourceSpec{
+		ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+	})
+	require.Equal(t, []v1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}}, ff_old.Spec.ImagePullSecrets)
+}
+
+func Test_FLagSourceConfiguration_MergeImage(t *testing.T) {
+	pinned := FeatureFlagSourceSpec{Image: "mirror.local/flagd", Digest: "sha256:abc"}
+	tests := []struct {
+		name string
+		new  FeatureFlagSourceSpec
+		want string
+	}{
+		{
+			name: "no image override",
+			new:  FeatureFlagSourceSpec{},
+			want: "mirror.local/flagd@sha256:abc",
+		},
+		{
+			name: "tag over digest",
+			new:  FeatureFlagSourceSpec{Tag: "v0.12.0"},
+			want: "ghcr.io/open-feature/flagd:v0.12.0",
+		},
+		{
+			name: "image over digest",
+			new:  FeatureFlagSourceSpec{Image: "other.local/flagd"},
+			want: "other.local/flagd:v0.11.0",
+		},
+		{
+			name: "digest over digest",
+			new:  FeatureFlagSourceSpec{Digest: "sha256:def"},
+			want: "ghcr.io/open-feature/flagd@sha256:def",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := pinned
+			spec.Merge(&tt.new)
+			require.Equal(t, tt.want, spec.ImageReference("ghcr.io/open-feature/flagd", "v0.11.0"))
+		})
+	}
+}
+
+func Test_FLagSourceConfiguration_MergeSidecar(t *testing.T) {
+	period := int32(20)
+	failures := int32(6)
+	timeout := int32(3)
+	grace := int64(45)
+
+	spec := &FeatureFlagSourceSpec{}
+	spec.Merge(&FeatureFlagSourceSpec{
+		Sidecar: &SidecarSpec{
+			LivenessProbe: &ProbeSpec{
+				PeriodSeconds: &period,
+			},
+			TerminationGracePeriodSeconds: &grace,
+		},
+	})
+	require.Equal(t, &period, spec.Sidecar.LivenessProbe.PeriodSeconds)
+
+	securityContext := &v1.SecurityContext{
+		Capabilities: &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+	}
+	spec.Merge(&FeatureFlagSourceSpec{
+		Sidecar: &SidecarSpec{
+			SecurityContext: securityContext,
+			LivenessProbe: &ProbeSpec{
+				FailureThreshold: &failures,
+			},
+			ReadinessProbe: &ProbeSpec{
+				TimeoutSeconds: &timeout,
+			},
+		},
+	})
+
+	// probe settings are merged field by field, other settings are overridden if set
+	require.Equal(t, &SidecarSpec{
+		SecurityContext: securityContext,
+		LivenessProbe: &ProbeSpec{
+			PeriodSeconds:    &period,
+			FailureThreshold: &failures,
+		},
+		ReadinessProbe: &ProbeSpec{
+			TimeoutSeconds: &timeout,
+		},
+		TerminationGracePeriodSeconds: &grace,
+	}, spec.Sidecar)
+
+	// an empty sidecar spec does not change anything
+	spec.Merge(&FeatureFlagSourceSpec{Sidecar: &SidecarSpec{}})
+	require.Equal(t, &grace, spec.Sidecar.TerminationGracePeriodSeconds)
+	require.Nil(t, spec.Sidecar.StartupProbe)
+}
+
+func Test_FLagSourceConfiguration_ImageReference(t *testing.T) {
+	tests := []struct {
+		name string
+		spec FeatureFlagSourceSpec
+		want string
+	}{
+		{
+			name: "defaults",
+			spec: FeatureFlagSourceSpec{},
+			want: "ghcr.io/open-feature/flagd:v0.11.0",
+		},
+		{
+			name: "tag override",
+			spec: FeatureFlagSourceSpec{Tag: "v0.12.0"},
+			want: "ghcr.io/open-feature/flagd:v0.12.0",
+		},
+		{
+			name: "image and tag override",
+			spec: FeatureFlagSourceSpec{Image: "mirror.local/flagd", Tag: "v0.12.0"},
+			want: "mirror.local/flagd:v0.12.0",
+		},
+		{
+			name: "digest takes precedence over tag",
+			spec: FeatureFlagSourceSpec{Tag: "v0.12.0", Digest: "sha256:abc"},
+			want: "ghcr.io/open-feature/flagd@sha256:abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.spec.ImageReference("ghcr.io/open-feature/flagd", "v0.11.0"))
+		})
+	}
 }
 
 func Test_FLagSourceConfiguration_ToEnvVars(t *testing.T) {
@@ -397,3 +537,50 @@ func Test_FLagSourceConfiguration_ToEnvVars(t *testing.T) {
 	}
 	require.Equal(t, expected, ff.Spec.ToEnvVars())
 }
+
+func Test_FLagSourceConfiguration_MergeMonitoring(t *testing.T) {
+	spec := &FeatureFlagSourceSpec{}
+	spec.Merge(&FeatureFlagSourceSpec{
+		Monitoring: &MonitoringSpec{
+			Enabled: true,
+			Labels:  map[string]string{"release": "prometheus"},
+		},
+	})
+	spec.Merge(&FeatureFlagSourceSpec{})
+	require.Equal(t, &MonitoringSpec{
+		Enabled: true,
+		Labels:  map[string]string{"release": "prometheus"},
+	}, spec.Monitoring)
+
+	// the monitoring settings of the last FeatureFlagSource defining them are used as a whole
+	spec.Merge(&FeatureFlagSourceSpec{
+		Monitoring: &MonitoringSpec{
+			Interval: "30s",
+		},
+	})
+	require.Equal(t, &MonitoringSpec{
+		Interval: "30s",
+	}, spec.Monitoring)
+}
+
+func Test_FLagSourceConfiguration_MergeTelemetry(t *testing.T) {
+	spec := &FeatureFlagSourceSpec{}
+	spec.Merge(&FeatureFlagSourceSpec{
+		Telemetry: &TelemetrySpec{
+			MetricsEndpoint: "collector:4317",
+			Sampler:         &TelemetrySamplerSpec{Type: "always_on"},
+		},
+	})
+	spec.Merge(&FeatureFlagSourceSpec{})
+	require.Equal(t, "collector:4317", spec.Telemetry.MetricsEndpoint)
+
+	// the telemetry settings of the last FeatureFlagSource defining them are used as a whole
+	spec.Merge(&FeatureFlagSourceSpec{
+		Telemetry: &TelemetrySpec{
+			TracesEndpoint: "tracing:4317",
+		},
+	})
+	require.Equal(t, &TelemetrySpec{
+		TracesEndpoint: "tracing:4317",
+	}, spec.Telemetry)
+}
@@ -26,9 +26,9 @@ import (
 // FlagdSpec defines the desired state of Flagd
 type FlagdSpec struct {
 	// Replicas defines the number of replicas to create for the service.
-	// Default: 1
+	// The replicas are left to other controllers, e.g. a HorizontalPodAutoscaler, if not set, a new Deployment then
+	// starts with the single replica defaulted by the API server.
 	// +optional
-	// +kubebuilder:default=1
 	Replicas *int32 `json:"replicas,omitempty"`
 
 	// ServiceType represents the type of Service to create.
@@ -45,7 +45,14 @@ type FlagdSpec struct {
 
 	// FeatureFlagSource references to a FeatureFlagSource from which the created flagd instance retrieves
 	// the feature flag configurations
-	FeatureFlagSource string `json:"featureFlagSource"`
+	// +optional
+	FeatureFlagSource string `json:"featureFlagSource,omitempty"`
+
+	// FeatureFlagSources is an ordered list of FeatureFlagSources merged into the configuration of the created flagd
+	// instance, after the FeatureFlagSource if both are set. FeatureFlagSources of other namespaces are referenced as
+	// {NAMESPACE}/{NAME} and require a ReferenceGrant in their namespace.
+	// +optional
+	FeatureFlagSources []string `json:"featureFlagSources,omitempty"`
 
 	// Ingress
 	// +optional
@@ -54,6 +61,20 @@ type FlagdSpec struct {
 	// GatewayApiRoutes
 	// +optional
 	GatewayApiRoutes GatewayApiSpec `json:"gatewayApiRoutes"`
+
+	// Monitoring creates a ServiceMonitor scraping the metrics port of the flagd service
+	// +optional
+	Monitoring MonitoringSpec `json:"monitoring"`
+}
+
+// FeatureFlagSourceReferences returns the references to the FeatureFlagSources of the flagd instance in the order
+// they are merged
+func (s *FlagdSpec) FeatureFlagSourceReferences() []string {
+	references := []string{}
+	if s.FeatureFlagSource != "" {
+		references = append(references, s.FeatureFlagSource)
+	}
+	return append(references, s.FeatureFlagSources...)
 }
 
 // IngressSpec defines the options to be used when deploying the ingress for flagd
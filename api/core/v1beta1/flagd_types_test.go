@@ -0,0 +1,17 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FlagdSpec_FeatureFlagSourceReferences(t *testing.T) {
+	require.Equal(t, []string{}, (&FlagdSpec{}).FeatureFlagSourceReferences())
+	require.Equal(t, []string{"source"}, (&FlagdSpec{FeatureFlagSource: "source"}).FeatureFlagSourceReferences())
+	require.Equal(t, []string{"source", "shared/team", "other"}, (&FlagdSpec{
+		FeatureFlagSource:  "source",
+		FeatureFlagSources: []string{"shared/team", "other"},
+	}).FeatureFlagSourceReferences())
+	require.Equal(t, []string{"shared/team"}, (&FlagdSpec{FeatureFlagSources: []string{"shared/team"}}).FeatureFlagSourceReferences())
+}
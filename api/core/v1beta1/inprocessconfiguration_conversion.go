@@ -0,0 +1,165 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	"github.com/open-feature/open-feature-operator/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ExplicitDefaultsAnnotation lists the fields of a v1beta2 InProcessConfiguration explicitly set to the default
+// value, which v1beta1 cannot tell apart from unset fields. It keeps the conversion through v1beta1 lossless.
+const ExplicitDefaultsAnnotation = "core.openfeature.dev/explicit-defaults"
+
+var _ conversion.Convertible = &InProcessConfiguration{}
+
+// ConvertTo converts the InProcessConfiguration to the v1beta2 hub. Fields holding the value v1beta1 defaults to are
+// unset, as they did not take precedence over the InProcessConfigurations referenced before, unless they are listed
+// in the ExplicitDefaultsAnnotation.
+func (src *InProcessConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta2.InProcessConfiguration)
+	if !ok {
+		return fmt.Errorf("expected a v1beta2 InProcessConfiguration but got %T", dstRaw)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	explicit := []string{}
+	if value, ok := dst.Annotations[ExplicitDefaultsAnnotation]; ok {
+		explicit = strings.Split(value, ",")
+		delete(dst.Annotations, ExplicitDefaultsAnnotation)
+	}
+	pointer := func(field string, set bool) bool {
+		return set || slices.Contains(explicit, field)
+	}
+
+	s := src.Spec.DeepCopy()
+	dst.Spec = v1beta2.InProcessConfigurationSpec{
+		Port:                  toPointer(s.Port, pointer("port", s.Port != common.DefaultInProcessPort)),
+		SocketPath:            toPointer(s.SocketPath, pointer("socketPath", s.SocketPath != "")),
+		Host:                  toPointer(s.Host, pointer("host", s.Host != common.DefaultHost)),
+		TLS:                   toPointer(s.TLS, pointer("tls", s.TLS != common.DefaultTLS)),
+		BearerToken:           s.BearerToken,
+		OfflineFlagSourcePath: toPointer(s.OfflineFlagSourcePath, pointer("offlineFlagSourcePath", s.OfflineFlagSourcePath != "")),
+		OfflineFeatureFlag:    toPointer(s.OfflineFeatureFlag, pointer("offlineFeatureFlag", s.OfflineFeatureFlag != "")),
+		Selector:              toPointer(s.Selector, pointer("selector", s.Selector != "")),
+		Cache:                 toPointer(s.Cache, pointer("cache", s.Cache != common.DefaultCache)),
+		CacheMaxSize:          toPointer(int32(s.CacheMaxSize), pointer("cacheMaxSize", s.CacheMaxSize != int(common.DefaultCacheMaxSize))),
+		EnvVars:               s.EnvVars,
+		EnvVarPrefix:          toPointer(s.EnvVarPrefix, pointer("envVarPrefix", s.EnvVarPrefix != common.DefaultEnvVarPrefix)),
+	}
+	if s.TLSConfig != nil {
+		dst.Spec.TLSConfig = &v1beta2.InProcessTLSConfig{
+			CASecret:         s.TLSConfig.CASecret,
+			ClientCertSecret: s.TLSConfig.ClientCertSecret,
+		}
+		// a TLS configuration enables TLS in v1beta1
+		if dst.Spec.TLS == nil {
+			dst.Spec.TLS = toPointer(true, true)
+		}
+	}
+	if s.SyncServer != nil {
+		dst.Spec.SyncServer = &v1beta2.SyncServerReference{
+			Kind:        s.SyncServer.Kind,
+			Name:        s.SyncServer.Name,
+			FeatureFlag: s.SyncServer.FeatureFlag,
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta2 hub to the InProcessConfiguration. Unset fields are set to the v1beta1 defaults,
+// fields explicitly set to the default value are listed in the ExplicitDefaultsAnnotation.
+func (dst *InProcessConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta2.InProcessConfiguration)
+	if !ok {
+		return fmt.Errorf("expected a v1beta2 InProcessConfiguration but got %T", srcRaw)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	explicit := []string{}
+	record := func(field string, explicitDefault bool) {
+		if explicitDefault {
+			explicit = append(explicit, field)
+		}
+	}
+
+	s := src.Spec.DeepCopy()
+	dst.Spec = InProcessConfigurationSpec{
+		Port:                  fromPointer(s.Port, common.DefaultInProcessPort),
+		SocketPath:            fromPointer(s.SocketPath, ""),
+		Host:                  fromPointer(s.Host, common.DefaultHost),
+		TLS:                   fromPointer(s.TLS, common.DefaultTLS),
+		BearerToken:           s.BearerToken,
+		OfflineFlagSourcePath: fromPointer(s.OfflineFlagSourcePath, ""),
+		OfflineFeatureFlag:    fromPointer(s.OfflineFeatureFlag, ""),
+		Selector:              fromPointer(s.Selector, ""),
+		Cache:                 fromPointer(s.Cache, common.DefaultCache),
+		CacheMaxSize:          int(fromPointer(s.CacheMaxSize, common.DefaultCacheMaxSize)),
+		EnvVars:               s.EnvVars,
+		EnvVarPrefix:          fromPointer(s.EnvVarPrefix, common.DefaultEnvVarPrefix),
+	}
+	record("port", s.Port != nil && *s.Port == common.DefaultInProcessPort)
+	record("socketPath", s.SocketPath != nil && *s.SocketPath == "")
+	record("host", s.Host != nil && *s.Host == common.DefaultHost)
+	record("tls", s.TLS != nil && *s.TLS == common.DefaultTLS)
+	record("offlineFlagSourcePath", s.OfflineFlagSourcePath != nil && *s.OfflineFlagSourcePath == "")
+	record("offlineFeatureFlag", s.OfflineFeatureFlag != nil && *s.OfflineFeatureFlag == "")
+	record("selector", s.Selector != nil && *s.Selector == "")
+	record("cache", s.Cache != nil && *s.Cache == common.DefaultCache)
+	record("cacheMaxSize", s.CacheMaxSize != nil && *s.CacheMaxSize == common.DefaultCacheMaxSize)
+	record("envVarPrefix", s.EnvVarPrefix != nil && *s.EnvVarPrefix == common.DefaultEnvVarPrefix)
+	if s.TLSConfig != nil {
+		dst.Spec.TLSConfig = &InProcessTLSConfig{
+			CASecret:         s.TLSConfig.CASecret,
+			ClientCertSecret: s.TLSConfig.ClientCertSecret,
+		}
+	}
+	if s.SyncServer != nil {
+		dst.Spec.SyncServer = &SyncServerReference{
+			Kind:        s.SyncServer.Kind,
+			Name:        s.SyncServer.Name,
+			FeatureFlag: s.SyncServer.FeatureFlag,
+		}
+	}
+
+	if len(explicit) == 0 {
+		delete(dst.Annotations, ExplicitDefaultsAnnotation)
+		return nil
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[ExplicitDefaultsAnnotation] = strings.Join(explicit, ",")
+	return nil
+}
+
+func toPointer[T any](value T, set bool) *T {
+	if !set {
+		return nil
+	}
+	return &value
+}
+
+func fromPointer[T any](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
@@ -0,0 +1,163 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/open-feature/open-feature-operator/api/core/v1beta2"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// v1beta1Defaults returns a v1beta1 spec holding the defaults applied by the v1beta1 schema
+func v1beta1Defaults() InProcessConfigurationSpec {
+	return InProcessConfigurationSpec{
+		Port:         8015,
+		Host:         "localhost",
+		Cache:        "lru",
+		CacheMaxSize: 1000,
+		EnvVarPrefix: "FLAGD",
+	}
+}
+
+func Test_InProcessConfiguration_ConvertTo(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        func(spec *InProcessConfigurationSpec)
+		want        v1beta2.InProcessConfigurationSpec
+	}{
+		{
+			name: "defaults are unset",
+			spec: func(spec *InProcessConfigurationSpec) {},
+			want: v1beta2.InProcessConfigurationSpec{},
+		},
+		{
+			name: "values other than the defaults are set",
+			spec: func(spec *InProcessConfigurationSpec) {
+				spec.Port = 33
+				spec.Host = "host"
+				spec.Selector = "selector"
+				spec.CacheMaxSize = 12
+				spec.EnvVars = []v1.EnvVar{{Name: "env", Value: "val"}}
+				spec.SyncServer = &SyncServerReference{Kind: SyncServerKindFlagd, Name: "central-flagd"}
+			},
+			want: v1beta2.InProcessConfigurationSpec{
+				Port:         ptr(int32(33)),
+				Host:         ptr("host"),
+				Selector:     ptr("selector"),
+				CacheMaxSize: ptr(int32(12)),
+				EnvVars:      []v1.EnvVar{{Name: "env", Value: "val"}},
+				SyncServer:   &v1beta2.SyncServerReference{Kind: v1beta2.SyncServerKindFlagd, Name: "central-flagd"},
+			},
+		},
+		{
+			name: "TLS configuration enables TLS",
+			spec: func(spec *InProcessConfigurationSpec) {
+				spec.TLSConfig = &InProcessTLSConfig{CASecret: "ca"}
+			},
+			want: v1beta2.InProcessConfigurationSpec{
+				TLS:       ptr(true),
+				TLSConfig: &v1beta2.InProcessTLSConfig{CASecret: "ca"},
+			},
+		},
+		{
+			name:        "explicit defaults are set",
+			annotations: map[string]string{ExplicitDefaultsAnnotation: "tls,port,selector"},
+			spec: func(spec *InProcessConfigurationSpec) {
+				spec.TLSConfig = &InProcessTLSConfig{CASecret: "ca"}
+			},
+			want: v1beta2.InProcessConfigurationSpec{
+				Port:      ptr(int32(8015)),
+				TLS:       ptr(false),
+				Selector:  ptr(""),
+				TLSConfig: &v1beta2.InProcessTLSConfig{CASecret: "ca"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &InProcessConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "app", Annotations: tt.annotations},
+				Spec:       v1beta1Defaults(),
+			}
+			tt.spec(&src.Spec)
+
+			dst := &v1beta2.InProcessConfiguration{}
+			require.Nil(t, src.ConvertTo(dst))
+			require.Equal(t, tt.want, dst.Spec)
+			require.Equal(t, "config", dst.Name)
+			require.NotContains(t, dst.Annotations, ExplicitDefaultsAnnotation)
+		})
+	}
+}
+
+func Test_InProcessConfiguration_ConvertFrom(t *testing.T) {
+	src := &v1beta2.InProcessConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "app"},
+		Spec: v1beta2.InProcessConfigurationSpec{
+			Port:       ptr(int32(33)),
+			TLS:        ptr(false),
+			Cache:      ptr("lru"),
+			SyncServer: &v1beta2.SyncServerReference{Kind: v1beta2.SyncServerKindFlagdProxy, FeatureFlag: "my-flags"},
+		},
+	}
+
+	dst := &InProcessConfiguration{}
+	require.Nil(t, dst.ConvertFrom(src))
+
+	want := v1beta1Defaults()
+	want.Port = 33
+	want.SyncServer = &SyncServerReference{Kind: SyncServerKindFlagdProxy, FeatureFlag: "my-flags"}
+	require.Equal(t, want, dst.Spec)
+	require.Equal(t, "tls,cache", dst.Annotations[ExplicitDefaultsAnnotation])
+
+	// the source is not modified
+	require.Empty(t, src.Annotations)
+}
+
+func Test_InProcessConfiguration_RoundTrip(t *testing.T) {
+	specs := []v1beta2.InProcessConfigurationSpec{
+		{},
+		{
+			Port:                  ptr(int32(8015)),
+			SocketPath:            ptr(""),
+			Host:                  ptr("localhost"),
+			TLS:                   ptr(false),
+			OfflineFlagSourcePath: ptr(""),
+			OfflineFeatureFlag:    ptr(""),
+			Selector:              ptr(""),
+			Cache:                 ptr("lru"),
+			CacheMaxSize:          ptr(int32(1000)),
+			EnvVarPrefix:          ptr("FLAGD"),
+		},
+		{
+			Port:         ptr(int32(33)),
+			Host:         ptr("host"),
+			TLS:          ptr(true),
+			TLSConfig:    &v1beta2.InProcessTLSConfig{CASecret: "ca", ClientCertSecret: "client"},
+			BearerToken:  &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "token"}, Key: "token"},
+			Cache:        ptr("disabled"),
+			EnvVars:      []v1.EnvVar{{Name: "env", Value: "val"}},
+			EnvVarPrefix: ptr(""),
+			SyncServer:   &v1beta2.SyncServerReference{Kind: v1beta2.SyncServerKindFlagd, Name: "flags/central-flagd"},
+		},
+	}
+
+	for _, spec := range specs {
+		hub := &v1beta2.InProcessConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "app", Annotations: map[string]string{"a": "b"}},
+			Spec:       spec,
+		}
+		spoke := &InProcessConfiguration{}
+		require.Nil(t, spoke.ConvertFrom(hub))
+		converted := &v1beta2.InProcessConfiguration{}
+		require.Nil(t, spoke.ConvertTo(converted))
+		require.Equal(t, hub, converted)
+	}
+}
@@ -17,9 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	"fmt"
-
 	"github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	"github.com/open-feature/open-feature-operator/api/core/v1beta2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,7 +30,8 @@ type InProcessConfigurationSpec struct {
 	// +optional
 	Port int32 `json:"port"`
 
-	// SocketPath defines the unix socket path to listen on
+	// SocketPath defines the unix socket path to listen on. The directory of the socket is shared between
+	// the containers of the pod through an emptyDir volume
 	// +optional
 	SocketPath string `json:"socketPath"`
 
@@ -45,10 +45,25 @@ type InProcessConfigurationSpec struct {
 	// +optional
 	TLS bool `json:"tls"`
 
+	// TLSConfig references the Secrets holding the certificates of TLS connections to the sync server. They are mounted
+	// into the app containers and enable TLS
+	// +optional
+	TLSConfig *InProcessTLSConfig `json:"tlsConfig,omitempty"`
+
+	// BearerToken references the key of a Secret of the namespace of the pod holding the bearer token sent to the sync
+	// server. It is provided to the app containers as a Secret-sourced environment variable
+	// +optional
+	BearerToken *corev1.SecretKeySelector `json:"bearerToken,omitempty"`
+
 	// OfflineFlagSourcePath
 	// +optional
 	OfflineFlagSourcePath string `json:"offlineFlagSourcePath"`
 
+	// OfflineFeatureFlag references a FeatureFlag of the namespace of the pod by name. Its flag configuration is mounted
+	// into the app containers and OfflineFlagSourcePath is set to it
+	// +optional
+	OfflineFeatureFlag string `json:"offlineFeatureFlag,omitempty"`
+
 	// Selector
 	// +optional
 	Selector string `json:"selector"`
@@ -73,8 +88,48 @@ type InProcessConfigurationSpec struct {
 	// +optional
 	// +kubebuilder:default:=FLAGD
 	EnvVarPrefix string `json:"envVarPrefix"`
+
+	// SyncServer references a Flagd or the flagd-proxy the in-process providers sync from. The host and the port are
+	// resolved to its Service and replace Host and Port
+	// +optional
+	SyncServer *SyncServerReference `json:"syncServer,omitempty"`
 }
 
+// SyncServerReference references a flag sync server deployed by the operator
+type SyncServerReference struct {
+	// Kind of the sync server, a Flagd or the FlagdProxy
+	// +kubebuilder:validation:Enum=Flagd;FlagdProxy
+	Kind string `json:"kind"`
+
+	// Name of the Flagd as {NAMESPACE}/{NAME} or {NAME}, resolved relative to the namespace of the pod.
+	// Unused for the flagd-proxy
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// FeatureFlag synced from the flagd-proxy as {NAMESPACE}/{NAME} or {NAME}, resolved relative to the namespace of
+	// the pod. Required for the flagd-proxy, the Selector is set to it
+	// +optional
+	FeatureFlag string `json:"featureFlag,omitempty"`
+}
+
+// InProcessTLSConfig references the Secrets of the namespace of the pod holding the certificates of TLS connections to
+// the sync server, following the keys of the Secrets issued by cert-manager
+type InProcessTLSConfig struct {
+	// CASecret names a Secret holding the CA bundle verifying the certificate of the sync server in its ca.crt key
+	// +optional
+	CASecret string `json:"caSecret,omitempty"`
+
+	// ClientCertSecret names a kubernetes.io/tls Secret holding the client certificate in its tls.crt key and the
+	// private key in its tls.key key, for sync servers requiring mutual TLS
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+}
+
+const (
+	SyncServerKindFlagd      = "Flagd"
+	SyncServerKindFlagdProxy = "FlagdProxy"
+)
+
 // InProcessConfigurationStatus defines the observed state of InProcessConfiguration
 type InProcessConfigurationStatus struct {
 }
@@ -128,6 +183,9 @@ func (fc *InProcessConfigurationSpec) Merge(new *InProcessConfigurationSpec) {
 	if new.OfflineFlagSourcePath != "" {
 		fc.OfflineFlagSourcePath = new.OfflineFlagSourcePath
 	}
+	if new.OfflineFeatureFlag != "" {
+		fc.OfflineFeatureFlag = new.OfflineFeatureFlag
+	}
 	if new.Selector != "" {
 		fc.Selector = new.Selector
 	}
@@ -140,77 +198,22 @@ func (fc *InProcessConfigurationSpec) Merge(new *InProcessConfigurationSpec) {
 	if new.TLS != common.DefaultTLS {
 		fc.TLS = new.TLS
 	}
-}
-
-func (fc *InProcessConfigurationSpec) ToEnvVars() []corev1.EnvVar {
-	envs := []corev1.EnvVar{}
-
-	for _, envVar := range fc.EnvVars {
-		newEnvVar := corev1.EnvVar{
-			Name: common.EnvVarKey(fc.EnvVarPrefix, envVar.Name),
-		}
-
-		if envVar.Value != "" {
-			newEnvVar.Value = envVar.Value
-		} else if envVar.ValueFrom != nil {
-			newEnvVar.ValueFrom = envVar.ValueFrom
-		}
-
-		envs = append(envs, newEnvVar)
-	}
-
-	// default values are always included in the envVars
-	envs = append(envs, corev1.EnvVar{
-		Name:  common.EnvVarKey(fc.EnvVarPrefix, common.HostEnvVar),
-		Value: fc.Host,
-	})
-
-	envs = append(envs, corev1.EnvVar{
-		Name:  common.EnvVarKey(fc.EnvVarPrefix, common.PortEnvVar),
-		Value: fmt.Sprintf("%d", fc.Port),
-	})
-
-	envs = append(envs, corev1.EnvVar{
-		Name:  common.EnvVarKey(fc.EnvVarPrefix, common.TLSEnvVar),
-		Value: fmt.Sprintf("%t", fc.TLS),
-	})
-
-	envs = append(envs, corev1.EnvVar{
-		Name:  common.EnvVarKey(fc.EnvVarPrefix, common.CacheEnvVar),
-		Value: fc.Cache,
-	})
-
-	envs = append(envs, corev1.EnvVar{
-		Name:  common.EnvVarKey(fc.EnvVarPrefix, common.CacheMaxSizeEnvVar),
-		Value: fmt.Sprintf("%d", fc.CacheMaxSize),
-	})
-
-	// sets the FLAGD_RESOLVER var to "in-process" to configure the provider for in-process evaluation mode
-	envs = append(envs, corev1.EnvVar{
-		Name:  common.EnvVarKey(fc.EnvVarPrefix, common.ResolverEnvVar),
-		Value: common.InProcessResolverType,
-	})
-
-	if fc.SocketPath != "" {
-		envs = append(envs, corev1.EnvVar{
-			Name:  common.EnvVarKey(fc.EnvVarPrefix, common.SocketPathEnvVar),
-			Value: fc.SocketPath,
-		})
+	if new.TLSConfig != nil {
+		fc.TLSConfig = new.TLSConfig.DeepCopy()
 	}
-
-	if fc.OfflineFlagSourcePath != "" {
-		envs = append(envs, corev1.EnvVar{
-			Name:  common.EnvVarKey(fc.EnvVarPrefix, common.OfflineFlagSourcePathEnvVar),
-			Value: fc.OfflineFlagSourcePath,
-		})
+	if new.BearerToken != nil {
+		fc.BearerToken = new.BearerToken.DeepCopy()
 	}
-
-	if fc.Selector != "" {
-		envs = append(envs, corev1.EnvVar{
-			Name:  common.EnvVarKey(fc.EnvVarPrefix, common.SelectorEnvVar),
-			Value: fc.Selector,
-		})
+	if new.SyncServer != nil {
+		fc.SyncServer = new.SyncServer.DeepCopy()
 	}
+}
 
-	return envs
+// ToEnvVars returns the environment variables configuring the in-process providers, the spec is converted to the
+// v1beta2 hub and uses its implementation
+func (fc *InProcessConfigurationSpec) ToEnvVars() []corev1.EnvVar {
+	hub := &v1beta2.InProcessConfiguration{}
+	// the conversion only fails for another hub type
+	_ = (&InProcessConfiguration{Spec: *fc}).ConvertTo(hub)
+	return hub.Spec.ToEnvVars()
 }
@@ -75,6 +75,13 @@ func Test_InProcessConfiguration_Merge(t *testing.T) {
 			Selector:              "",
 			Cache:                 "lru",
 			CacheMaxSize:          1000,
+			SyncServer:            &SyncServerReference{Kind: SyncServerKindFlagd, Name: "central-flagd"},
+			OfflineFeatureFlag:    "flags/offline",
+			TLSConfig:             &InProcessTLSConfig{CASecret: "sync-ca"},
+			BearerToken: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "sync-token"},
+				Key:                  "token",
+			},
 		},
 	}
 
@@ -89,6 +96,10 @@ func Test_InProcessConfiguration_Merge(t *testing.T) {
 	require.Equal(t, ff_old.Spec.Selector, "selector")
 	require.Equal(t, ff_old.Spec.Cache, "cache")
 	require.Equal(t, ff_old.Spec.CacheMaxSize, 12)
+	require.Equal(t, &SyncServerReference{Kind: SyncServerKindFlagd, Name: "central-flagd"}, ff_old.Spec.SyncServer)
+	require.Equal(t, "flags/offline", ff_old.Spec.OfflineFeatureFlag)
+	require.Equal(t, &InProcessTLSConfig{CASecret: "sync-ca"}, ff_old.Spec.TLSConfig)
+	require.Equal(t, "sync-token", ff_old.Spec.BearerToken.Name)
 	require.Len(t, ff_old.Spec.EnvVars, 3)
 	require.Contains(t, ff_old.Spec.EnvVars, v1.EnvVar{
 		Name:  "env1",
@@ -250,3 +261,32 @@ func Test_InProcessConfiguration_ToEnvVars(t *testing.T) {
 	}
 	require.Equal(t, expected, ff.Spec.ToEnvVars())
 }
+
+func Test_InProcessConfiguration_ToEnvVars_Credentials(t *testing.T) {
+	spec := InProcessConfigurationSpec{
+		EnvVarPrefix: "PRE",
+		TLSConfig: &InProcessTLSConfig{
+			CASecret:         "sync-ca",
+			ClientCertSecret: "sync-client",
+		},
+		BearerToken: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "sync-token"},
+			Key:                  "token",
+		},
+	}
+
+	envVars := spec.ToEnvVars()
+	require.Contains(t, envVars, v1.EnvVar{Name: "PRE_TLS", Value: "true"})
+	require.Contains(t, envVars, v1.EnvVar{Name: "PRE_SERVER_CERT_PATH", Value: "/etc/flagd-tls/ca/ca.crt"})
+	require.Contains(t, envVars, v1.EnvVar{Name: "PRE_CLIENT_CERT_PATH", Value: "/etc/flagd-tls/client/tls.crt"})
+	require.Contains(t, envVars, v1.EnvVar{Name: "PRE_CLIENT_KEY_PATH", Value: "/etc/flagd-tls/client/tls.key"})
+	require.Contains(t, envVars, v1.EnvVar{
+		Name: "PRE_BEARER_TOKEN",
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "sync-token"},
+				Key:                  "token",
+			},
+		},
+	})
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// OperatorConfigurationConditionApplied reports whether the spec of the OperatorConfiguration is applied
+	OperatorConfigurationConditionApplied = "Applied"
+)
+
+// OperatorConfigurationSpec overrides the configuration the operator was started with, unset fields keep the value
+// of the environment variables and flags of the operator
+type OperatorConfigurationSpec struct {
+	// Sidecar configures the flagd containers injected into pods
+	// +optional
+	Sidecar *OperatorSidecarConfiguration `json:"sidecar,omitempty"`
+
+	// FlagdProxy configures the flagd-proxy deployment
+	// +optional
+	FlagdProxy *OperatorFlagdProxyConfiguration `json:"flagdProxy,omitempty"`
+
+	// Flagd configures the deployments of Flagd resources
+	// +optional
+	Flagd *OperatorFlagdConfiguration `json:"flagd,omitempty"`
+
+	// InProcess configures the defaults of InProcessConfigurations
+	// +optional
+	InProcess *OperatorInProcessConfiguration `json:"inProcess,omitempty"`
+
+	// ImagePullSecrets are added to the flagd-proxy and Flagd deployments
+	// +optional
+	ImagePullSecrets []string `json:"imagePullSecrets"`
+
+	// Labels are added to the flagd-proxy and Flagd deployments
+	// +optional
+	Labels map[string]string `json:"labels"`
+
+	// Annotations are added to the flagd-proxy and Flagd deployments
+	// +optional
+	Annotations map[string]string `json:"annotations"`
+}
+
+// OperatorSidecarConfiguration defines the defaults of the injected flagd containers, FeatureFlagSources take
+// precedence over them
+type OperatorSidecarConfiguration struct {
+	// Image of the flagd container
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// Tag of the flagd image
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+
+	// Port defines the port of the evaluation API
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// ManagementPort defines the port of the metrics and health endpoints
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	ManagementPort *int32 `json:"managementPort,omitempty"`
+
+	// SocketPath defines the unix socket path to listen on
+	// +optional
+	SocketPath *string `json:"socketPath,omitempty"`
+
+	// Evaluator defines the evaluator of flagd
+	// +optional
+	Evaluator *string `json:"evaluator,omitempty"`
+
+	// ProviderArgs are string arguments passed to all sync providers
+	// +optional
+	ProviderArgs []string `json:"providerArgs"`
+
+	// DefaultSyncProvider defines the sync provider of sources which do not specify one
+	// +optional
+	DefaultSyncProvider *common.SyncProviderType `json:"defaultSyncProvider,omitempty"`
+
+	// EnvVarPrefix defines the prefix of the environment variables of the flagd container
+	// +optional
+	EnvVarPrefix *string `json:"envVarPrefix,omitempty"`
+
+	// LogFormat defines the log format of flagd
+	// +optional
+	LogFormat *string `json:"logFormat,omitempty"`
+
+	// ProbesEnabled defines whether liveness and readiness probes are added to the flagd container
+	// +optional
+	ProbesEnabled *bool `json:"probesEnabled,omitempty"`
+
+	// Resources of the flagd container, which can be overridden within the bounds of the operator flags by
+	// annotations of the pods
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// OperatorFlagdProxyConfiguration defines the flagd-proxy deployment
+type OperatorFlagdProxyConfiguration struct {
+	// Image of the flagd-proxy
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// Tag of the flagd-proxy image
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+
+	// Port defines the port of the sync API
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// ManagementPort defines the port of the metrics and health endpoints
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	ManagementPort *int32 `json:"managementPort,omitempty"`
+
+	// Replicas of the flagd-proxy deployment
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// DebugLogging enables the debug logs of the flagd-proxy
+	// +optional
+	DebugLogging *bool `json:"debugLogging,omitempty"`
+}
+
+// OperatorFlagdConfiguration defines the deployments of Flagd resources
+type OperatorFlagdConfiguration struct {
+	// Image of flagd
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// Tag of the flagd image
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+
+	// Port defines the port of the evaluation API
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// OFREPPort defines the port of the OFREP API
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	OFREPPort *int32 `json:"ofrepPort,omitempty"`
+
+	// SyncPort defines the port of the sync API
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	SyncPort *int32 `json:"syncPort,omitempty"`
+
+	// ManagementPort defines the port of the metrics and health endpoints
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	ManagementPort *int32 `json:"managementPort,omitempty"`
+
+	// DebugLogging enables the debug logs of flagd
+	// +optional
+	DebugLogging *bool `json:"debugLogging,omitempty"`
+}
+
+// OperatorInProcessConfiguration defines the defaults of InProcessConfigurations, which take precedence over them
+type OperatorInProcessConfiguration struct {
+	// Port defines the port of the in-process provider
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// SocketPath defines the unix socket path of the in-process provider
+	// +optional
+	SocketPath *string `json:"socketPath,omitempty"`
+
+	// Host of the in-process provider
+	// +optional
+	Host *string `json:"host,omitempty"`
+
+	// TLS enables TLS towards the sync API
+	// +optional
+	TLS *bool `json:"tls,omitempty"`
+
+	// OfflineFlagSourcePath
+	// +optional
+	OfflineFlagSourcePath *string `json:"offlineFlagSourcePath,omitempty"`
+
+	// Selector
+	// +optional
+	Selector *string `json:"selector,omitempty"`
+
+	// Cache
+	// +kubebuilder:validation:Pattern="^(lru|disabled)$"
+	// +optional
+	Cache *string `json:"cache,omitempty"`
+
+	// CacheMaxSize
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	CacheMaxSize *int32 `json:"cacheMaxSize,omitempty"`
+
+	// EnvVarPrefix defines the prefix of the environment variables of the in-process provider
+	// +optional
+	EnvVarPrefix *string `json:"envVarPrefix,omitempty"`
+}
+
+// OperatorConfigurationStatus defines the observed state of OperatorConfiguration
+type OperatorConfigurationStatus struct {
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Effective is the configuration applied by the operator, the spec merged with the environment variables and
+	// flags of the operator. An invalid spec is not applied, the previous configuration stays in effect.
+	// +optional
+	Effective *OperatorConfigurationSpec `json:"effective,omitempty"`
+
+	// Conditions report whether the spec is applied, validation errors are reported in the Applied condition
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Applied",type=string,JSONPath=`.status.conditions[?(@.type=="Applied")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OperatorConfiguration is the Schema for the operatorconfigurations API, the operator only applies the
+// OperatorConfiguration with the configured name in its own namespace
+type OperatorConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorConfigurationSpec   `json:"spec,omitempty"`
+	Status OperatorConfigurationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OperatorConfigurationList contains a list of OperatorConfiguration
+type OperatorConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OperatorConfiguration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OperatorConfiguration{}, &OperatorConfigurationList{})
+}
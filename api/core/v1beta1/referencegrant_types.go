@@ -0,0 +1,119 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReferenceGrantSpec defines the references from other namespaces allowed to the resources of the namespace
+// holding the ReferenceGrant
+type ReferenceGrantSpec struct {
+	// From lists the workloads in other namespaces which may reference the resources listed in To
+	// +kubebuilder:validation:MinItems=1
+	From []ReferenceGrantFrom `json:"from"`
+
+	// To lists the resources of this namespace which may be referenced
+	// +kubebuilder:validation:MinItems=1
+	To []ReferenceGrantTo `json:"to"`
+}
+
+// ReferenceGrantFrom describes the workloads which may reference resources of the namespace of the ReferenceGrant
+type ReferenceGrantFrom struct {
+	// Group of the referencing workload, empty for the core API group
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind of the referencing workload, a Pod injected with flagd or a Flagd
+	// +kubebuilder:validation:Enum=Pod;Flagd
+	Kind string `json:"kind"`
+
+	// Namespace of the referencing workload
+	Namespace string `json:"namespace"`
+}
+
+// ReferenceGrantTo describes the resources which may be referenced from other namespaces
+type ReferenceGrantTo struct {
+	// Group of the referenced resource
+	// +kubebuilder:default:="core.openfeature.dev"
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind of the referenced resource
+	// +kubebuilder:validation:Enum=FeatureFlagSource;InProcessConfiguration;FeatureFlag;Flagd
+	Kind string `json:"kind"`
+
+	// Name of the referenced resource, all resources of the kind may be referenced if omitted
+	// +optional
+	Name *string `json:"name,omitempty"`
+}
+
+// ReferenceGrantStatus defines the observed state of ReferenceGrant
+type ReferenceGrantStatus struct {
+}
+
+//+kubebuilder:resource:shortName="rg"
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// ReferenceGrant is the Schema for the referencegrants API
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReferenceGrantSpec   `json:"spec,omitempty"`
+	Status ReferenceGrantStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReferenceGrantList contains a list of ReferenceGrant
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReferenceGrant{}, &ReferenceGrantList{})
+}
+
+// Allows reports whether the grant allows a workload of the given group and kind in the given namespace to reference
+// the resource of the given group, kind and name
+func (rg *ReferenceGrant) Allows(fromGroup, fromKind, fromNamespace, toGroup, toKind, toName string) bool {
+	fromAllowed := false
+	for _, from := range rg.Spec.From {
+		if from.Group == fromGroup && from.Kind == fromKind && from.Namespace == fromNamespace {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+	for _, to := range rg.Spec.To {
+		group := to.Group
+		if group == "" {
+			group = GroupVersion.Group
+		}
+		if group == toGroup && to.Kind == toKind && (to.Name == nil || *to.Name == toName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReferenceGrant_Allows(t *testing.T) {
+	name := "my-flags"
+	rg := &ReferenceGrant{
+		Spec: ReferenceGrantSpec{
+			From: []ReferenceGrantFrom{
+				{Kind: "Pod", Namespace: "app"},
+			},
+			To: []ReferenceGrantTo{
+				{Kind: "FeatureFlag", Name: &name},
+				{Group: "core.openfeature.dev", Kind: "FeatureFlagSource"},
+			},
+		},
+	}
+
+	require.True(t, rg.Allows("", "Pod", "app", "core.openfeature.dev", "FeatureFlag", "my-flags"))
+	require.True(t, rg.Allows("", "Pod", "app", "core.openfeature.dev", "FeatureFlagSource", "any"))
+	require.False(t, rg.Allows("", "Pod", "app", "core.openfeature.dev", "FeatureFlag", "other"))
+	require.False(t, rg.Allows("", "Pod", "other", "core.openfeature.dev", "FeatureFlag", "my-flags"))
+	require.False(t, rg.Allows("core.openfeature.dev", "Flagd", "app", "core.openfeature.dev", "FeatureFlag", "my-flags"))
+}
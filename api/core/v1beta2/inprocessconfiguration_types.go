@@ -0,0 +1,297 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+
+	"github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InProcessConfigurationSpec defines the desired state of InProcessConfiguration. Unset fields are inherited from the
+// InProcessConfigurations referenced before it and the defaults of the operator, a field set to its zero value
+// overrides them.
+type InProcessConfigurationSpec struct {
+	// Port defines the port of the sync server, defaults to 8015
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// SocketPath defines the unix socket path to listen on. The directory of the socket is shared between
+	// the containers of the pod through an emptyDir volume
+	// +optional
+	SocketPath *string `json:"socketPath,omitempty"`
+
+	// Host of the sync server, defaults to localhost
+	// +optional
+	Host *string `json:"host,omitempty"`
+
+	// TLS enables TLS towards the sync server, defaults to false
+	// +optional
+	TLS *bool `json:"tls,omitempty"`
+
+	// TLSConfig references the Secrets holding the certificates of TLS connections to the sync server. They are mounted
+	// into the app containers, TLS defaults to true when it is set
+	// +optional
+	TLSConfig *InProcessTLSConfig `json:"tlsConfig,omitempty"`
+
+	// BearerToken references the key of a Secret of the namespace of the pod holding the bearer token sent to the sync
+	// server. It is provided to the app containers as a Secret-sourced environment variable
+	// +optional
+	BearerToken *corev1.SecretKeySelector `json:"bearerToken,omitempty"`
+
+	// OfflineFlagSourcePath defines the path of a flag configuration file evaluated offline
+	// +optional
+	OfflineFlagSourcePath *string `json:"offlineFlagSourcePath,omitempty"`
+
+	// OfflineFeatureFlag references a FeatureFlag of the namespace of the pod by name. Its flag configuration is mounted
+	// into the app containers and OfflineFlagSourcePath is set to it
+	// +optional
+	OfflineFeatureFlag *string `json:"offlineFeatureFlag,omitempty"`
+
+	// Selector of the flags synced from the sync server
+	// +optional
+	Selector *string `json:"selector,omitempty"`
+
+	// Cache defines the cache of evaluated flags, defaults to lru
+	// +kubebuilder:validation:Pattern="^(lru|disabled)$"
+	// +optional
+	Cache *string `json:"cache,omitempty"`
+
+	// CacheMaxSize defines the maximum number of cached evaluations, defaults to 1000
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	CacheMaxSize *int32 `json:"cacheMaxSize,omitempty"`
+
+	// EnvVars are added to the app containers, variables replace the variables of the same name of the
+	// InProcessConfigurations referenced before
+	// +optional
+	EnvVars []corev1.EnvVar `json:"envVars,omitempty"`
+
+	// EnvVarPrefix defines the prefix to be applied to all environment variables applied to the app containers,
+	// defaults to FLAGD
+	// +optional
+	EnvVarPrefix *string `json:"envVarPrefix,omitempty"`
+
+	// SyncServer references a Flagd or the flagd-proxy the in-process providers sync from. The host and the port are
+	// resolved to its Service and replace Host and Port
+	// +optional
+	SyncServer *SyncServerReference `json:"syncServer,omitempty"`
+}
+
+// InProcessTLSConfig references the Secrets of the namespace of the pod holding the certificates of TLS connections to
+// the sync server, following the keys of the Secrets issued by cert-manager
+type InProcessTLSConfig struct {
+	// CASecret names a Secret holding the CA bundle verifying the certificate of the sync server in its ca.crt key
+	// +optional
+	CASecret string `json:"caSecret,omitempty"`
+
+	// ClientCertSecret names a kubernetes.io/tls Secret holding the client certificate in its tls.crt key and the
+	// private key in its tls.key key, for sync servers requiring mutual TLS
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+}
+
+// SyncServerReference references a flag sync server deployed by the operator
+type SyncServerReference struct {
+	// Kind of the sync server, a Flagd or the FlagdProxy
+	// +kubebuilder:validation:Enum=Flagd;FlagdProxy
+	Kind string `json:"kind"`
+
+	// Name of the Flagd as {NAMESPACE}/{NAME} or {NAME}, resolved relative to the namespace of the pod.
+	// Unused for the flagd-proxy
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// FeatureFlag synced from the flagd-proxy as {NAMESPACE}/{NAME} or {NAME}, resolved relative to the namespace of
+	// the pod. Required for the flagd-proxy, the Selector is set to it
+	// +optional
+	FeatureFlag string `json:"featureFlag,omitempty"`
+}
+
+const (
+	SyncServerKindFlagd      = "Flagd"
+	SyncServerKindFlagdProxy = "FlagdProxy"
+)
+
+// InProcessConfigurationStatus defines the observed state of InProcessConfiguration
+type InProcessConfigurationStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// InProcessConfiguration is the Schema for the inprocesconfigurations API
+type InProcessConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InProcessConfigurationSpec   `json:"spec,omitempty"`
+	Status InProcessConfigurationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// InProcessConfigurationList contains a list of InProcessConfiguration
+type InProcessConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InProcessConfiguration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InProcessConfiguration{}, &InProcessConfigurationList{})
+}
+
+// Merge merges new on top of the spec following the layered merge of the common package, the fields set in new
+// replace the fields of the spec
+func (fc *InProcessConfigurationSpec) Merge(new *InProcessConfigurationSpec) {
+	if new == nil {
+		return
+	}
+	common.MergeEnvVars(&fc.EnvVars, new.EnvVars)
+	common.MergePointer(&fc.Port, new.Port)
+	common.MergePointer(&fc.SocketPath, new.SocketPath)
+	common.MergePointer(&fc.Host, new.Host)
+	common.MergePointer(&fc.EnvVarPrefix, new.EnvVarPrefix)
+	common.MergePointer(&fc.OfflineFlagSourcePath, new.OfflineFlagSourcePath)
+	common.MergePointer(&fc.OfflineFeatureFlag, new.OfflineFeatureFlag)
+	common.MergePointer(&fc.Selector, new.Selector)
+	common.MergePointer(&fc.Cache, new.Cache)
+	common.MergePointer(&fc.CacheMaxSize, new.CacheMaxSize)
+	common.MergePointer(&fc.TLS, new.TLS)
+	if new.TLSConfig != nil {
+		fc.TLSConfig = new.TLSConfig.DeepCopy()
+	}
+	if new.BearerToken != nil {
+		fc.BearerToken = new.BearerToken.DeepCopy()
+	}
+	if new.SyncServer != nil {
+		fc.SyncServer = new.SyncServer.DeepCopy()
+	}
+}
+
+// ToEnvVars returns the environment variables configuring the in-process providers, unset fields are replaced by
+// the defaults of the package
+func (fc *InProcessConfigurationSpec) ToEnvVars() []corev1.EnvVar {
+	envs := []corev1.EnvVar{}
+	prefix := valueOrDefault(fc.EnvVarPrefix, common.DefaultEnvVarPrefix)
+
+	for _, envVar := range fc.EnvVars {
+		newEnvVar := corev1.EnvVar{
+			Name: common.EnvVarKey(prefix, envVar.Name),
+		}
+
+		if envVar.Value != "" {
+			newEnvVar.Value = envVar.Value
+		} else if envVar.ValueFrom != nil {
+			newEnvVar.ValueFrom = envVar.ValueFrom
+		}
+
+		envs = append(envs, newEnvVar)
+	}
+
+	// default values are always included in the envVars
+	envs = append(envs, corev1.EnvVar{
+		Name:  common.EnvVarKey(prefix, common.HostEnvVar),
+		Value: valueOrDefault(fc.Host, common.DefaultHost),
+	})
+
+	envs = append(envs, corev1.EnvVar{
+		Name:  common.EnvVarKey(prefix, common.PortEnvVar),
+		Value: fmt.Sprintf("%d", valueOrDefault(fc.Port, common.DefaultInProcessPort)),
+	})
+
+	envs = append(envs, corev1.EnvVar{
+		Name:  common.EnvVarKey(prefix, common.TLSEnvVar),
+		Value: fmt.Sprintf("%t", valueOrDefault(fc.TLS, fc.TLSConfig != nil)),
+	})
+
+	envs = append(envs, corev1.EnvVar{
+		Name:  common.EnvVarKey(prefix, common.CacheEnvVar),
+		Value: valueOrDefault(fc.Cache, common.DefaultCache),
+	})
+
+	envs = append(envs, corev1.EnvVar{
+		Name:  common.EnvVarKey(prefix, common.CacheMaxSizeEnvVar),
+		Value: fmt.Sprintf("%d", valueOrDefault(fc.CacheMaxSize, common.DefaultCacheMaxSize)),
+	})
+
+	// sets the FLAGD_RESOLVER var to "in-process" to configure the provider for in-process evaluation mode
+	envs = append(envs, corev1.EnvVar{
+		Name:  common.EnvVarKey(prefix, common.ResolverEnvVar),
+		Value: common.InProcessResolverType,
+	})
+
+	if socketPath := valueOrDefault(fc.SocketPath, ""); socketPath != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  common.EnvVarKey(prefix, common.SocketPathEnvVar),
+			Value: socketPath,
+		})
+	}
+
+	if offlineFlagSourcePath := valueOrDefault(fc.OfflineFlagSourcePath, ""); offlineFlagSourcePath != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  common.EnvVarKey(prefix, common.OfflineFlagSourcePathEnvVar),
+			Value: offlineFlagSourcePath,
+		})
+	}
+
+	if selector := valueOrDefault(fc.Selector, ""); selector != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  common.EnvVarKey(prefix, common.SelectorEnvVar),
+			Value: selector,
+		})
+	}
+
+	// the Secrets referenced by the TLS configuration are mounted by the webhook
+	if fc.TLSConfig != nil && fc.TLSConfig.CASecret != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  common.EnvVarKey(prefix, common.ServerCertPathEnvVar),
+			Value: fmt.Sprintf("%s/%s", common.InProcessCAMountPath, corev1.ServiceAccountRootCAKey),
+		})
+	}
+	if fc.TLSConfig != nil && fc.TLSConfig.ClientCertSecret != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  common.EnvVarKey(prefix, common.ClientCertPathEnvVar),
+			Value: fmt.Sprintf("%s/%s", common.InProcessClientCertMountPath, corev1.TLSCertKey),
+		}, corev1.EnvVar{
+			Name:  common.EnvVarKey(prefix, common.ClientKeyPathEnvVar),
+			Value: fmt.Sprintf("%s/%s", common.InProcessClientCertMountPath, corev1.TLSPrivateKeyKey),
+		})
+	}
+
+	if fc.BearerToken != nil {
+		envs = append(envs, corev1.EnvVar{
+			Name:      common.EnvVarKey(prefix, common.BearerTokenEnvVar),
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: fc.BearerToken.DeepCopy()},
+		})
+	}
+
+	return envs
+}
+
+func valueOrDefault[T any](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
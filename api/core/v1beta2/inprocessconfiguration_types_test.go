@@ -0,0 +1,195 @@
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// defaults mirrors the bottom layer of the operator, every field is set
+func defaults() *InProcessConfigurationSpec {
+	return &InProcessConfigurationSpec{
+		Port:                  ptr(int32(8015)),
+		SocketPath:            ptr(""),
+		Host:                  ptr("localhost"),
+		TLS:                   ptr(false),
+		OfflineFlagSourcePath: ptr(""),
+		Selector:              ptr(""),
+		Cache:                 ptr("lru"),
+		CacheMaxSize:          ptr(int32(1000)),
+		EnvVarPrefix:          ptr("FLAGD"),
+	}
+}
+
+func Test_InProcessConfiguration_Merge(t *testing.T) {
+	tests := []struct {
+		name   string
+		layers []*InProcessConfigurationSpec
+		want   func(spec *InProcessConfigurationSpec)
+	}{
+		{
+			name:   "no layers",
+			layers: nil,
+			want:   func(spec *InProcessConfigurationSpec) {},
+		},
+		{
+			name:   "unset fields are inherited",
+			layers: []*InProcessConfigurationSpec{{}, nil},
+			want:   func(spec *InProcessConfigurationSpec) {},
+		},
+		{
+			name: "last layer takes precedence",
+			layers: []*InProcessConfigurationSpec{
+				{Host: ptr("first"), Port: ptr(int32(1))},
+				{Host: ptr("second")},
+			},
+			want: func(spec *InProcessConfigurationSpec) {
+				spec.Host = ptr("second")
+				spec.Port = ptr(int32(1))
+			},
+		},
+		{
+			name: "layer resets a value to the default",
+			layers: []*InProcessConfigurationSpec{
+				{TLS: ptr(true), Cache: ptr("disabled"), EnvVarPrefix: ptr("APP")},
+				{TLS: ptr(false), Cache: ptr("lru"), EnvVarPrefix: ptr("FLAGD")},
+			},
+			want: func(spec *InProcessConfigurationSpec) {},
+		},
+		{
+			name: "layer resets a value to the zero value",
+			layers: []*InProcessConfigurationSpec{
+				{Selector: ptr("core.openfeature.dev/flags/my-flags"), SocketPath: ptr("/var/run/flagd/flagd.sock")},
+				{Selector: ptr("")},
+			},
+			want: func(spec *InProcessConfigurationSpec) {
+				spec.SocketPath = ptr("/var/run/flagd/flagd.sock")
+			},
+		},
+		{
+			name: "references are replaced as a whole",
+			layers: []*InProcessConfigurationSpec{
+				{
+					SyncServer: &SyncServerReference{Kind: SyncServerKindFlagd, Name: "central-flagd"},
+					TLSConfig:  &InProcessTLSConfig{CASecret: "ca", ClientCertSecret: "client"},
+				},
+				{
+					SyncServer: &SyncServerReference{Kind: SyncServerKindFlagdProxy, FeatureFlag: "my-flags"},
+					TLSConfig:  &InProcessTLSConfig{CASecret: "other-ca"},
+				},
+			},
+			want: func(spec *InProcessConfigurationSpec) {
+				spec.SyncServer = &SyncServerReference{Kind: SyncServerKindFlagdProxy, FeatureFlag: "my-flags"}
+				spec.TLSConfig = &InProcessTLSConfig{CASecret: "other-ca"}
+			},
+		},
+		{
+			name: "environment variables are merged by name",
+			layers: []*InProcessConfigurationSpec{
+				{EnvVars: []corev1.EnvVar{{Name: "a", Value: "first"}}},
+				{EnvVars: []corev1.EnvVar{{Name: "a", Value: "second"}}},
+			},
+			want: func(spec *InProcessConfigurationSpec) {
+				spec.EnvVars = []corev1.EnvVar{{Name: "a", Value: "second"}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := defaults()
+			for _, layer := range tt.layers {
+				spec.Merge(layer)
+			}
+			want := defaults()
+			tt.want(want)
+			require.Equal(t, want, spec)
+		})
+	}
+}
+
+func Test_InProcessConfiguration_Merge_DoesNotAlias(t *testing.T) {
+	spec := defaults()
+	layer := &InProcessConfigurationSpec{
+		Host:       ptr("host"),
+		SyncServer: &SyncServerReference{Kind: SyncServerKindFlagd, Name: "central-flagd"},
+	}
+	spec.Merge(layer)
+
+	*layer.Host = "changed"
+	layer.SyncServer.Name = "changed"
+	require.Equal(t, "host", *spec.Host)
+	require.Equal(t, "central-flagd", spec.SyncServer.Name)
+}
+
+func Test_InProcessConfiguration_ToEnvVars(t *testing.T) {
+	tests := []struct {
+		name string
+		spec InProcessConfigurationSpec
+		want []corev1.EnvVar
+	}{
+		{
+			name: "unset fields use the defaults",
+			spec: InProcessConfigurationSpec{},
+			want: []corev1.EnvVar{
+				{Name: "FLAGD_HOST", Value: "localhost"},
+				{Name: "FLAGD_PORT", Value: "8015"},
+				{Name: "FLAGD_TLS", Value: "false"},
+				{Name: "FLAGD_CACHE", Value: "lru"},
+				{Name: "FLAGD_MAX_CACHE_SIZE", Value: "1000"},
+				{Name: "FLAGD_RESOLVER", Value: "in-process"},
+			},
+		},
+		{
+			name: "set fields",
+			spec: InProcessConfigurationSpec{
+				EnvVars:               []corev1.EnvVar{{Name: "env", Value: "val"}},
+				EnvVarPrefix:          ptr("PRE"),
+				Host:                  ptr("host"),
+				Port:                  ptr(int32(33)),
+				TLS:                   ptr(true),
+				Cache:                 ptr("disabled"),
+				CacheMaxSize:          ptr(int32(12)),
+				SocketPath:            ptr("/var/run/flagd/flagd.sock"),
+				OfflineFlagSourcePath: ptr("/etc/flagd/flags.json"),
+				Selector:              ptr("selector"),
+			},
+			want: []corev1.EnvVar{
+				{Name: "PRE_env", Value: "val"},
+				{Name: "PRE_HOST", Value: "host"},
+				{Name: "PRE_PORT", Value: "33"},
+				{Name: "PRE_TLS", Value: "true"},
+				{Name: "PRE_CACHE", Value: "disabled"},
+				{Name: "PRE_MAX_CACHE_SIZE", Value: "12"},
+				{Name: "PRE_RESOLVER", Value: "in-process"},
+				{Name: "PRE_SOCKET_PATH", Value: "/var/run/flagd/flagd.sock"},
+				{Name: "PRE_OFFLINE_FLAG_SOURCE_PATH", Value: "/etc/flagd/flags.json"},
+				{Name: "PRE_SOURCE_SELECTOR", Value: "selector"},
+			},
+		},
+		{
+			name: "empty prefix",
+			spec: InProcessConfigurationSpec{EnvVarPrefix: ptr(""), TLS: ptr(false), TLSConfig: &InProcessTLSConfig{CASecret: "ca"}},
+			want: []corev1.EnvVar{
+				{Name: "HOST", Value: "localhost"},
+				{Name: "PORT", Value: "8015"},
+				{Name: "TLS", Value: "false"},
+				{Name: "CACHE", Value: "lru"},
+				{Name: "MAX_CACHE_SIZE", Value: "1000"},
+				{Name: "RESOLVER", Value: "in-process"},
+				{Name: "SERVER_CERT_PATH", Value: "/etc/flagd-tls/ca/ca.crt"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.spec.ToEnvVars())
+		})
+	}
+}
@@ -8,4 +8,44 @@ provided in .Values.defaultNamespace is used
 {{- else -}}
 {{- .Release.Namespace -}}
 {{- end -}}
-{{- end -}}
\ No newline at end of file
+{{- end -}}
+{{/*
+Define the namespaceSelector of the mutating webhook. Namespaces with the fail-closed admission policy are served by the
+copy of the configuration maintained by the operator, and an operator restricted to .Values.watchNamespaces only
+receives the admissions of these namespaces
+*/}}
+{{- define "chart.mutatingWebhookNamespaceSelector" -}}
+matchExpressions:
+- key: openfeature.dev/admission-policy
+  operator: NotIn
+  values:
+  - fail-closed
+{{- if .Values.watchNamespaces }}
+- key: kubernetes.io/metadata.name
+  operator: In
+  values:
+{{- toYaml .Values.watchNamespaces | nindent 2 }}
+{{- end }}
+{{- end -}}
+{{/*
+Define the namespaceSelector of the Deployment webhook, which labels the Deployments of .Values.watchNamespaces or of all
+namespaces
+*/}}
+{{- define "chart.watchNamespacesSelector" -}}
+{{- if .Values.watchNamespaces -}}
+matchExpressions:
+- key: kubernetes.io/metadata.name
+  operator: In
+  values:
+{{- toYaml .Values.watchNamespaces | nindent 2 }}
+{{- else -}}
+{}
+{{- end }}
+{{- end -}}
+{{/*
+Define the namespaces an operator restricted to .Values.watchNamespaces is granted its roles in, the watched namespaces
+and the namespace of the operator, which holds the flagd-proxy and the OperatorConfiguration
+*/}}
+{{- define "chart.rbacNamespaces" -}}
+{{- append .Values.watchNamespaces (include "chart.namespace" .) | uniq | toYaml }}
+{{- end -}}
@@ -25,34 +25,49 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	corev1beta1 "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	corev1beta2 "github.com/open-feature/open-feature-operator/api/core/v1beta2"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdinjector"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/metrics"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
 	"github.com/open-feature/open-feature-operator/internal/common/types"
 	"github.com/open-feature/open-feature-operator/internal/common/utils"
+	"github.com/open-feature/open-feature-operator/internal/common/webhookcert"
+	"github.com/open-feature/open-feature-operator/internal/controller/admissionregistration/failclosed"
 	"github.com/open-feature/open-feature-operator/internal/controller/core/featureflagsource"
 	"github.com/open-feature/open-feature-operator/internal/controller/core/flagd"
 	flagdResources "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/resources"
+	"github.com/open-feature/open-feature-operator/internal/controller/core/inprocessconfiguration"
+	"github.com/open-feature/open-feature-operator/internal/controller/core/operatorconfiguration"
+	"github.com/open-feature/open-feature-operator/internal/controller/core/pod"
+	"github.com/open-feature/open-feature-operator/internal/controller/rbac/kubernetessync"
 	webhooks "github.com/open-feature/open-feature-operator/internal/webhook"
 	"go.uber.org/zap/zapcore"
-	appsV1 "k8s.io/api/apps/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -79,6 +94,11 @@ const (
 	sidecarRamRequestFlagName = "sidecar-ram-request"
 	sidecarRamRequestDefault  = "32M"
 
+	sidecarCpuMinFlagName = "sidecar-cpu-min"
+	sidecarCpuMaxFlagName = "sidecar-cpu-max"
+	sidecarRamMinFlagName = "sidecar-ram-min"
+	sidecarRamMaxFlagName = "sidecar-ram-max"
+
 	imagePullSecretFlagName    = "image-pull-secrets"
 	imagePullSecretFlagDefault = ""
 
@@ -87,6 +107,15 @@ const (
 
 	annotationsFlagName    = "annotations"
 	annotationsFlagDefault = ""
+
+	webhookCertSelfManagedFlagName = "webhook-cert-self-managed"
+	webhookCertSecretNameFlagName  = "webhook-cert-secret-name"
+	webhookCertSecretNameDefault   = "open-feature-operator-webhook-server-cert"
+	webhookServiceNameFlagName     = "webhook-service-name"
+	webhookServiceNameDefault      = "open-feature-operator-webhook-service"
+	validatingWebhookConfiguration = "open-feature-operator-validating-webhook-configuration"
+
+	watchNamespacesFlagName = "watch-namespaces"
 )
 
 var (
@@ -95,6 +124,8 @@ var (
 	metricsAddr                                                            string
 	metricsCertPath, metricsCertName, metricsCertKey                       string
 	webhookCertPath, webhookCertName, webhookCertKey                       string
+	webhookCertSelfManaged                                                 bool
+	webhookCertSecretName, webhookServiceName                              string
 	secureMetrics                                                          bool
 	enableHTTP2                                                            bool
 	tlsOpts                                                                []func(*tls.Config)
@@ -102,9 +133,11 @@ var (
 	probeAddr                                                              string
 	verbose                                                                bool
 	sidecarCpuLimit, sidecarRamLimit, sidecarCpuRequest, sidecarRamRequest string
+	sidecarCpuMin, sidecarCpuMax, sidecarRamMin, sidecarRamMax             string
 	imagePullSecrets                                                       string
 	labels                                                                 string
 	annotations                                                            string
+	watchNamespaces                                                        string
 )
 
 // StringToMap transforms a string into a map[string]string
@@ -130,7 +163,9 @@ func CommaSeparatedStringToSlice(s string) []string {
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(corev1beta1.AddToScheme(scheme))
+	utilruntime.Must(corev1beta2.AddToScheme(scheme))
 	utilruntime.Must(gatewayApiv1.Install(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -153,6 +188,12 @@ func main() {
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.BoolVar(&webhookCertSelfManaged, webhookCertSelfManagedFlagName, false,
+		"If set, the operator generates and rotates the webhook certificates itself instead of reading them from --webhook-cert-path.")
+	flag.StringVar(&webhookCertSecretName, webhookCertSecretNameFlagName, webhookCertSecretNameDefault,
+		"The name of the secret in the operator namespace holding the self-managed webhook certificates.")
+	flag.StringVar(&webhookServiceName, webhookServiceNameFlagName, webhookServiceNameDefault,
+		"The name of the service in the operator namespace the self-managed webhook certificate is issued for.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
@@ -165,9 +206,14 @@ func main() {
 	flag.StringVar(&sidecarRamLimit, sidecarRamLimitFlagName, sidecarRamLimitDefault, "sidecar memory limit, in bytes. (500Gi = 500GiB = 500 * 1024 * 1024 * 1024)")
 	flag.StringVar(&sidecarCpuRequest, sidecarCpuRequestFlagName, sidecarCpuRequestDefault, "sidecar CPU minimum, in cores. (500m = .5 cores)")
 	flag.StringVar(&sidecarRamRequest, sidecarRamRequestFlagName, sidecarRamRequestDefault, "sidecar memory minimum, in bytes. (500Gi = 500GiB = 500 * 1024 * 1024 * 1024)")
+	flag.StringVar(&sidecarCpuMin, sidecarCpuMinFlagName, "", "minimum sidecar CPU which can be set via pod annotations, in cores. Unbounded if empty")
+	flag.StringVar(&sidecarCpuMax, sidecarCpuMaxFlagName, "", "maximum sidecar CPU which can be set via pod annotations, in cores. Unbounded if empty")
+	flag.StringVar(&sidecarRamMin, sidecarRamMinFlagName, "", "minimum sidecar memory which can be set via pod annotations, in bytes. Unbounded if empty")
+	flag.StringVar(&sidecarRamMax, sidecarRamMaxFlagName, "", "maximum sidecar memory which can be set via pod annotations, in bytes. Unbounded if empty")
 	flag.StringVar(&imagePullSecrets, imagePullSecretFlagName, imagePullSecretFlagDefault, "Comma-delimited list of secrets containing credentials to pull images.")
 	flag.StringVar(&labels, labelsFlagName, labelsFlagDefault, "Map of labels to add to the deployed pods. Formatted like key1:value1,key2:value2,key3:value3")
 	flag.StringVar(&annotations, annotationsFlagName, annotationsFlagDefault, "Map of annotations to add to the deployed pods. Formatted like key1:value1,key2:value2,key3:value3")
+	flag.StringVar(&watchNamespaces, watchNamespacesFlagName, "", "Comma-delimited list of namespaces the operator is restricted to, without cluster-scoped permissions. Watches all namespaces if empty.")
 
 	flag.Parse()
 
@@ -204,7 +250,34 @@ func main() {
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
-	if len(webhookCertPath) > 0 {
+	namespaces := CommaSeparatedStringToSlice(watchNamespaces)
+	if len(namespaces) > 0 && webhookCertSelfManaged {
+		// the CA bundle is injected into cluster-scoped webhook configurations and CustomResourceDefinitions
+		setupLog.Error(fmt.Errorf("--%s requires cluster-scoped permissions", webhookCertSelfManagedFlagName),
+			"invalid flags", watchNamespacesFlagName, watchNamespaces)
+		os.Exit(1)
+	}
+
+	var webhookCertRotator *webhookcert.Rotator
+	if webhookCertSelfManaged {
+		setupLog.Info("Initializing self-managed webhook certificates",
+			webhookCertSecretNameFlagName, webhookCertSecretName, webhookServiceNameFlagName, webhookServiceName)
+
+		webhookCertRotator = &webhookcert.Rotator{
+			Log:           ctrl.Log.WithName("webhook-cert-rotator"),
+			Secret:        ctrlclient.ObjectKey{Namespace: env.PodNamespace, Name: webhookCertSecretName},
+			Service:       ctrlclient.ObjectKey{Namespace: env.PodNamespace, Name: webhookServiceName},
+			ClusterDomain: env.FlagdClusterDomain,
+			MutatingWebhookConfigurations: []string{
+				env.MutatingWebhookConfigurationName,
+				env.MutatingWebhookConfigurationName + failclosed.NameSuffix,
+			},
+			ValidatingWebhookConfigurations: []string{validatingWebhookConfiguration},
+		}
+		webhookTLSOpts = append(webhookTLSOpts, func(config *tls.Config) {
+			config.GetCertificate = webhookCertRotator.GetCertificate
+		})
+	} else if len(webhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
 			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
 
@@ -277,10 +350,30 @@ func main() {
 		os.Exit(1)
 	}
 
-	disableCacheFor := []client.Object{&v1.ClusterRoleBinding{}}
+	resourceBounds, err := processResourceBounds()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	// Deployments are only watched as metadata, the few Deployments whose spec or status is read, those of Flagd
+	// resources and the flagd-proxy and the ones restarted on changes of their FeatureFlagSource, are read from the API
+	// server instead of caching the full objects
+	disableCacheFor := []client.Object{&v1.ClusterRoleBinding{}, &appsv1.Deployment{}}
+
+	// only the pods and Deployments related to OpenFeature are cached
+	cacheOptions := cache.Options{ByObject: cachelabel.ByObject()}
+	if len(namespaces) > 0 {
+		setupLog.Info("Restricting the operator to namespaces", watchNamespacesFlagName, watchNamespaces)
+		// the operator namespace holds the flagd-proxy and the OperatorConfiguration
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{env.PodNamespace: {}}
+		for _, ns := range namespaces {
+			cacheOptions.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOptions,
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
@@ -316,39 +409,20 @@ func main() {
 		}
 	}
 
-	// setup indexer for backfilling permissions on the flagd-kubernetes-sync role binding
-	if err := mgr.GetFieldIndexer().IndexField(
-		context.Background(),
-		&corev1.Pod{},
-		fmt.Sprintf("%s/%s", common.PodOpenFeatureAnnotationPath, common.AllowKubernetesSyncAnnotation),
-		webhooks.OpenFeatureEnabledAnnotationIndex,
-	); err != nil {
-		setupLog.Error(
-			err,
-			"unable to create indexer",
-			"webhook",
-			fmt.Sprintf("%s/%s", common.PodOpenFeatureAnnotationPath, common.AllowKubernetesSyncAnnotation),
-		)
-		os.Exit(1)
-	}
-
-	if err := mgr.GetFieldIndexer().IndexField(
-		context.Background(),
-		&appsV1.Deployment{},
-		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPath, common.FeatureFlagSourceAnnotation),
-		common.FeatureFlagSourceIndex,
-	); err != nil {
-		setupLog.Error(
-			err,
-			"unable to create indexer",
-			"webhook",
-			fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPath, common.FeatureFlagSourceAnnotation),
-		)
-		os.Exit(1)
+	if webhookCertRotator != nil {
+		// the certificates are read without the cache, so the operator does not watch all Secrets of its namespace
+		webhookCertRotator.Client = mgr.GetClient()
+		webhookCertRotator.Reader = mgr.GetAPIReader()
+		setupLog.Info("Adding webhook certificate rotator to manager")
+		if err := mgr.Add(webhookCertRotator); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate rotator to manager")
+			os.Exit(1)
+		}
 	}
 
 	labelsMap := StringToMap(labels)
 	annotationsMap := StringToMap(annotations)
+	recorder := mgr.GetEventRecorderFor(common.EventRecorderName)
 
 	kph := flagdproxy.NewFlagdProxyHandler(
 		flagdproxy.NewFlagdProxyConfiguration(
@@ -360,6 +434,15 @@ func main() {
 		mgr.GetClient(),
 		ctrl.Log.WithName("FeatureFlagSource FlagdProxyHandler"),
 	)
+	// the operator Deployment is not labeled for the cache
+	kph.Reader = mgr.GetAPIReader()
+
+	ctrlmetrics.Registry.MustRegister(metrics.NewResourceCollector(
+		mgr.GetClient(),
+		ctrl.Log.WithName("metrics collector"),
+		kph.Config().Namespace,
+		flagdproxy.FlagdProxyDeploymentName,
+	))
 
 	flagSourceController := &featureflagsource.FeatureFlagSourceReconciler{
 		Client:     mgr.GetClient(),
@@ -370,27 +453,47 @@ func main() {
 			StartDelay: time.Second,
 			MaxDelay:   time.Minute,
 		},
+		Recorder: recorder,
 	}
 	if err = flagSourceController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FeatureFlagSource")
 		os.Exit(1)
 	}
 
+	inProcessConfigurationController := &inprocessconfiguration.InProcessConfigurationReconciler{
+		Client:     mgr.GetClient(),
+		Log:        ctrl.Log.WithName("InProcessConfiguration Controller"),
+		FlagdProxy: kph,
+		FlagdProxyBackoff: &utils.ExponentialBackoff{
+			StartDelay: time.Second,
+			MaxDelay:   time.Minute,
+		},
+		Recorder: recorder,
+	}
+	if err = inProcessConfigurationController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "InProcessConfiguration")
+		os.Exit(1)
+	}
+
 	flagdContainerInjector := &flagdinjector.FlagdContainerInjector{
 		Client:                    mgr.GetClient(),
 		Logger:                    ctrl.Log.WithName("flagd-container injector"),
 		FlagdProxyConfig:          kph.Config(),
 		FlagdResourceRequirements: *resources,
+		FlagdResourceBounds:       *resourceBounds,
 		Image:                     env.SidecarImage,
 		Tag:                       env.SidecarTag,
+		Recorder:                  recorder,
+		Namespaces:                namespaces,
 	}
 
 	flagdControllerLogger := ctrl.Log.WithName("Flagd Controller")
 
 	flagdResourceReconciler := &flagd.ResourceReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    flagdControllerLogger,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      flagdControllerLogger,
+		Recorder: recorder,
 	}
 
 	flagdConfig := flagd.NewFlagdConfiguration(
@@ -400,9 +503,10 @@ func main() {
 		annotationsMap,
 	)
 
-	if err = (&flagd.FlagdReconciler{
+	flagdReconciler := &flagd.FlagdReconciler{
 		Client:             mgr.GetClient(),
 		Scheme:             mgr.GetScheme(),
+		Recorder:           recorder,
 		ResourceReconciler: flagdResourceReconciler,
 		FlagdDeployment: &flagdResources.FlagdDeployment{
 			Client:        mgr.GetClient(),
@@ -419,11 +523,34 @@ func main() {
 		FlagdGatewayApiHttpRoute: &flagdResources.FlagdGatewayApiHttpRoute{
 			FlagdConfig: flagdConfig,
 		},
-	}).SetupWithManager(mgr); err != nil {
+		FlagdServiceMonitor: &flagdResources.FlagdServiceMonitor{},
+	}
+	if err = flagdReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Flagd")
 		os.Exit(1)
 	}
 
+	// pods created before the cache label was introduced are not cached until they are labeled
+	var cacheLabeled atomic.Bool
+	if err = (&kubernetessync.KubernetesSyncReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("KubernetesSync Controller"),
+		Ready:  cacheLabeled.Load,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KubernetesSync")
+		os.Exit(1)
+	}
+
+	if err = (&pod.PodReconciler{
+		Client:        mgr.GetClient(),
+		Log:           ctrl.Log.WithName("Pod Controller"),
+		FlagdInjector: flagdContainerInjector,
+		Recorder:      recorder,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Pod")
+		os.Exit(1)
+	}
+
 	if env.FlagsValidationEnabled {
 		if err = (&webhooks.FeatureFlagCustomValidator{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create the validation webhook for FeatureFlag CRD", "webhook", "FeatureFlag")
@@ -431,20 +558,74 @@ func main() {
 		}
 	}
 
+	// also serves the conversion webhook between the InProcessConfiguration versions
+	if err = (&webhooks.InProcessConfigurationCustomDefaulter{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create the defaulting webhook for InProcessConfiguration CRD", "webhook", "InProcessConfiguration")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 	hookServer := mgr.GetWebhookServer()
 	podMutator := &webhooks.PodMutator{
 		Client:           mgr.GetClient(),
+		Reader:           mgr.GetAPIReader(),
 		Log:              ctrl.Log.WithName("mutating-pod-webhook"),
 		FlagdProxyConfig: kph.Config(),
 		Env:              env,
 		FlagdInjector:    flagdContainerInjector,
+		Recorder:         recorder,
+		Namespaces:       namespaces,
 	}
 	if err := podMutator.InjectDecoder(admission.NewDecoder(mgr.GetScheme())); err != nil {
 		setupLog.Error(err, "unable to inject decoder into mutating webhook")
 		os.Exit(1)
 	}
 	hookServer.Register("/mutate-v1-pod", &webhook.Admission{Handler: podMutator})
+	deploymentMutator := &webhooks.DeploymentMutator{
+		Log:        ctrl.Log.WithName("mutating-deployment-webhook"),
+		Namespaces: namespaces,
+	}
+	if err := deploymentMutator.InjectDecoder(admission.NewDecoder(mgr.GetScheme())); err != nil {
+		setupLog.Error(err, "unable to inject decoder into mutating webhook")
+		os.Exit(1)
+	}
+	hookServer.Register("/mutate-apps-v1-deployment", &webhook.Admission{Handler: deploymentMutator})
+
+	if err = (&operatorconfiguration.OperatorConfigurationReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("OperatorConfiguration Controller"),
+		Recorder:  recorder,
+		Name:      env.OperatorConfigurationName,
+		Namespace: env.PodNamespace,
+		Defaults: operatorconfig.Configuration{
+			Env:              env,
+			ImagePullSecrets: CommaSeparatedStringToSlice(imagePullSecrets),
+			Labels:           labelsMap,
+			Annotations:      annotationsMap,
+			SidecarResources: *resources,
+		},
+		Appliers:   []operatorconfig.Applier{kph, flagdContainerInjector, flagdReconciler, podMutator},
+		FlagdProxy: kph,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OperatorConfiguration")
+		os.Exit(1)
+	}
+
+	// the fail-closed copy of the cluster-scoped webhook configuration is only maintained by cluster-wide operators
+	if len(namespaces) > 0 {
+		setupLog.Info("Not maintaining the fail-closed mutating webhook configuration", watchNamespacesFlagName, watchNamespaces)
+	} else if err = (&failclosed.FailClosedWebhookReconciler{
+		Client:            mgr.GetClient(),
+		Log:               ctrl.Log.WithName("FailClosedWebhook Controller"),
+		Name:              env.MutatingWebhookConfigurationName,
+		OperatorNamespace: env.PodNamespace,
+		Ready: func() bool {
+			return podMutator.IsReady(nil) == nil
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FailClosedWebhook")
+		os.Exit(1)
+	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -454,9 +635,31 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if webhookCertRotator != nil {
+		if err := mgr.AddReadyzCheck("webhook-cert", webhookCertRotator.IsReady); err != nil {
+			setupLog.Error(err, "unable to set up ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	ctx := ctrl.SetupSignalHandler()
+
+	shutdownTracing, err := tracing.Setup(ctx, env)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	go func() {
+		<-ctx.Done()
+		// flush the pending spans before the process exits
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			setupLog.Error(err, "unable to shut down tracing")
+		}
+	}()
+
 	errChan := make(chan error, 1)
 	go func(chan error) {
 		if err := mgr.Start(ctx); err != nil {
@@ -464,9 +667,14 @@ func main() {
 		}
 	}(errChan)
 
-	setupLog.Info("restoring flagd-kubernetes-sync cluster role binding subjects from current cluster state")
+	setupLog.Info("restoring flagd-kubernetes-sync role bindings from current cluster state")
 	// backfill can be handled asynchronously, so we do not need to block via the channel
 	go func() {
+		if err := cachelabel.Backfill(ctx, mgr.GetClient(), mgr.GetAPIReader(), namespaces); err != nil {
+			setupLog.Error(err, "cache label backfill error, unused kubernetes sync permissions are not revoked")
+		} else {
+			cacheLabeled.Store(true)
+		}
 		if err := podMutator.BackfillPermissions(ctx); err != nil {
 			setupLog.Error(err, "podMutator backfill permissions error")
 		}
@@ -520,3 +728,40 @@ func processResources() (*corev1.ResourceRequirements, error) {
 		},
 	}, nil
 }
+
+func processResourceBounds() (*flagdinjector.ResourceBounds, error) {
+	bounds := &flagdinjector.ResourceBounds{
+		Min: corev1.ResourceList{},
+		Max: corev1.ResourceList{},
+	}
+	for _, b := range []struct {
+		flagName string
+		value    string
+		list     corev1.ResourceList
+		resource corev1.ResourceName
+	}{
+		{sidecarCpuMinFlagName, sidecarCpuMin, bounds.Min, corev1.ResourceCPU},
+		{sidecarCpuMaxFlagName, sidecarCpuMax, bounds.Max, corev1.ResourceCPU},
+		{sidecarRamMinFlagName, sidecarRamMin, bounds.Min, corev1.ResourceMemory},
+		{sidecarRamMaxFlagName, sidecarRamMax, bounds.Max, corev1.ResourceMemory},
+	} {
+		if b.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(b.value)
+		if err != nil {
+			setupLog.Error(err, "parse sidecar resource bound", b.flagName, b.value)
+			return nil, err
+		}
+		b.list[b.resource] = quantity
+	}
+
+	for name, min := range bounds.Min {
+		if max, ok := bounds.Max[name]; ok && min.Cmp(max) > 0 {
+			err := fmt.Errorf("sidecar %s minimum %s is higher than the maximum %s", name, min.String(), max.String())
+			setupLog.Error(err, "invalid sidecar resource bounds")
+			return nil, err
+		}
+	}
+	return bounds, nil
+}
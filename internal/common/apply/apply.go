@@ -0,0 +1,169 @@
+// Package apply applies the resources managed by the operator with server-side apply. The operator owns only the fields
+// it sets, so that fields defaulted by the API server or set by other controllers, such as the replicas of a
+// HorizontalPodAutoscaler or the annotations of a service mesh, are neither overwritten nor taken for drift.
+package apply
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// FieldManager is the field manager of the operator in the managed fields of the applied resources
+const FieldManager = "open-feature-operator"
+
+// Apply applies the set fields of the object with the field manager of the operator. Conflicts with other field
+// managers are forced, the operator takes over the fields it sets.
+func Apply(ctx context.Context, c client.Client, obj client.Object) error {
+	configuration, err := Configuration(obj)
+	if err != nil {
+		return err
+	}
+	return c.Patch(ctx, configuration, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+// Configuration returns the apply configuration of the object. The type meta of the object has to be set. The status,
+// nil values and empty fields outside of lists are left out, as applying them would make the operator own fields set
+// by the API server or other field managers, e.g. the revision annotation of a Deployment.
+func Configuration(obj client.Object) (*unstructured.Unstructured, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		return nil, fmt.Errorf("kind of %s/%s is not set", obj.GetNamespace(), obj.GetName())
+	}
+
+	var content map[string]interface{}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		content = u.DeepCopy().Object
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return nil, fmt.Errorf("could not convert %s %s/%s: %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+	delete(content, "status")
+	prune(content, false)
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// Differs reports whether the fields of the existing object applied by the operator differ from the desired object.
+// The applied fields are read from the managed fields of the operator, an object without them always differs.
+func Differs(existing client.Object, desired client.Object) (bool, error) {
+	configuration, err := Configuration(desired)
+	if err != nil {
+		return false, err
+	}
+	applied, err := extract(existing)
+	if err != nil {
+		return false, err
+	}
+	if applied == nil {
+		return true, nil
+	}
+
+	// the type meta, name and namespace are not part of the managed fields
+	delete(configuration.Object, "apiVersion")
+	delete(configuration.Object, "kind")
+	if metadata, ok := configuration.Object["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "name")
+		delete(metadata, "namespace")
+		if len(metadata) == 0 {
+			delete(configuration.Object, "metadata")
+		}
+	}
+	return !contains(applied, configuration.Object, false), nil
+}
+
+// extract returns the fields of the object owned by the apply operations of the operator, or nil if there are none
+func extract(obj client.Object) (map[string]interface{}, error) {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != FieldManager || entry.Operation != metav1.ManagedFieldsOperationApply ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("could not read the managed fields of %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		// the structure is deduced from the object, lists are extracted as a whole
+		typedObj, err := typed.DeducedParseableType.FromUnstructured(content)
+		if err != nil {
+			return nil, err
+		}
+		applied, ok := typedObj.ExtractItems(fields.Leaves()).AsValue().Unstructured().(map[string]interface{})
+		if !ok {
+			return map[string]interface{}{}, nil
+		}
+		return applied, nil
+	}
+	return nil, nil
+}
+
+// contains reports whether the applied value contains the desired value. Lists are extracted as a whole, so the
+// fields of their items defaulted by the API server are ignored, other fields that are not desired are not.
+func contains(applied interface{}, desired interface{}, inList bool) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		a, ok := applied.(map[string]interface{})
+		if !ok || (!inList && len(a) != len(d)) {
+			return false
+		}
+		for k, v := range d {
+			if _, ok := a[k]; !ok || !contains(a[k], v, inList) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		a, ok := applied.([]interface{})
+		if !ok || len(a) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !contains(a[i], d[i], true) {
+				return false
+			}
+		}
+		return true
+	default:
+		return value.Equals(value.NewValueInterface(applied), value.NewValueInterface(desired))
+	}
+}
+
+// prune removes nil values, empty lists and, outside of lists, empty maps. Empty maps in lists are kept, as they are
+// meaningful there, e.g. the emptyDir of a volume.
+func prune(content map[string]interface{}, inList bool) {
+	for k, v := range content {
+		switch val := v.(type) {
+		case nil:
+			delete(content, k)
+		case map[string]interface{}:
+			prune(val, inList)
+			if len(val) == 0 && !inList {
+				delete(content, k)
+			}
+		case []interface{}:
+			if len(val) == 0 {
+				delete(content, k)
+				continue
+			}
+			for _, item := range val {
+				if m, ok := item.(map[string]interface{}); ok {
+					prune(m, true)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package apply_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/open-feature-operator/internal/common/apply"
+	applyfake "github.com/open-feature/open-feature-operator/internal/common/apply/fake"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "flagd",
+			Namespace:   "test-namespace",
+			Labels:      map[string]string{"app": "flagd"},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "flagd"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "flagd"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "flagd", Image: "flagd:v1"}},
+					Volumes: []corev1.Volume{{
+						Name:         "socket",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestConfiguration(t *testing.T) {
+	configuration, err := apply.Configuration(newDeployment())
+	require.Nil(t, err)
+
+	require.Equal(t, "Deployment", configuration.GetKind())
+	require.NotContains(t, configuration.Object, "status")
+	_, found, _ := unstructured.NestedFieldNoCopy(configuration.Object, "metadata", "creationTimestamp")
+	require.False(t, found)
+	_, found, _ = unstructured.NestedFieldNoCopy(configuration.Object, "metadata", "annotations")
+	require.False(t, found)
+	_, found, _ = unstructured.NestedFieldNoCopy(configuration.Object, "spec", "strategy")
+	require.False(t, found)
+	_, found, _ = unstructured.NestedFieldNoCopy(configuration.Object, "spec", "replicas")
+	require.False(t, found)
+
+	volumes, _, _ := unstructured.NestedSlice(configuration.Object, "spec", "template", "spec", "volumes")
+	require.Equal(t, []interface{}{map[string]interface{}{"name": "socket", "emptyDir": map[string]interface{}{}}}, volumes)
+
+	_, err = apply.Configuration(&appsv1.Deployment{})
+	require.NotNil(t, err)
+}
+
+func TestApply_Differs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, appsv1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(applyfake.Interceptor()).Build()
+	ctx := context.Background()
+
+	get := func() *appsv1.Deployment {
+		existing := &appsv1.Deployment{}
+		require.Nil(t, c.Get(ctx, client.ObjectKey{Namespace: "test-namespace", Name: "flagd"}, existing))
+		return existing
+	}
+	differs := func(desired *appsv1.Deployment) bool {
+		d, err := apply.Differs(get(), desired)
+		require.Nil(t, err)
+		return d
+	}
+
+	// objects without managed fields of the operator always differ
+	require.Nil(t, c.Create(ctx, newDeployment()))
+	require.True(t, differs(newDeployment()))
+
+	require.Nil(t, apply.Apply(ctx, c, newDeployment()))
+	require.False(t, differs(newDeployment()))
+
+	// fields set by the API server or other controllers are not drift
+	existing := get()
+	existing.Spec.Replicas = ptr.To(int32(3))
+	existing.Annotations = map[string]string{"deployment.kubernetes.io/revision": "2"}
+	existing.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+	require.Nil(t, c.Update(ctx, existing))
+	require.False(t, differs(newDeployment()))
+
+	desired := newDeployment()
+	desired.Spec.Template.Spec.Containers[0].Image = "flagd:v2"
+	require.True(t, differs(desired))
+
+	desired = newDeployment()
+	desired.Labels["version"] = "v1"
+	require.True(t, differs(desired))
+	require.Nil(t, apply.Apply(ctx, c, desired))
+	require.False(t, differs(desired))
+	require.Equal(t, int32(3), *get().Spec.Replicas)
+
+	// fields which are not desired anymore are removed
+	require.True(t, differs(newDeployment()))
+}
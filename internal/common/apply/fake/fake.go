@@ -0,0 +1,76 @@
+// Package fake emulates server-side apply for the fake client of controller-runtime, which does not support apply
+// patches.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-feature/open-feature-operator/internal/common/apply"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+)
+
+// Interceptor returns the functions to intercept the fake client with. An applied object is created if it does not
+// exist and merged into the existing object otherwise, the applied fields are recorded in its managed fields. Other
+// patches are passed to the fake client.
+func Interceptor() interceptor.Funcs {
+	return interceptor.Funcs{Patch: patch}
+}
+
+func patch(ctx context.Context, c client.WithWatch, obj client.Object, p client.Patch, opts ...client.PatchOption) error {
+	if p.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, p, opts...)
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("applying %T is not supported, apply unstructured objects", obj)
+	}
+
+	applied := u.DeepCopy()
+	fields := applied.DeepCopy()
+	unstructured.RemoveNestedField(fields.Object, "apiVersion")
+	unstructured.RemoveNestedField(fields.Object, "kind")
+	unstructured.RemoveNestedField(fields.Object, "metadata", "name")
+	unstructured.RemoveNestedField(fields.Object, "metadata", "namespace")
+	typedObj, err := typed.DeducedParseableType.FromUnstructured(fields.Object)
+	if err != nil {
+		return err
+	}
+	fieldSet, err := typedObj.ToFieldSet()
+	if err != nil {
+		return err
+	}
+	raw, err := fieldSet.ToJSON()
+	if err != nil {
+		return err
+	}
+	applied.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:    apply.FieldManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: applied.GetAPIVersion(),
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: raw},
+	}})
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(applied.GroupVersionKind())
+	err = c.Get(ctx, client.ObjectKeyFromObject(applied), existing)
+	if errors.IsNotFound(err) {
+		return c.Create(ctx, applied)
+	}
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(applied.Object)
+	if err != nil {
+		return err
+	}
+	return c.Patch(ctx, existing, client.RawPatch(types.MergePatchType, data))
+}
@@ -0,0 +1,136 @@
+package cachelabel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/utils"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Label marks the pods and Deployments related to OpenFeature, the cache of the operator is restricted to them
+	Label = "openfeature.dev/cache"
+	Value = "true"
+)
+
+// Selector selects the labeled objects
+func Selector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{Label: Value})
+}
+
+// ByObject restricts the cache of pods and Deployments to the labeled objects, instead of caching all pods and
+// Deployments of the cluster. The selector applies to the metadata-only informers of these kinds as well, Deployments
+// are only watched as metadata.
+func ByObject() map[client.Object]cache.ByObject {
+	return map[client.Object]cache.ByObject{
+		&corev1.Pod{}:        {Label: Selector()},
+		&appsV1.Deployment{}: {Label: Selector()},
+	}
+}
+
+// Set labels the object and reports whether the label was missing
+func Set(obj metav1.Object) bool {
+	if obj.GetLabels()[Label] == Value {
+		return false
+	}
+	objLabels := obj.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	objLabels[Label] = Value
+	obj.SetLabels(objLabels)
+	return true
+}
+
+// PodSelected reports whether the pod is injected by the operator or belongs to one of its deployments
+func PodSelected(obj client.Object) bool {
+	if common.IsManagedByOFO(obj) {
+		return true
+	}
+	annotations := obj.GetAnnotations()
+	if annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation)] != "true" {
+		return false
+	}
+	_, rpc := annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation)]
+	_, inProcess := annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation)]
+	return rpc || inProcess
+}
+
+// DeploymentSelected reports whether the Deployment is managed by the operator or its pods use a FeatureFlagSource,
+// these Deployments are restarted on changes of their FeatureFlagSources
+func DeploymentSelected(deployment *appsV1.Deployment) bool {
+	if common.IsManagedByOFO(deployment) {
+		return true
+	}
+	_, ok := deployment.Spec.Template.Annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation)]
+	return ok
+}
+
+// Backfill labels the pods and Deployments of the given namespaces (all namespaces if empty) created before the label
+// was introduced. The objects are read page by page without the cache, pods only as metadata.
+func Backfill(ctx context.Context, c client.Client, reader client.Reader, namespaces []string) error {
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, namespace := range namespaces {
+		if err := backfillPods(ctx, c, reader, namespace); err != nil {
+			return err
+		}
+		if err := backfillDeployments(ctx, c, reader, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func backfillPods(ctx context.Context, c client.Client, reader client.Reader, namespace string) error {
+	pods := &metav1.PartialObjectMetadataList{}
+	pods.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PodList"))
+	return utils.ForEachPage(ctx, reader, pods, namespace, func() error {
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !PodSelected(pod) {
+				continue
+			}
+			pod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+			if err := patch(ctx, c, pod); err != nil {
+				return fmt.Errorf("could not label pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func backfillDeployments(ctx context.Context, c client.Client, reader client.Reader, namespace string) error {
+	deployments := &appsV1.DeploymentList{}
+	return utils.ForEachPage(ctx, reader, deployments, namespace, func() error {
+		for i := range deployments.Items {
+			deployment := &deployments.Items[i]
+			if !DeploymentSelected(deployment) {
+				continue
+			}
+			if err := patch(ctx, c, deployment); err != nil {
+				return fmt.Errorf("could not label deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func patch(ctx context.Context, c client.Client, obj client.Object) error {
+	original, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", obj)
+	}
+	if !Set(obj) {
+		return nil
+	}
+	return client.IgnoreNotFound(c.Patch(ctx, obj, client.MergeFrom(original)))
+}
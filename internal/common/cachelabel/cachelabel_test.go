@@ -0,0 +1,208 @@
+package cachelabel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "test-namespace"
+
+func newScheme(t testing.TB) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.Nil(t, corev1.AddToScheme(scheme))
+	require.Nil(t, appsV1.AddToScheme(scheme))
+	return scheme
+}
+
+func annotatedPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):           "true",
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation): "my-source",
+			},
+		},
+	}
+}
+
+// unrelatedPod is a pod with a spec of a typical size, which is not cached by the operator
+func unrelatedPod(name string) *corev1.Pod {
+	env := []corev1.EnvVar{}
+	for i := 0; i < 20; i++ {
+		env = append(env, corev1.EnvVar{Name: fmt.Sprintf("VARIABLE_%d", i), Value: "some value of the variable"})
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"app": name},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: "registry.example.com/app:v1.0.0", Env: env},
+				{Name: "proxy", Image: "registry.example.com/proxy:v1.0.0", Env: env},
+			},
+		},
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	deployment := &appsV1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: testNamespace},
+		Spec: appsV1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: annotatedPod("").Annotations},
+			},
+		},
+	}
+	managedDeployment := &appsV1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "flagd",
+			Namespace: testNamespace,
+			Labels:    map[string]string{common.ManagedByAnnotationKey: common.ManagedByAnnotationValue},
+		},
+	}
+	unrelatedDeployment := &appsV1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: testNamespace},
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(
+		annotatedPod("my-pod"),
+		unrelatedPod("other-pod"),
+		deployment,
+		managedDeployment,
+		unrelatedDeployment,
+	).Build()
+	ctx := context.Background()
+
+	require.Nil(t, Backfill(ctx, c, c, []string{testNamespace}))
+
+	labeled := func(obj client.Object, name string) bool {
+		require.Nil(t, c.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, obj))
+		return obj.GetLabels()[Label] == Value
+	}
+	require.True(t, labeled(&corev1.Pod{}, "my-pod"))
+	require.False(t, labeled(&corev1.Pod{}, "other-pod"))
+	require.True(t, labeled(&appsV1.Deployment{}, deployment.Name))
+	require.True(t, labeled(&appsV1.Deployment{}, managedDeployment.Name))
+	require.False(t, labeled(&appsV1.Deployment{}, unrelatedDeployment.Name))
+
+	// labeled objects are left unchanged
+	require.Nil(t, Backfill(ctx, c, c, nil))
+}
+
+func TestSet(t *testing.T) {
+	pod := unrelatedPod("my-pod")
+	require.False(t, Selector().Matches(labels.Set(pod.Labels)))
+
+	require.True(t, Set(pod))
+	require.False(t, Set(pod))
+	require.True(t, Selector().Matches(labels.Set(pod.Labels)))
+	require.Equal(t, "my-pod", pod.Labels["app"])
+}
+
+// unrelatedDeployment is a Deployment with a pod template of a typical size, which is not cached by the operator
+func unrelatedDeployment(name string) *appsV1.Deployment {
+	pod := unrelatedPod(name)
+	return &appsV1.Deployment{
+		ObjectMeta: pod.ObjectMeta,
+		Spec: appsV1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: pod.Labels},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: pod.Labels}, Spec: pod.Spec},
+		},
+	}
+}
+
+// benchmarkCache stores the objects of a cluster, in which only one in ten objects is related to OpenFeature, the way
+// an informer does: every object received from the API server is decoded into a new object and kept in the store.
+// The allocated bytes per operation approximate the memory held by the cache.
+func benchmarkCache(b *testing.B, newClusterObject func(i int) client.Object, selected func(client.Object) bool, newObject func() runtime.Object) {
+	raw := [][]byte{}
+	for i := 0; i < 1000; i++ {
+		obj := newClusterObject(i)
+		if i%10 == 0 {
+			Set(obj)
+		}
+		if !selected(obj) {
+			continue
+		}
+		data, err := json.Marshal(obj)
+		require.Nil(b, err)
+		raw = append(raw, data)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		store := toolscache.NewStore(toolscache.MetaNamespaceKeyFunc)
+		for _, data := range raw {
+			obj := newObject()
+			if err := json.Unmarshal(data, obj); err != nil {
+				b.Fatal(err)
+			}
+			if err := store.Add(obj); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func clusterPod(i int) client.Object {
+	if i%10 == 0 {
+		return annotatedPod(fmt.Sprintf("pod-%d", i))
+	}
+	return unrelatedPod(fmt.Sprintf("pod-%d", i))
+}
+
+func clusterDeployment(i int) client.Object {
+	return unrelatedDeployment(fmt.Sprintf("deployment-%d", i))
+}
+
+func selectAll(client.Object) bool { return true }
+
+func selectLabeled(obj client.Object) bool { return Selector().Matches(labels.Set(obj.GetLabels())) }
+
+// BenchmarkPodCache_All caches all pods of the cluster
+func BenchmarkPodCache_All(b *testing.B) {
+	benchmarkCache(b, clusterPod, selectAll, func() runtime.Object { return &corev1.Pod{} })
+}
+
+// BenchmarkPodCache_Metadata caches the metadata of all pods of the cluster
+func BenchmarkPodCache_Metadata(b *testing.B) {
+	benchmarkCache(b, clusterPod, selectAll, func() runtime.Object { return &metav1.PartialObjectMetadata{} })
+}
+
+// BenchmarkPodCache_Labeled caches the labeled pods, the others are filtered by the API server
+func BenchmarkPodCache_Labeled(b *testing.B) {
+	benchmarkCache(b, clusterPod, selectLabeled, func() runtime.Object { return &corev1.Pod{} })
+}
+
+// BenchmarkDeploymentCache_All caches all Deployments of the cluster
+func BenchmarkDeploymentCache_All(b *testing.B) {
+	benchmarkCache(b, clusterDeployment, selectAll, func() runtime.Object { return &appsV1.Deployment{} })
+}
+
+// BenchmarkDeploymentCache_Metadata caches the metadata of all Deployments of the cluster
+func BenchmarkDeploymentCache_Metadata(b *testing.B) {
+	benchmarkCache(b, clusterDeployment, selectAll, func() runtime.Object { return &metav1.PartialObjectMetadata{} })
+}
+
+// BenchmarkDeploymentCache_LabeledMetadata caches the metadata of the labeled Deployments, the way the operator
+// watches them
+func BenchmarkDeploymentCache_LabeledMetadata(b *testing.B) {
+	benchmarkCache(b, clusterDeployment, selectLabeled, func() runtime.Object { return &metav1.PartialObjectMetadata{} })
+}
@@ -3,11 +3,9 @@ package common
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
-	appsV1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,13 +15,11 @@ const (
 	ReconcileErrorInterval                             = 10 * time.Second
 	ReconcileSuccessInterval                           = 120 * time.Second
 	FinalizerName                                      = "featureflag.core.openfeature.dev/finalizer"
-	OpenFeatureAnnotationPath                          = "spec.template.metadata.annotations.openfeature.dev/openfeature.dev"
 	OpenFeatureAnnotationRoot                          = "openfeature.dev"
 	FlagdImagePullPolicy             corev1.PullPolicy = "Always"
 	ClusterRoleBindingName           string            = "open-feature-operator-flagd-kubernetes-sync"
 	AllowKubernetesSyncAnnotation                      = "allowkubernetessync"
 	OpenFeatureAnnotationPrefix                        = "openfeature.dev"
-	PodOpenFeatureAnnotationPath                       = "metadata.annotations.openfeature.dev"
 	SourceConfigParam                                  = "--sources"
 	ProbeReadiness                                     = "/readyz"
 	ProbeLiveness                                      = "/healthz"
@@ -39,33 +35,30 @@ const (
 	SyncGrpcService                                    = "flagd.sync.v1.Service"
 	SyncGrpcServicePath                                = "/" + SyncGrpcService
 	OFREPHttpServicePath                               = "/ofrep"
+	SocketVolumeName                                   = "flagd-socket"
+	InProcessCAVolumeName                              = "flagd-tls-ca"
+	InProcessClientCertVolumeName                      = "flagd-tls-client"
+	SidecarCpuRequestAnnotation                        = "sidecar-cpu-request"
+	SidecarCpuLimitAnnotation                          = "sidecar-cpu-limit"
+	SidecarRamRequestAnnotation                        = "sidecar-ram-request"
+	SidecarRamLimitAnnotation                          = "sidecar-ram-limit"
+	FlagdMonitorLabel                                  = "openfeature.dev/flagd-monitor"
+	AdmissionPolicyLabel                               = "openfeature.dev/admission-policy"
+	AdmissionPolicyFailClosed                          = "fail-closed"
 )
 
+// PodConditionFlagdDependenciesReady reports whether the objects the injected flagd container depends on exist
+const PodConditionFlagdDependenciesReady corev1.PodConditionType = "openfeature.dev/FlagdDependenciesReady"
+
 var ErrFlagdProxyNotReady = errors.New("flagd-proxy is not ready, deferring pod admission")
+var ErrFlagdNotReady = errors.New("flagd is not ready, deferring pod admission")
 var ErrUnrecognizedSyncProvider = errors.New("unrecognized sync provider")
-
-func FeatureFlagSourceIndex(o client.Object) []string {
-	deployment, ok := o.(*appsV1.Deployment)
-	if !ok {
-		return []string{
-			"false",
-		}
-	}
-
-	if deployment.Spec.Template.ObjectMeta.Annotations == nil {
-		return []string{
-			"false",
-		}
-	}
-	if _, ok := deployment.Spec.Template.ObjectMeta.Annotations[fmt.Sprintf("openfeature.dev/%s", FeatureFlagSourceAnnotation)]; ok {
-		return []string{
-			"true",
-		}
-	}
-	return []string{
-		"false",
-	}
-}
+var ErrInvalidSocketPath = errors.New("socket path must be an absolute file path below a non-root directory")
+var ErrInvalidSidecarResources = errors.New("invalid sidecar resource annotation")
+var ErrInvalidTelemetryTLS = errors.New("telemetry tls cannot be insecure and reference a secret")
+var ErrCrossNamespaceFileSource = errors.New("file sources must reference a FeatureFlag of the namespace of the pod")
+var ErrReferenceNotGranted = errors.New("cross-namespace reference not granted")
+var ErrVolumeConflict = errors.New("volume name is already used by a volume of another source")
 
 func FindFlagConfig(ctx context.Context, c client.Client, namespace string, name string) (*api.FeatureFlag, error) {
 	ffConfig := &api.FeatureFlag{}
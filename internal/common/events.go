@@ -0,0 +1,53 @@
+package common
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	EventRecorderName = "open-feature-operator"
+
+	// EventReasonFlagdInjected is emitted on the owner of a pod after the flagd sidecar has been injected
+	EventReasonFlagdInjected = "FlagdInjected"
+	// EventReasonInProcessConfigured is emitted on the owner of a pod after the in-process configuration has been applied
+	EventReasonInProcessConfigured = "InProcessConfigured"
+	// EventReasonInjectionFailed is emitted on the owner of a pod if the pod could not be mutated
+	EventReasonInjectionFailed = "InjectionFailed"
+	// EventReasonConfigMapCreated is emitted on a FeatureFlag after the ConfigMap for the file sync has been created
+	EventReasonConfigMapCreated = "ConfigMapCreated"
+	// EventReasonConfigMapFailed is emitted on a FeatureFlag if the ConfigMap for the file sync could not be created
+	EventReasonConfigMapFailed = "ConfigMapFailed"
+	// EventReasonFlagdProxyFailed is emitted on a FeatureFlagSource if the flagd-proxy could not be deployed
+	EventReasonFlagdProxyFailed = "FlagdProxyFailed"
+	// EventReasonMonitorFailed is emitted on a FeatureFlagSource if the PodMonitor of its sidecars could not be reconciled
+	EventReasonMonitorFailed = "MonitorFailed"
+	// EventReasonRolloutRestarted is emitted on a FeatureFlagSource and the restarted Deployment after a rollout restart
+	EventReasonRolloutRestarted = "RolloutRestarted"
+	// EventReasonRolloutRestartFailed is emitted on a FeatureFlagSource if a Deployment could not be restarted
+	EventReasonRolloutRestartFailed = "RolloutRestartFailed"
+	// EventReasonResourceCreated is emitted on a Flagd after one of its resources has been created
+	EventReasonResourceCreated = "ResourceCreated"
+	// EventReasonResourceUpdated is emitted on a Flagd after one of its resources has been updated
+	EventReasonResourceUpdated = "ResourceUpdated"
+	// EventReasonResourceDeleted is emitted on a Flagd after one of its resources has been disabled and deleted
+	EventReasonResourceDeleted = "ResourceDeleted"
+	// EventReasonReconcileFailed is emitted on a Flagd if one of its resources could not be reconciled
+	EventReasonReconcileFailed = "ReconcileFailed"
+	// EventReasonDependenciesReady is emitted on a pod once the objects its flagd container depends on have been created
+	EventReasonDependenciesReady = "FlagdDependenciesReady"
+	// EventReasonDependenciesFailed is emitted on a pod if the objects its flagd container depends on could not be created
+	EventReasonDependenciesFailed = "FlagdDependenciesFailed"
+	// EventReasonConfigurationApplied is emitted on the OperatorConfiguration after its spec has been applied
+	EventReasonConfigurationApplied = "ConfigurationApplied"
+	// EventReasonConfigurationInvalid is emitted on the OperatorConfiguration if its spec could not be applied
+	EventReasonConfigurationInvalid = "ConfigurationInvalid"
+)
+
+// RecordEvent emits an event on the given object, nothing is done if no recorder is configured
+func RecordEvent(recorder record.EventRecorder, object runtime.Object, eventType, reason, messageFmt string, args ...interface{}) {
+	if recorder == nil || object == nil {
+		return
+	}
+	recorder.Eventf(object, eventType, reason, messageFmt, args...)
+}
@@ -11,6 +11,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	reflect "reflect"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // MockFlagdContainerInjector is a mock of IFlagdContainerInjector interface.
@@ -36,18 +37,32 @@ func (m *MockFlagdContainerInjector) EXPECT() *MockFlagdContainerInjectorMockRec
 	return m.recorder
 }
 
-// EnableClusterRoleBinding mocks base method.
-func (m *MockFlagdContainerInjector) EnableClusterRoleBinding(ctx context.Context, namespace, serviceAccountName string) error {
+// EnableKubernetesSyncPermissions mocks base method.
+func (m *MockFlagdContainerInjector) EnableKubernetesSyncPermissions(ctx context.Context, namespace, serviceAccountName string, featureFlags []client.ObjectKey) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnableClusterRoleBinding", ctx, namespace, serviceAccountName)
+	ret := m.ctrl.Call(m, "EnableKubernetesSyncPermissions", ctx, namespace, serviceAccountName, featureFlags)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EnableClusterRoleBinding indicates an expected call of EnableClusterRoleBinding.
-func (mr *MockFlagdContainerInjectorMockRecorder) EnableClusterRoleBinding(ctx, namespace, serviceAccountName interface{}) *gomock.Call {
+// EnableKubernetesSyncPermissions indicates an expected call of EnableKubernetesSyncPermissions.
+func (mr *MockFlagdContainerInjectorMockRecorder) EnableKubernetesSyncPermissions(ctx, namespace, serviceAccountName, featureFlags interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableClusterRoleBinding", reflect.TypeOf((*MockFlagdContainerInjector)(nil).EnableClusterRoleBinding), ctx, namespace, serviceAccountName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableKubernetesSyncPermissions", reflect.TypeOf((*MockFlagdContainerInjector)(nil).EnableKubernetesSyncPermissions), ctx, namespace, serviceAccountName, featureFlags)
+}
+
+// EnsureDependencies mocks base method.
+func (m *MockFlagdContainerInjector) EnsureDependencies(ctx context.Context, objectMeta *v10.ObjectMeta, podSpec *v1.PodSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureDependencies", ctx, objectMeta, podSpec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureDependencies indicates an expected call of EnsureDependencies.
+func (mr *MockFlagdContainerInjectorMockRecorder) EnsureDependencies(ctx, objectMeta, podSpec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureDependencies", reflect.TypeOf((*MockFlagdContainerInjector)(nil).EnsureDependencies), ctx, objectMeta, podSpec)
 }
 
 // InjectFlagd mocks base method.
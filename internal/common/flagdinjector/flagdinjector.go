@@ -4,28 +4,37 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
+	"slices"
 	"sort"
-	"time"
+	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
 	"github.com/open-feature/open-feature-operator/internal/common"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/kubernetessync"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
+	"github.com/open-feature/open-feature-operator/internal/common/referencegrant"
+	"github.com/open-feature/open-feature-operator/internal/common/syncserver"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
 	"github.com/open-feature/open-feature-operator/internal/common/types"
 	"github.com/open-feature/open-feature-operator/internal/common/utils"
-	appsV1 "k8s.io/api/apps/v1"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	rootFileSyncMountPath = "/etc/flagd"
+	flagdContainerName    = "flagd"
 )
 
 type IFlagdContainerInjector interface {
@@ -36,10 +45,17 @@ type IFlagdContainerInjector interface {
 		flagSourceConfig *api.FeatureFlagSourceSpec,
 	) error
 
-	EnableClusterRoleBinding(
+	EnableKubernetesSyncPermissions(
 		ctx context.Context,
 		namespace,
 		serviceAccountName string,
+		featureFlags []client.ObjectKey,
+	) error
+
+	EnsureDependencies(
+		ctx context.Context,
+		objectMeta *metav1.ObjectMeta,
+		podSpec *corev1.PodSpec,
 	) error
 }
 
@@ -48,8 +64,28 @@ type FlagdContainerInjector struct {
 	Logger                    logr.Logger
 	FlagdProxyConfig          *flagdproxy.FlagdProxyConfiguration
 	FlagdResourceRequirements corev1.ResourceRequirements
+	FlagdResourceBounds       ResourceBounds
 	Image                     string
 	Tag                       string
+	Recorder                  record.EventRecorder
+	// Namespaces the operator is restricted to, FeatureFlags of other namespaces cannot be synced by the kubernetes
+	// provider. All namespaces are watched if empty.
+	Namespaces []string
+
+	// mu guards the configuration against changes of the OperatorConfiguration
+	mu sync.RWMutex
+}
+
+// ApplyConfiguration replaces the image, the resources and the flagd-proxy settings of the injected containers
+func (fi *FlagdContainerInjector) ApplyConfiguration(cfg operatorconfig.Configuration) {
+	proxyConfig := flagdproxy.NewFlagdProxyConfiguration(cfg.Env, cfg.ImagePullSecrets, cfg.Labels, cfg.Annotations)
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.Image = cfg.Env.SidecarImage
+	fi.Tag = cfg.Env.SidecarTag
+	fi.FlagdResourceRequirements = cfg.SidecarResources
+	fi.FlagdProxyConfig = proxyConfig
 }
 
 func (fi *FlagdContainerInjector) InjectFlagd(
@@ -58,6 +94,9 @@ func (fi *FlagdContainerInjector) InjectFlagd(
 	podSpec *corev1.PodSpec,
 	flagSourceConfig *api.FeatureFlagSourceSpec,
 ) error {
+	fi.mu.RLock()
+	defer fi.mu.RUnlock()
+
 	fi.Logger.V(1).Info(fmt.Sprintf("creating flagdContainer for pod %s/%s", objectMeta.Namespace, objectMeta.Name))
 	flagdContainer := fi.generateBasicFlagdContainer(flagSourceConfig)
 
@@ -65,6 +104,13 @@ func (fi *FlagdContainerInjector) InjectFlagd(
 	if flagSourceConfig.ProbesEnabled != nil && *flagSourceConfig.ProbesEnabled {
 		flagdContainer.LivenessProbe = buildProbe(common.ProbeLiveness, int(flagSourceConfig.ManagementPort))
 		flagdContainer.ReadinessProbe = buildProbe(common.ProbeReadiness, int(flagSourceConfig.ManagementPort))
+		if flagSourceConfig.Sidecar != nil && flagSourceConfig.Sidecar.StartupProbe != nil {
+			flagdContainer.StartupProbe = buildProbe(common.ProbeLiveness, int(flagSourceConfig.ManagementPort))
+		}
+	}
+
+	if flagSourceConfig.Sidecar != nil {
+		applySidecarSpec(podSpec, &flagdContainer, flagSourceConfig.Sidecar)
 	}
 
 	if err := fi.handleSidecarSources(ctx, objectMeta, podSpec, flagSourceConfig, &flagdContainer); err != nil {
@@ -78,6 +124,12 @@ func (fi *FlagdContainerInjector) InjectFlagd(
 
 	flagdContainer.Args = append(flagdContainer.Args, buildFlagdArgs(flagSourceConfig)...)
 
+	if flagSourceConfig.Telemetry != nil {
+		if err := applyTelemetrySpec(objectMeta, podSpec, &flagdContainer, flagSourceConfig.Telemetry); err != nil {
+			return err
+		}
+	}
+
 	if len(flagSourceConfig.Resources.Requests) != 0 {
 		flagdContainer.Resources.Requests = flagSourceConfig.Resources.Requests
 	}
@@ -86,6 +138,20 @@ func (fi *FlagdContainerInjector) InjectFlagd(
 		flagdContainer.Resources.Limits = flagSourceConfig.Resources.Limits
 	}
 
+	// pod annotations take precedence, the resources are copied to not modify the shared defaults
+	flagdContainer.Resources = *flagdContainer.Resources.DeepCopy()
+	if err := fi.applyResourceAnnotations(objectMeta, &flagdContainer.Resources); err != nil {
+		return err
+	}
+
+	podSpec.ImagePullSecrets = appendImagePullSecrets(podSpec.ImagePullSecrets, flagSourceConfig.ImagePullSecrets)
+
+	if flagSourceConfig.SocketPath != "" {
+		if err := MountSocketVolume(podSpec, flagSourceConfig.SocketPath, &flagdContainer); err != nil {
+			return err
+		}
+	}
+
 	// Handle standalone Flagd deployment as well as sidecar injection.
 	if len(podSpec.Containers) == 0 {
 		addFlagdContainer(podSpec, flagdContainer)
@@ -110,7 +176,12 @@ func buildFlagdArgs(cfg *api.FeatureFlagSourceSpec) []string {
 		args = append(args, "--debug")
 	}
 
-	if cfg.OtelCollectorUri != "" {
+	if cfg.SocketPath != "" {
+		args = append(args, "--socket-path", cfg.SocketPath)
+	}
+
+	// the telemetry configuration takes precedence over the collector uri
+	if cfg.OtelCollectorUri != "" && cfg.Telemetry == nil {
 		args = append(args, "--metrics-exporter", "otel", "--otel-collector-uri", cfg.OtelCollectorUri)
 	}
 
@@ -145,68 +216,124 @@ func sortedMapArgs(flag string, m map[string]string) []string {
 	return args
 }
 
-// EnableClusterRoleBinding enables the open-feature-operator-flagd-kubernetes-sync cluster role binding for the given
-// service account under the given namespace (required for kubernetes sync provider)
-func (fi *FlagdContainerInjector) EnableClusterRoleBinding(ctx context.Context, namespace, serviceAccountName string) error {
+// EnableKubernetesSyncPermissions grants the given service account under the given namespace read access to the given
+// FeatureFlags, through a Role and RoleBinding in each of their namespaces (required for kubernetes sync provider)
+func (fi *FlagdContainerInjector) EnableKubernetesSyncPermissions(ctx context.Context, namespace, serviceAccountName string, featureFlags []client.ObjectKey) (err error) {
 	serviceAccount := client.ObjectKey{
 		Name:      determineServiceAccountName(serviceAccountName),
 		Namespace: namespace,
 	}
+	ctx, span := tracing.Start(ctx, "FlagdContainerInjector.EnableKubernetesSyncPermissions",
+		attribute.String("namespace", serviceAccount.Namespace), attribute.String("serviceAccount", serviceAccount.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	// the operator cannot grant permissions outside of its namespaces
+	if len(fi.Namespaces) > 0 {
+		for _, ff := range featureFlags {
+			if !slices.Contains(fi.Namespaces, ff.Namespace) {
+				return fmt.Errorf("FeatureFlag %s is not in a namespace watched by the operator", ff)
+			}
+		}
+	}
 
 	// Check if the service account exists
 	fi.Logger.V(1).Info(fmt.Sprintf("Fetching serviceAccount: %s/%s", serviceAccount.Namespace, serviceAccount.Name))
 	sa := corev1.ServiceAccount{}
-	if err := fi.Client.Get(ctx, serviceAccount, &sa); err != nil {
+	saCtx, saSpan := tracing.Start(ctx, "get ServiceAccount")
+	err = fi.Client.Get(saCtx, serviceAccount, &sa)
+	tracing.End(saSpan, err)
+	if err != nil {
 		fi.Logger.V(1).Info(fmt.Sprintf("ServiceAccount not found: %s/%s", serviceAccount.Namespace, serviceAccount.Name))
 		return err
 	}
 
-	fi.Logger.V(1).Info(fmt.Sprintf("Fetching clusterrolebinding: %s", common.ClusterRoleBindingName))
-	// Fetch service account if it exists
-	crb := rbacv1.ClusterRoleBinding{}
-	if err := fi.Client.Get(ctx, client.ObjectKey{Name: common.ClusterRoleBindingName}, &crb); errors.IsNotFound(err) {
-		fi.Logger.V(1).Info(fmt.Sprintf("ClusterRoleBinding not found: %s", common.ClusterRoleBindingName))
+	fi.Logger.V(1).Info(fmt.Sprintf("Granting kubernetes sync permissions to service account: %s/%s", serviceAccount.Namespace, serviceAccount.Name))
+	grantCtx, grantSpan := tracing.Start(ctx, "grant kubernetes sync permissions")
+	err = kubernetessync.Grant(grantCtx, fi.Client, serviceAccount, featureFlags)
+	tracing.End(grantSpan, err)
+	if err != nil {
+		fi.Logger.V(1).Info(fmt.Sprintf("Failed to grant kubernetes sync permissions: %s", err.Error()))
 		return err
 	}
-
-	if !fi.isServiceAccountSet(&crb, serviceAccount) {
-		return fi.updateServiceAccount(ctx, &crb, serviceAccount)
-	}
-
 	return nil
 }
 
-func determineServiceAccountName(name string) string {
-	if name == "" {
-		return "default"
+// EnsureDependencies creates the objects the injected flagd container depends on: the permissions of the service
+// account to read the FeatureFlags synced by the kubernetes provider and the ConfigMaps of the FeatureFlags synced by
+// the file provider. The dependencies are read from the pod spec, InjectFlagd itself does not modify the cluster.
+// As the pod may not have been mutated by the webhook, the references to other namespaces are checked again against
+// the ReferenceGrants.
+func (fi *FlagdContainerInjector) EnsureDependencies(ctx context.Context, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec) (err error) {
+	ctx, span := tracing.Start(ctx, "FlagdContainerInjector.EnsureDependencies",
+		attribute.String("namespace", objectMeta.Namespace), attribute.String("name", objectMeta.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	pod := &corev1.Pod{ObjectMeta: *objectMeta, Spec: *podSpec}
+	// only the sidecar injected by the webhook is read, the deployments of a Flagd run flagd as a regular container
+	featureFlags := kubernetessync.SidecarFeatureFlags(pod)
+	if referrerKind(objectMeta) == referencegrant.KindFlagd {
+		featureFlags = kubernetessync.PodFeatureFlags(pod)
+	}
+	if len(featureFlags) > 0 {
+		for _, featureFlag := range featureFlags {
+			if err = referencegrant.Check(ctx, fi.Client, referencegrant.Reference{
+				FromKind:      referrerKind(objectMeta),
+				FromNamespace: objectMeta.Namespace,
+				ToKind:        referencegrant.KindFeatureFlag,
+				ToNamespace:   featureFlag.Namespace,
+				ToName:        featureFlag.Name,
+			}); err != nil {
+				return err
+			}
+		}
+		if err = fi.EnableKubernetesSyncPermissions(ctx, objectMeta.Namespace, podSpec.ServiceAccountName, featureFlags); err != nil {
+			return err
+		}
 	}
-	return name
+	for _, featureFlag := range FileSyncFeatureFlags(podSpec) {
+		// the ConfigMap is created next to the FeatureFlag, volumes cannot mount ConfigMaps of another namespace
+		if featureFlag.Namespace != objectMeta.Namespace {
+			return fmt.Errorf("file source %s: %w", featureFlag, common.ErrCrossNamespaceFileSource)
+		}
+		if err = fi.ensureConfigMap(ctx, objectMeta, featureFlag); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (fi *FlagdContainerInjector) isServiceAccountSet(crb *rbacv1.ClusterRoleBinding, serviceAccount client.ObjectKey) bool {
-	for _, subject := range crb.Subjects {
-		if subject.Kind == "ServiceAccount" && subject.Name == serviceAccount.Name && subject.Namespace == serviceAccount.Namespace {
-			fi.Logger.V(1).Info(fmt.Sprintf("ClusterRoleBinding already exists for service account: %s/%s", serviceAccount.Namespace, serviceAccount.Name))
-			return true
+// FileSyncFeatureFlags returns the FeatureFlags mounted into the containers of the pod, synced by the file provider of
+// the flagd container or read by in-process providers offline. They are read from the volume mounts added by
+// MountFeatureFlag.
+func FileSyncFeatureFlags(podSpec *corev1.PodSpec) []client.ObjectKey {
+	var featureFlags []client.ObjectKey
+	for _, container := range append(slices.Clone(podSpec.InitContainers), podSpec.Containers...) {
+		for _, mount := range container.VolumeMounts {
+			if path.Dir(mount.MountPath) != rootFileSyncMountPath {
+				continue
+			}
+			// namespaces and names cannot contain underscores
+			ns, name, ok := strings.Cut(path.Base(mount.MountPath), "_")
+			if !ok {
+				continue
+			}
+			if key := (client.ObjectKey{Namespace: ns, Name: name}); !slices.Contains(featureFlags, key) {
+				featureFlags = append(featureFlags, key)
+			}
 		}
 	}
-	return false
+	return featureFlags
 }
 
-func (fi *FlagdContainerInjector) updateServiceAccount(ctx context.Context, crb *rbacv1.ClusterRoleBinding, serviceAccount client.ObjectKey) error {
-	fi.Logger.V(1).Info(fmt.Sprintf("Updating ClusterRoleBinding %s for service account: %s/%s", crb.Name,
-		serviceAccount.Namespace, serviceAccount.Name))
-	crb.Subjects = append(crb.Subjects, rbacv1.Subject{
-		Kind:      "ServiceAccount",
-		Name:      serviceAccount.Name,
-		Namespace: serviceAccount.Namespace,
-	})
-	if err := fi.Client.Update(ctx, crb); err != nil {
-		fi.Logger.V(1).Info(fmt.Sprintf("Failed to update ClusterRoleBinding: %s", err.Error()))
-		return err
+func determineServiceAccountName(name string) string {
+	if name == "" {
+		return "default"
 	}
-	fi.Logger.V(1).Info(fmt.Sprintf("Updated ClusterRoleBinding: %s", crb.Name))
-	return nil
+	return name
 }
 
 func (fi *FlagdContainerInjector) handleSidecarSources(ctx context.Context, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec, flagSourceConfig *api.FeatureFlagSourceSpec, sidecar *corev1.Container) error {
@@ -223,6 +350,9 @@ func (fi *FlagdContainerInjector) handleSidecarSources(ctx context.Context, obje
 }
 
 func (fi *FlagdContainerInjector) buildSources(ctx context.Context, objectMeta *metav1.ObjectMeta, flagSourceConfig *api.FeatureFlagSourceSpec, podSpec *corev1.PodSpec, sidecar *corev1.Container) ([]types.SourceConfig, error) {
+	ctx, span := tracing.Start(ctx, "FlagdContainerInjector.buildSources")
+	defer span.End()
+
 	var sourceCfgCollection []types.SourceConfig
 
 	for _, source := range flagSourceConfig.Sources {
@@ -230,7 +360,10 @@ func (fi *FlagdContainerInjector) buildSources(ctx context.Context, objectMeta *
 			source.Provider = flagSourceConfig.DefaultSyncProvider
 		}
 
-		sourceCfg, err := fi.newSourceConfig(ctx, source, objectMeta, podSpec, sidecar)
+		sourceCtx, sourceSpan := tracing.Start(ctx, "build source",
+			attribute.String("provider", string(source.Provider)), attribute.String("source", source.Source))
+		sourceCfg, err := fi.newSourceConfig(sourceCtx, source, objectMeta, podSpec, sidecar)
+		tracing.End(sourceSpan, err)
 		if err != nil {
 			return []types.SourceConfig{}, err
 		}
@@ -246,6 +379,25 @@ func (fi *FlagdContainerInjector) newSourceConfig(ctx context.Context, source ap
 	sourceCfg := types.SourceConfig{}
 	var err error = nil
 
+	// sources referencing a FeatureFlag or a Flagd of another namespace require a ReferenceGrant in that namespace,
+	// file sources are restricted to the namespace of the pod
+	if source.Provider.IsKubernetes() || source.Provider.IsFlagdProxy() || source.Provider.IsFlagd() {
+		toKind := referencegrant.KindFeatureFlag
+		if source.Provider.IsFlagd() {
+			toKind = referencegrant.KindFlagd
+		}
+		ns, n := utils.ParseAnnotation(source.Source, objectMeta.Namespace)
+		if err := referencegrant.Check(ctx, fi.Client, referencegrant.Reference{
+			FromKind:      referrerKind(objectMeta),
+			FromNamespace: objectMeta.Namespace,
+			ToKind:        toKind,
+			ToNamespace:   ns,
+			ToName:        n,
+		}); err != nil {
+			return &sourceCfg, err
+		}
+	}
+
 	switch {
 	case source.Provider.IsKubernetes():
 		sourceCfg, err = fi.toKubernetesProviderConfig(ctx, objectMeta, podSpec, source)
@@ -259,6 +411,8 @@ func (fi *FlagdContainerInjector) newSourceConfig(ctx context.Context, source ap
 		sourceCfg = fi.toGrpcProviderConfig(source)
 	case source.Provider.IsFlagdProxy():
 		sourceCfg, err = fi.toFlagdProxyConfig(ctx, objectMeta, source)
+	case source.Provider.IsFlagd():
+		sourceCfg, err = fi.toFlagdConfig(ctx, objectMeta, source)
 	case source.Provider.IsAzureBlob():
 		sourceCfg = fi.toAzureBlobConfig(source)
 	case source.Provider.IsS3():
@@ -270,25 +424,47 @@ func (fi *FlagdContainerInjector) newSourceConfig(ctx context.Context, source ap
 	return &sourceCfg, err
 }
 
+// referrerKind returns the kind of the workload the flagd container is injected into, deployments of a Flagd
+// are owned by the Flagd
+func referrerKind(objectMeta *metav1.ObjectMeta) string {
+	for _, owner := range objectMeta.OwnerReferences {
+		if owner.Kind == referencegrant.KindFlagd && strings.HasPrefix(owner.APIVersion, api.GroupVersion.Group+"/") {
+			return referencegrant.KindFlagd
+		}
+	}
+	return referencegrant.KindPod
+}
+
 func (fi *FlagdContainerInjector) toFilepathProviderConfig(ctx context.Context, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec, sidecar *corev1.Container, source api.Source) (types.SourceConfig, error) {
-	// create config map
+	// the ConfigMap is created next to the FeatureFlag, volumes cannot mount ConfigMaps of another namespace
 	ns, n := utils.ParseAnnotation(source.Source, objectMeta.Namespace)
-	cm := corev1.ConfigMap{}
-	if err := fi.Client.Get(ctx, client.ObjectKey{Name: n, Namespace: ns}, &cm); errors.IsNotFound(err) {
-		err := fi.createConfigMap(ctx, ns, n, objectMeta.OwnerReferences)
-		if err != nil {
-			fi.Logger.V(1).Info(fmt.Sprintf("failed to create config map %s error: %s", n, err.Error()))
-			return types.SourceConfig{}, err
-		}
+	if ns != objectMeta.Namespace {
+		return types.SourceConfig{}, fmt.Errorf("file source %s/%s: %w", ns, n, common.ErrCrossNamespaceFileSource)
+	}
+	uri, err := MountFeatureFlag(ctx, fi.Client, podSpec, client.ObjectKey{Namespace: ns, Name: n}, sidecar)
+	if err != nil {
+		return types.SourceConfig{}, err
 	}
+	return types.SourceConfig{
+		URI:      uri,
+		Provider: "file",
+	}, nil
+}
 
-	// Add owner reference of the pod's owner
-	if !common.SharedOwnership(objectMeta.OwnerReferences, cm.OwnerReferences) {
-		fi.updateCMOwnerReference(ctx, objectMeta, cm)
+// MountFeatureFlag mounts the ConfigMap holding the flag configuration of the FeatureFlag into the given containers and
+// returns the path of the flag configuration file. The ConfigMap is created by EnsureDependencies once the pod exists.
+func MountFeatureFlag(ctx context.Context, c client.Client, podSpec *corev1.PodSpec, featureFlag client.ObjectKey, containers ...*corev1.Container) (string, error) {
+	ns, n := featureFlag.Namespace, featureFlag.Name
+	// ensure that the FeatureFlag exists
+	ffCtx, ffSpan := tracing.Start(ctx, "get FeatureFlag", attribute.String("namespace", ns), attribute.String("name", n))
+	_, err := common.FindFlagConfig(ffCtx, c, ns, n)
+	tracing.End(ffSpan, err)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve featureflag %s/%s: %w", ns, n, err)
 	}
 
 	// mount configmap
-	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+	if err := addVolume(podSpec, corev1.Volume{
 		Name: n,
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
@@ -297,25 +473,51 @@ func (fi *FlagdContainerInjector) toFilepathProviderConfig(ctx context.Context,
 				},
 			},
 		},
-	})
+	}); err != nil {
+		return "", err
+	}
 
 	mountPath := fmt.Sprintf("%s/%s", rootFileSyncMountPath, utils.FeatureFlagId(ns, n))
-	sidecar.VolumeMounts = append(sidecar.VolumeMounts, corev1.VolumeMount{
-		Name: n,
-		// create a directory mount per featureFlag spec
-		// file mounts will not work
-		MountPath: mountPath,
-	})
+	for _, container := range containers {
+		if err := addVolumeMount(container, corev1.VolumeMount{
+			Name: n,
+			// create a directory mount per featureFlag spec
+			// file mounts will not work
+			MountPath: mountPath,
+		}); err != nil {
+			return "", err
+		}
+	}
 
-	return types.SourceConfig{
-		URI:      fmt.Sprintf("%s/%s", mountPath, utils.FeatureFlagConfigMapKey(ns, n)),
-		Provider: "file",
-	}, nil
+	return fmt.Sprintf("%s/%s", mountPath, utils.FeatureFlagConfigMapKey(ns, n)), nil
+}
+
+// ensureConfigMap creates the ConfigMap of a FeatureFlag synced by the file provider or adds the owner of the pod
+// to the owners of an existing one
+func (fi *FlagdContainerInjector) ensureConfigMap(ctx context.Context, objectMeta *metav1.ObjectMeta, featureFlag client.ObjectKey) error {
+	cm := corev1.ConfigMap{}
+	err := fi.Client.Get(ctx, featureFlag, &cm)
+	if errors.IsNotFound(err) {
+		if err := fi.createConfigMap(ctx, featureFlag.Namespace, featureFlag.Name, objectMeta.OwnerReferences); err != nil {
+			fi.Logger.V(1).Info(fmt.Sprintf("failed to create config map %s error: %s", featureFlag.Name, err.Error()))
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Add owner reference of the pod's owner
+	if !common.SharedOwnership(objectMeta.OwnerReferences, cm.OwnerReferences) {
+		return fi.updateCMOwnerReference(ctx, objectMeta, cm)
+	}
+	return nil
 }
 
-func (fi *FlagdContainerInjector) updateCMOwnerReference(ctx context.Context, objectMeta *metav1.ObjectMeta, cm corev1.ConfigMap) {
+func (fi *FlagdContainerInjector) updateCMOwnerReference(ctx context.Context, objectMeta *metav1.ObjectMeta, cm corev1.ConfigMap) error {
 	if len(objectMeta.OwnerReferences) == 0 {
-		return
+		return nil
 	}
 	reference := objectMeta.OwnerReferences[0]
 	reference.Controller = utils.FalseVal()
@@ -323,7 +525,9 @@ func (fi *FlagdContainerInjector) updateCMOwnerReference(ctx context.Context, ob
 	err := fi.Client.Update(ctx, &cm)
 	if err != nil {
 		fi.Logger.V(1).Info(fmt.Sprintf("failed to update owner reference for %s error: %s", cm.Name, err.Error()))
+		return err
 	}
+	return nil
 }
 
 func (fi *FlagdContainerInjector) toHttpProviderConfig(source api.Source) types.SourceConfig {
@@ -371,61 +575,52 @@ func (fi *FlagdContainerInjector) toS3Config(source api.Source) types.SourceConf
 }
 
 func (fi *FlagdContainerInjector) toFlagdProxyConfig(ctx context.Context, objectMeta *metav1.ObjectMeta, source api.Source) (types.SourceConfig, error) {
-	// does the proxy exist
-	exists, ready, err := fi.isFlagdProxyReady(ctx)
+	address, err := syncserver.FlagdProxy(ctx, fi.Client, fi.FlagdProxyConfig)
 	if err != nil {
 		return types.SourceConfig{}, err
 	}
-	if !exists || (exists && !ready) {
-		return types.SourceConfig{}, common.ErrFlagdProxyNotReady
-	}
 	ns, n := utils.ParseAnnotation(source.Source, objectMeta.Namespace)
 	return types.SourceConfig{
 		Provider: "grpc",
 		Selector: fmt.Sprintf("core.openfeature.dev/%s/%s", ns, n),
-		URI:      fmt.Sprintf("%s.%s.svc.%s:%d", flagdproxy.FlagdProxyServiceName, fi.FlagdProxyConfig.Namespace, fi.FlagdProxyConfig.ClusterDomain, fi.FlagdProxyConfig.Port),
+		URI:      address.String(),
 	}, nil
 }
 
-func (fi *FlagdContainerInjector) isFlagdProxyReady(ctx context.Context) (bool, bool, error) {
-	d := appsV1.Deployment{}
-	err := fi.Client.Get(ctx, client.ObjectKey{Name: flagdproxy.FlagdProxyDeploymentName, Namespace: fi.FlagdProxyConfig.Namespace}, &d)
+// toFlagdConfig resolves a Flagd to the sync port of its Service, the injected flagd syncs the flags of the
+// FeatureFlagSources of the Flagd from it
+func (fi *FlagdContainerInjector) toFlagdConfig(ctx context.Context, objectMeta *metav1.ObjectMeta, source api.Source) (types.SourceConfig, error) {
+	ns, n := utils.ParseAnnotation(source.Source, objectMeta.Namespace)
+	if referrerKind(objectMeta) == referencegrant.KindFlagd && ns == objectMeta.Namespace && n == objectMeta.Name {
+		return types.SourceConfig{}, fmt.Errorf("flagd %s/%s cannot sync from itself", ns, n)
+	}
+
+	address, err := syncserver.Flagd(ctx, fi.Client, fi.FlagdProxyConfig.ClusterDomain, client.ObjectKey{Namespace: ns, Name: n})
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// does not exist, is not ready, no error
-			return false, false, nil
-		}
-		// does not exist, is not ready, is in error
-		return false, false, err
-	}
-	if d.Status.ReadyReplicas == 0 {
-		// exists, not ready, no error
-		if d.CreationTimestamp.Time.Before(time.Now().Add(-3 * time.Minute)) {
-			return true, false, fmt.Errorf(
-				"flagd-proxy not ready after 3 minutes, was created at %s: %w",
-				d.CreationTimestamp.Time.String(),
-				common.ErrFlagdProxyNotReady,
-			)
-		}
-		return true, false, nil
+		return types.SourceConfig{}, err
 	}
-	// exists, at least one replica ready, no error
-	return true, true, nil
+
+	return types.SourceConfig{
+		Provider:   string(apicommon.SyncProviderGrpc),
+		URI:        address.String(),
+		TLS:        source.TLS,
+		CertPath:   source.CertPath,
+		ProviderID: source.ProviderID,
+		Selector:   source.Selector,
+	}, nil
 }
 
 func (fi *FlagdContainerInjector) toKubernetesProviderConfig(ctx context.Context, objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec, source api.Source) (types.SourceConfig, error) {
 	ns, n := utils.ParseAnnotation(source.Source, objectMeta.Namespace)
 
 	// ensure that the FeatureFlag exists
-	if _, err := common.FindFlagConfig(ctx, fi.Client, ns, n); err != nil {
+	ffCtx, ffSpan := tracing.Start(ctx, "get FeatureFlag", attribute.String("namespace", ns), attribute.String("name", n))
+	_, err := common.FindFlagConfig(ffCtx, fi.Client, ns, n)
+	tracing.End(ffSpan, err)
+	if err != nil {
 		return types.SourceConfig{}, fmt.Errorf("could not retrieve featureflag %s/%s: %w", ns, n, err)
 	}
 
-	// add permissions to pod
-	if err := fi.EnableClusterRoleBinding(ctx, objectMeta.Namespace, podSpec.ServiceAccountName); err != nil {
-		return types.SourceConfig{}, err
-	}
-
 	// mark pod with annotation (required to backfill permissions if they are dropped)
 	if objectMeta.Annotations == nil {
 		objectMeta.Annotations = map[string]string{}
@@ -440,36 +635,49 @@ func (fi *FlagdContainerInjector) toKubernetesProviderConfig(ctx context.Context
 }
 
 func (fi *FlagdContainerInjector) generateBasicFlagdContainer(flagSourceConfig *api.FeatureFlagSourceSpec) corev1.Container {
-	return corev1.Container{
-		Name:  "flagd",
-		Image: fmt.Sprintf("%s:%s", fi.Image, fi.Tag),
-		Args: []string{
-			"start",
-			"--management-port",
-			fmt.Sprintf("%d", flagSourceConfig.ManagementPort),
-			"--port",
-			fmt.Sprintf("%d", flagSourceConfig.Port),
+	pullPolicy := common.FlagdImagePullPolicy
+	if flagSourceConfig.ImagePullPolicy != "" {
+		pullPolicy = flagSourceConfig.ImagePullPolicy
+	}
+	args := []string{
+		"start",
+		"--management-port",
+		fmt.Sprintf("%d", flagSourceConfig.ManagementPort),
+	}
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "management",
+			ContainerPort: flagSourceConfig.ManagementPort,
 		},
-		ImagePullPolicy: common.FlagdImagePullPolicy,
+	}
+	// with a socket path the evaluation service is served on the socket instead of the flagd port,
+	// the management port (and therefore the probes) stays on TCP
+	if flagSourceConfig.SocketPath == "" {
+		args = append(args, "--port", fmt.Sprintf("%d", flagSourceConfig.Port))
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "flagd",
+			ContainerPort: flagSourceConfig.Port,
+		})
+	}
+	return corev1.Container{
+		Name:            flagdContainerName,
+		Image:           flagSourceConfig.ImageReference(fi.Image, fi.Tag),
+		Args:            args,
+		ImagePullPolicy: pullPolicy,
 		VolumeMounts:    []corev1.VolumeMount{},
 		Env:             []corev1.EnvVar{},
-		Ports: []corev1.ContainerPort{
-			{
-				Name:          "management",
-				ContainerPort: flagSourceConfig.ManagementPort,
-			},
-			{
-				Name:          "flagd",
-				ContainerPort: flagSourceConfig.Port,
-			},
-		},
+		Ports:           ports,
 		SecurityContext: getSecurityContext(),
 		Resources:       fi.FlagdResourceRequirements,
 	}
 }
 
-func (fi *FlagdContainerInjector) createConfigMap(ctx context.Context, namespace, name string, ownerReferences []metav1.OwnerReference) error {
+func (fi *FlagdContainerInjector) createConfigMap(ctx context.Context, namespace, name string, ownerReferences []metav1.OwnerReference) (err error) {
 	fi.Logger.V(1).Info(fmt.Sprintf("Creating configmap %s", name))
+	ctx, span := tracing.Start(ctx, "create ConfigMap", attribute.String("namespace", namespace), attribute.String("name", name))
+	defer func() {
+		tracing.End(span, err)
+	}()
 	references := []metav1.OwnerReference{}
 	if len(ownerReferences) > 0 {
 		references = append(references, ownerReferences[0])
@@ -484,10 +692,16 @@ func (fi *FlagdContainerInjector) createConfigMap(ctx context.Context, namespace
 
 	cm, err := ff.GenerateConfigMap(name, namespace, references)
 	if err != nil {
+		common.RecordEvent(fi.Recorder, ff, corev1.EventTypeWarning, common.EventReasonConfigMapFailed, "could not generate ConfigMap for file sync: %s", err.Error())
 		return fmt.Errorf("could generate configmap for featureflag %s/%s: %w", namespace, name, err)
 	}
 
-	return fi.Client.Create(ctx, cm)
+	if err := fi.Client.Create(ctx, cm); err != nil {
+		common.RecordEvent(fi.Recorder, ff, corev1.EventTypeWarning, common.EventReasonConfigMapFailed, "could not create ConfigMap %s/%s for file sync: %s", cm.Namespace, cm.Name, err.Error())
+		return err
+	}
+	common.RecordEvent(fi.Recorder, ff, corev1.EventTypeNormal, common.EventReasonConfigMapCreated, "created ConfigMap %s/%s for file sync", cm.Namespace, cm.Name)
+	return nil
 }
 
 func addFlagdSidecarContainer(spec *corev1.PodSpec, flagdContainer corev1.Container) {
@@ -510,6 +724,120 @@ func addFlagdContainer(spec *corev1.PodSpec, flagdContainer corev1.Container) {
 	spec.Containers = append(spec.Containers, flagdContainer)
 }
 
+// MountSocketVolume adds a shared emptyDir volume for the directory of the given unix socket path and mounts it
+// into all application containers, restartable init containers and the additionally provided containers
+func MountSocketVolume(podSpec *corev1.PodSpec, socketPath string, containers ...*corev1.Container) error {
+	socketDir := path.Dir(socketPath)
+	if !path.IsAbs(socketPath) || socketDir == "/" {
+		return fmt.Errorf("could not mount socket path %q: %w", socketPath, common.ErrInvalidSocketPath)
+	}
+
+	return mountVolume(podSpec, corev1.Volume{
+		Name: common.SocketVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}, socketDir, false, containers...)
+}
+
+// MountSecretVolume adds a read-only volume of the named Secret and mounts it at the given path into all application
+// containers, restartable init containers and the additionally provided containers
+func MountSecretVolume(podSpec *corev1.PodSpec, volumeName, secretName, mountPath string, containers ...*corev1.Container) error {
+	return mountVolume(podSpec, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}, mountPath, true, containers...)
+}
+
+func mountVolume(podSpec *corev1.PodSpec, volume corev1.Volume, mountPath string, readOnly bool, containers ...*corev1.Container) error {
+	if err := addVolume(podSpec, volume); err != nil {
+		return err
+	}
+
+	mount := corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: mountPath,
+		ReadOnly:  readOnly,
+	}
+	targets := []*corev1.Container{}
+	for i := range podSpec.Containers {
+		targets = append(targets, &podSpec.Containers[i])
+	}
+	for i := range podSpec.InitContainers {
+		if podSpec.InitContainers[i].RestartPolicy != nil &&
+			*podSpec.InitContainers[i].RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			targets = append(targets, &podSpec.InitContainers[i])
+		}
+	}
+	for _, container := range append(targets, containers...) {
+		if err := addVolumeMount(container, mount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendImagePullSecrets(existing []corev1.LocalObjectReference, secrets []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	for _, secret := range secrets {
+		if !slices.Contains(existing, secret) {
+			existing = append(existing, secret)
+		}
+	}
+	return existing
+}
+
+// addVolume adds the volume to the pod unless it is already present, e.g. when a pod is admitted again. A volume of the
+// same name with another source, such as a volume of the application, is not reused.
+func addVolume(podSpec *corev1.PodSpec, volume corev1.Volume) error {
+	for _, existing := range podSpec.Volumes {
+		if existing.Name != volume.Name {
+			continue
+		}
+		if !sameVolumeSource(existing.VolumeSource, volume.VolumeSource) {
+			return fmt.Errorf("volume %s: %w", volume.Name, common.ErrVolumeConflict)
+		}
+		return nil
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	return nil
+}
+
+// sameVolumeSource reports whether the existing volume mounts the same ConfigMap, Secret or emptyDir as the desired
+// one, fields defaulted by the API server are ignored
+func sameVolumeSource(existing corev1.VolumeSource, desired corev1.VolumeSource) bool {
+	switch {
+	case desired.ConfigMap != nil:
+		return existing.ConfigMap != nil && existing.ConfigMap.Name == desired.ConfigMap.Name
+	case desired.Secret != nil:
+		return existing.Secret != nil && existing.Secret.SecretName == desired.Secret.SecretName
+	case desired.EmptyDir != nil:
+		return existing.EmptyDir != nil
+	}
+	return false
+}
+
+// addVolumeMount mounts the volume into the container, an existing mount of the volume at the same path is replaced.
+// A mount of the volume at another path belongs to the container and is not overwritten.
+func addVolumeMount(container *corev1.Container, mount corev1.VolumeMount) error {
+	for idx, existing := range container.VolumeMounts {
+		if existing.Name != mount.Name {
+			continue
+		}
+		if existing.MountPath != mount.MountPath {
+			return fmt.Errorf("volume %s is already mounted at %s in container %s: %w",
+				mount.Name, existing.MountPath, container.Name, common.ErrVolumeConflict)
+		}
+		container.VolumeMounts[idx] = mount
+		return nil
+	}
+	container.VolumeMounts = append(container.VolumeMounts, mount)
+	return nil
+}
+
 func appendSources(sources []types.SourceConfig, sidecar *corev1.Container) error {
 	if len(sources) == 0 {
 		return nil
@@ -550,6 +878,49 @@ func getSecurityContext() *corev1.SecurityContext {
 	}
 }
 
+// applySidecarSpec applies the overrides of the sidecar spec to the flagd container and the pod
+func applySidecarSpec(podSpec *corev1.PodSpec, flagdContainer *corev1.Container, sidecar *api.SidecarSpec) {
+	if sidecar.SecurityContext != nil {
+		flagdContainer.SecurityContext = sidecar.SecurityContext.DeepCopy()
+	}
+	applyProbeSpec(flagdContainer.LivenessProbe, sidecar.LivenessProbe, false)
+	applyProbeSpec(flagdContainer.ReadinessProbe, sidecar.ReadinessProbe, true)
+	applyProbeSpec(flagdContainer.StartupProbe, sidecar.StartupProbe, false)
+	if sidecar.PreStop != nil {
+		flagdContainer.Lifecycle = &corev1.Lifecycle{
+			PreStop: sidecar.PreStop.DeepCopy(),
+		}
+	}
+	// only raise the grace period, a longer period required by the workload must not be shortened
+	if sidecar.TerminationGracePeriodSeconds != nil &&
+		(podSpec.TerminationGracePeriodSeconds == nil || *podSpec.TerminationGracePeriodSeconds < *sidecar.TerminationGracePeriodSeconds) {
+		podSpec.TerminationGracePeriodSeconds = ptr.To(*sidecar.TerminationGracePeriodSeconds)
+	}
+}
+
+// applyProbeSpec overrides the timings and thresholds of the probe, nothing is done if the probe is disabled. The API
+// server only accepts a success threshold of 1 for liveness and startup probes, it is only applied to readiness probes.
+func applyProbeSpec(probe *corev1.Probe, spec *api.ProbeSpec, readiness bool) {
+	if probe == nil || spec == nil {
+		return
+	}
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
+	}
+	if spec.SuccessThreshold != nil && readiness {
+		probe.SuccessThreshold = *spec.SuccessThreshold
+	}
+}
+
 // buildProbe generates a http corev1.Probe with provided endpoint, port and with ProbeInitialDelay
 func buildProbe(path string, port int) *corev1.Probe {
 	httpGetAction := &corev1.HTTPGetAction{
@@ -11,11 +11,13 @@ import (
 	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
 	"github.com/open-feature/open-feature-operator/internal/common"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/kubernetessync"
 	"github.com/open-feature/open-feature-operator/internal/common/utils"
 	"github.com/stretchr/testify/require"
 	appsV1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -131,6 +133,163 @@ func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithOtelCollectorUri(t
 	require.Equal(t, expectedPod, pod)
 }
 
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithTelemetry(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+	pod.Labels = map[string]string{"app.kubernetes.io/name": "my-app"}
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+	flagSourceConfig.DefaultSyncProvider = apicommon.SyncProviderGrpc
+	flagSourceConfig.Sources = []api.Source{{}}
+	// ignored in favor of the telemetry configuration
+	flagSourceConfig.OtelCollectorUri = "localhost:4317"
+	flagSourceConfig.Telemetry = &api.TelemetrySpec{
+		MetricsEndpoint: "metrics-collector:4317",
+		TracesEndpoint:  "traces-collector:4317",
+		Headers: []api.TelemetryHeader{{
+			Name: "x-api-key",
+			ValueFrom: v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "otel-credentials"},
+				Key:                  "api-key",
+			},
+		}},
+		TLS: &api.TelemetryTLSSpec{
+			SecretName:        "otel-tls",
+			ClientCertificate: true,
+		},
+		ResourceAttributes: map[string]string{"deployment.environment": "prod", "team": "a=b,c"},
+		Sampler: &api.TelemetrySamplerSpec{
+			Type:  "parentbased_traceidratio",
+			Ratio: "0.25",
+		},
+	}
+
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+
+	flagd := pod.Spec.InitContainers[0]
+	require.Equal(t, []string{
+		"start", "--management-port", "8014", "--port", "8013", "--sources", "[{\"uri\":\"\",\"provider\":\"grpc\"}]",
+		"--metrics-exporter", "otel", "--otel-collector-uri", "metrics-collector:4317",
+		"--otel-ca-path", "/etc/flagd/otel-tls/ca.crt",
+		"--otel-cert-path", "/etc/flagd/otel-tls/tls.crt",
+		"--otel-key-path", "/etc/flagd/otel-tls/tls.key",
+	}, flagd.Args)
+
+	require.Subset(t, flagd.Env, []v1.EnvVar{
+		{Name: "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", Value: "https://metrics-collector:4317"},
+		{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: "https://traces-collector:4317"},
+		{Name: "OTEL_EXPORTER_OTLP_HEADER_0", ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "otel-credentials"},
+				Key:                  "api-key",
+			},
+		}},
+		{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "x-api-key=$(OTEL_EXPORTER_OTLP_HEADER_0)"},
+		{Name: "OTEL_K8S_NAMESPACE_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+		{Name: "OTEL_K8S_POD_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		{Name: "OTEL_SERVICE_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['app.kubernetes.io/name']"}}},
+		{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "k8s.namespace.name=$(OTEL_K8S_NAMESPACE_NAME),k8s.pod.name=$(OTEL_K8S_POD_NAME),deployment.environment=prod,team=a%3Db%2Cc"},
+		{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+		{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.25"},
+	}, flagd.Env)
+
+	// the telemetry env vars are not shared with the application containers
+	for _, env := range pod.Spec.Containers[0].Env {
+		require.NotContains(t, env.Name, "OTEL_")
+	}
+
+	require.Equal(t, []v1.Volume{{
+		Name: "flagd-otel-tls",
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{SecretName: "otel-tls"},
+		},
+	}}, pod.Spec.Volumes)
+	require.Equal(t, []v1.VolumeMount{{Name: "flagd-otel-tls", MountPath: "/etc/flagd/otel-tls", ReadOnly: true}}, flagd.VolumeMounts)
+}
+
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithTelemetryProtocol(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      api.TelemetrySpec
+		wantEnv []v1.EnvVar
+		wantErr error
+	}{
+		{
+			name: "http/protobuf appends the signal paths",
+			in: api.TelemetrySpec{
+				MetricsEndpoint: "otel-collector:4318",
+				Protocol:        "http/protobuf",
+				TLS:             &api.TelemetryTLSSpec{Insecure: true},
+			},
+			wantEnv: []v1.EnvVar{
+				{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+				{Name: "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", Value: "http://otel-collector:4318/v1/metrics"},
+				{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: "http://otel-collector:4318/v1/traces"},
+				{Name: "OTEL_EXPORTER_OTLP_INSECURE", Value: "true"},
+			},
+		},
+		{
+			name: "endpoints with a scheme and a path are kept",
+			in: api.TelemetrySpec{
+				MetricsEndpoint: "https://otel.example.com/otlp/v1/metrics",
+				TracesEndpoint:  "https://otel.example.com/otlp/v1/traces",
+				Protocol:        "http/protobuf",
+			},
+			wantEnv: []v1.EnvVar{
+				{Name: "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", Value: "https://otel.example.com/otlp/v1/metrics"},
+				{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: "https://otel.example.com/otlp/v1/traces"},
+			},
+		},
+		{
+			name: "insecure export referencing a secret",
+			in: api.TelemetrySpec{
+				MetricsEndpoint: "otel-collector:4317",
+				TLS:             &api.TelemetryTLSSpec{Insecure: true, SecretName: "otel-tls"},
+			},
+			wantErr: common.ErrInvalidTelemetryTLS,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, fakeClient := initContainerInjectionTestEnv()
+
+			fi := &FlagdContainerInjector{
+				Client:                    fakeClient,
+				Logger:                    testr.New(t),
+				FlagdProxyConfig:          getProxyConfig(),
+				FlagdResourceRequirements: getResourceRequirements(),
+				Image:                     testImage,
+				Tag:                       testTag,
+			}
+
+			pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+			flagSourceConfig := getFlagSourceConfigSpec()
+			flagSourceConfig.DefaultSyncProvider = apicommon.SyncProviderGrpc
+			flagSourceConfig.Sources = []api.Source{{}}
+			flagSourceConfig.Telemetry = &tt.in
+
+			err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Subset(t, pod.Spec.InitContainers[0].Env, tt.wantEnv)
+		})
+	}
+}
+
 func TestFlagdContainerInjector_InjectDefaultSyncProvider_FlagdConfigArgs(t *testing.T) {
 	baseArgs := []string{"start", "--management-port", "8014", "--port", "8013", "--sources", "[{\"uri\":\"\",\"provider\":\"grpc\"}]"}
 
@@ -307,18 +466,147 @@ func TestFlagdContainerInjector_InjectFlagdKubernetesSource(t *testing.T) {
 
 	require.Equal(t, expectedPod, pod)
 
-	// verify the update of the ClusterRoleBinding
-	cbr := &rbacv1.ClusterRoleBinding{}
-	err = fakeClient.Get(context.Background(), client.ObjectKey{Name: common.ClusterRoleBindingName}, cbr)
+	// the injection does not grant any permissions
+	rbs := &rbacv1.RoleBindingList{}
+	require.Nil(t, fakeClient.List(context.Background(), rbs))
+	require.Empty(t, rbs.Items)
 
+	err = fi.EnsureDependencies(context.Background(), &pod.ObjectMeta, &pod.Spec)
 	require.Nil(t, err)
 
-	require.Len(t, cbr.Subjects, 1)
+	// verify the RoleBinding granting access to the FeatureFlag
+	rb := &rbacv1.RoleBinding{}
+	err = fakeClient.Get(context.Background(), client.ObjectKey{
+		Namespace: "my-namespace",
+		Name:      kubernetessync.Name(client.ObjectKey{Namespace: namespace, Name: "default"}),
+	}, rb)
+
+	require.Nil(t, err)
+
+	require.Len(t, rb.Subjects, 1)
 	require.Equal(t, rbacv1.Subject{
 		Kind:      "ServiceAccount",
 		Name:      "default",
 		Namespace: namespace,
-	}, cbr.Subjects[0])
+	}, rb.Subjects[0])
+}
+
+func TestFlagdContainerInjector_InjectFlagdKubernetesSource_CrossNamespace(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+	flagSourceConfig.Sources = []api.Source{
+		{
+			Source:   "flags/shared",
+			Provider: apicommon.SyncProviderKubernetes,
+		},
+	}
+
+	// the FeatureFlag of another namespace is not resolved without a ReferenceGrant
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.ErrorIs(t, err, common.ErrReferenceNotGranted)
+
+	rbs := &rbacv1.RoleBindingList{}
+	require.Nil(t, fakeClient.List(context.Background(), rbs))
+	require.Empty(t, rbs.Items)
+
+	// grants for Pods do not apply to deployments of a Flagd
+	require.Nil(t, fakeClient.Create(context.Background(), &api.FeatureFlag{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "flags"},
+	}))
+	require.Nil(t, fakeClient.Create(context.Background(), &api.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-pods", Namespace: "flags"},
+		Spec: api.ReferenceGrantSpec{
+			From: []api.ReferenceGrantFrom{{Kind: "Pod", Namespace: namespace}},
+			To:   []api.ReferenceGrantTo{{Kind: "FeatureFlag", Name: ptr.To("shared")}},
+		},
+	}))
+
+	flagdMeta := metav1.ObjectMeta{
+		Namespace:       namespace,
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "core.openfeature.dev/v1beta1", Kind: "Flagd", Name: "flagd"}},
+	}
+	err = fi.InjectFlagd(context.Background(), &flagdMeta, &v1.PodSpec{}, flagSourceConfig)
+	require.ErrorIs(t, err, common.ErrReferenceNotGranted)
+
+	pod = generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+}
+
+func TestFlagdContainerInjector_EnsureDependencies_NotInjected(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client: fakeClient,
+		Logger: testr.New(t),
+	}
+
+	sources := []string{"start", "--sources", `[{"uri":"flags/shared","provider":"kubernetes"}]`}
+	tests := []struct {
+		name    string
+		spec    v1.PodSpec
+		wantErr error
+	}{
+		{
+			name: "flagd app container",
+			spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "flagd", Args: sources}},
+			},
+		},
+		{
+			name: "flagd sidecar without ReferenceGrant",
+			spec: v1.PodSpec{
+				InitContainers: []v1.Container{{Name: "flagd", Args: sources, RestartPolicy: ptr.To(v1.ContainerRestartPolicyAlways)}},
+				Containers:     []v1.Container{generateContainer()},
+			},
+			wantErr: common.ErrReferenceNotGranted,
+		},
+		{
+			name: "FeatureFlag of another namespace mounted",
+			spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Name:         "app",
+					VolumeMounts: []v1.VolumeMount{{Name: "shared", MountPath: "/etc/flagd/flags_shared"}},
+				}},
+			},
+			wantErr: common.ErrCrossNamespaceFileSource,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := generatePod(nil, nil, nil, namespace)
+			pod.Spec = tt.spec
+
+			err := fi.EnsureDependencies(context.Background(), &pod.ObjectMeta, &pod.Spec)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.Nil(t, err)
+			}
+
+			// no permissions nor ConfigMaps are created in the namespace of the FeatureFlag
+			roles := &rbacv1.RoleList{}
+			require.Nil(t, fakeClient.List(context.Background(), roles))
+			require.Empty(t, roles.Items)
+			rbs := &rbacv1.RoleBindingList{}
+			require.Nil(t, fakeClient.List(context.Background(), rbs))
+			require.Empty(t, rbs.Items)
+			cms := &v1.ConfigMapList{}
+			require.Nil(t, fakeClient.List(context.Background(), cms, client.InNamespace("flags")))
+			require.Empty(t, cms.Items)
+		})
+	}
 }
 
 func TestFlagdContainerInjector_InjectFlagdFilePathSource(t *testing.T) {
@@ -375,12 +663,120 @@ func TestFlagdContainerInjector_InjectFlagdFilePathSource(t *testing.T) {
 
 	require.Equal(t, expectedPod, pod)
 
+	// the injection does not create the ConfigMap
+	err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: "server-side", Namespace: namespace}, &v1.ConfigMap{})
+	require.True(t, k8serrors.IsNotFound(err))
+
+	err = fi.EnsureDependencies(context.Background(), &pod.ObjectMeta, &pod.Spec)
+	require.Nil(t, err)
+
 	// verify the creation of the referenced ConfigMap
 	cm := &v1.ConfigMap{}
 	err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.Volumes[0].ConfigMap.Name, Namespace: namespace}, cm)
 	require.Nil(t, err)
 }
 
+func TestFlagdContainerInjector_InjectFlagdFilePathSource_OtherNamespace(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+	flagSourceConfig.Sources = []api.Source{
+		{
+			Source:   "flags/server-side",
+			Provider: apicommon.SyncProviderFilepath,
+		},
+	}
+
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.ErrorIs(t, err, common.ErrCrossNamespaceFileSource)
+	require.Empty(t, pod.Spec.Volumes)
+}
+
+func TestFlagdContainerInjector_InjectFlagdFilePathSource_ExistingVolume(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+	flagSourceConfig.Sources = []api.Source{
+		{
+			Source:   "server-side",
+			Provider: apicommon.SyncProviderFilepath,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		volume  v1.Volume
+		wantErr error
+	}{
+		{
+			name: "ConfigMap volume of the FeatureFlag is reused",
+			volume: v1.Volume{
+				Name: "server-side",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: "server-side"},
+						DefaultMode:          ptr.To(int32(420)),
+					},
+				},
+			},
+		},
+		{
+			name: "ConfigMap volume of another ConfigMap",
+			volume: v1.Volume{
+				Name: "server-side",
+				VolumeSource: v1.VolumeSource{
+					ConfigMap: &v1.ConfigMapVolumeSource{
+						LocalObjectReference: v1.LocalObjectReference{Name: "app-config"},
+					},
+				},
+			},
+			wantErr: common.ErrVolumeConflict,
+		},
+		{
+			name: "volume of the application",
+			volume: v1.Volume{
+				Name:         "server-side",
+				VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+			},
+			wantErr: common.ErrVolumeConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+			pod.Spec.Volumes = []v1.Volume{tt.volume}
+
+			err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, []v1.Volume{tt.volume}, pod.Spec.Volumes)
+		})
+	}
+}
+
 func TestFlagdContainerInjector_InjectFlagdFilePathSource_UpdateReferencedConfigMap(t *testing.T) {
 	namespace, fakeClient := initContainerInjectionTestEnv()
 
@@ -454,6 +850,9 @@ func TestFlagdContainerInjector_InjectFlagdFilePathSource_UpdateReferencedConfig
 
 	require.Equal(t, expectedPod, pod)
 
+	err = fi.EnsureDependencies(context.Background(), &pod.ObjectMeta, &pod.Spec)
+	require.Nil(t, err)
+
 	// verify the creation of the referenced ConfigMap
 	cm = &v1.ConfigMap{}
 	err = fakeClient.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.Volumes[0].ConfigMap.Name, Namespace: namespace}, cm)
@@ -766,9 +1165,18 @@ func TestFlagdContainerInjector_InjectProxySource_ProxyIsReady(t *testing.T) {
 	require.Equal(t, expectedPod, pod)
 }
 
-func TestFlagdContainerInjector_Inject_FlagdContainerAlreadyPresent(t *testing.T) {
+func TestFlagdContainerInjector_InjectFlagdSource_FlagdNotReady(t *testing.T) {
 	namespace, fakeClient := initContainerInjectionTestEnv()
 
+	err := fakeClient.Create(context.Background(), &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{Name: "central-flagd", Namespace: namespace},
+	})
+	require.Nil(t, err)
+	err = fakeClient.Create(context.Background(), &appsV1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "central-flagd", Namespace: namespace},
+	})
+	require.Nil(t, err)
+
 	fi := &FlagdContainerInjector{
 		Client:                    fakeClient,
 		Logger:                    testr.New(t),
@@ -778,26 +1186,79 @@ func TestFlagdContainerInjector_Inject_FlagdContainerAlreadyPresent(t *testing.T
 		Tag:                       testTag,
 	}
 
-	pod := generatePod([]v1.Container{generateContainer()}, []v1.Container{
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+
+	flagSourceConfig.Sources = []api.Source{
 		{
-			Name: "flagd",
+			Source:   "central-flagd",
+			Provider: apicommon.SyncProviderFlagd,
 		},
-	}, nil, namespace)
+	}
+
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.ErrorIs(t, err, common.ErrFlagdNotReady)
+}
+
+func TestFlagdContainerInjector_InjectFlagdSource_FlagdIsReady(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	err := fakeClient.Create(context.Background(), &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{Name: "central-flagd", Namespace: namespace},
+	})
+	require.Nil(t, err)
+	flagdDeployment := &appsV1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "central-flagd", Namespace: namespace},
+	}
+	err = fakeClient.Create(context.Background(), flagdDeployment)
+	require.Nil(t, err)
+	flagdDeployment.Status.ReadyReplicas = 1
+	err = fakeClient.Status().Update(context.Background(), flagdDeployment)
+	require.Nil(t, err)
+	err = fakeClient.Create(context.Background(), &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "central-flagd", Namespace: namespace},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{Name: "flagd", Port: 8013}, {Name: "sync", Port: 8015}},
+		},
+	})
+	require.Nil(t, err)
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
 
 	flagSourceConfig := getFlagSourceConfigSpec()
 
-	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	flagSourceConfig.Sources = []api.Source{
+		{
+			Source:   "central-flagd",
+			Provider: apicommon.SyncProviderFlagd,
+			TLS:      true,
+			Selector: "core.openfeature.dev/my-namespace/server-side",
+		},
+	}
+
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
 	require.Nil(t, err)
 
 	expectedPod := getExpectedPod(namespace)
 
 	expectedPod.Annotations = nil
-	expectedPod.Spec.InitContainers[0].Args = []string{"start", "--management-port", "8014", "--port", "8013"}
+
+	expectedPod.Spec.InitContainers[0].Args = []string{"start", "--management-port", "8014", "--port", "8013", "--sources", "[{\"uri\":\"central-flagd.my-namespace.svc.cluster.local:8015\",\"provider\":\"grpc\",\"tls\":true,\"selector\":\"core.openfeature.dev/my-namespace/server-side\"}]"}
 
 	require.Equal(t, expectedPod, pod)
 }
 
-func TestFlagdContainerInjector_InjectUnknownSyncProvider(t *testing.T) {
+func TestFlagdContainerInjector_InjectFlagdSource_Itself(t *testing.T) {
 	namespace, fakeClient := initContainerInjectionTestEnv()
 
 	fi := &FlagdContainerInjector{
@@ -809,23 +1270,90 @@ func TestFlagdContainerInjector_InjectUnknownSyncProvider(t *testing.T) {
 		Tag:                       testTag,
 	}
 
-	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+	objectMeta := metav1.ObjectMeta{
+		Name:      "central-flagd",
+		Namespace: namespace,
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: api.GroupVersion.String(),
+			Kind:       "Flagd",
+			Name:       "central-flagd",
+		}},
+	}
+	podSpec := v1.PodSpec{}
 
 	flagSourceConfig := getFlagSourceConfigSpec()
 
 	flagSourceConfig.Sources = []api.Source{
 		{
-			Provider: "unknown",
+			Source:   "central-flagd",
+			Provider: apicommon.SyncProviderFlagd,
 		},
 	}
 
-	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
-
-	require.NotNil(t, err)
-	require.ErrorIs(t, err, common.ErrUnrecognizedSyncProvider)
+	err := fi.InjectFlagd(context.Background(), &objectMeta, &podSpec, flagSourceConfig)
+	require.ErrorContains(t, err, "cannot sync from itself")
 }
 
-func TestFlagdContainerInjector_InjectDefaultSyncProvider_Standalone(t *testing.T) {
+func TestFlagdContainerInjector_Inject_FlagdContainerAlreadyPresent(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	pod := generatePod([]v1.Container{generateContainer()}, []v1.Container{
+		{
+			Name: "flagd",
+		},
+	}, nil, namespace)
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+
+	expectedPod := getExpectedPod(namespace)
+
+	expectedPod.Annotations = nil
+	expectedPod.Spec.InitContainers[0].Args = []string{"start", "--management-port", "8014", "--port", "8013"}
+
+	require.Equal(t, expectedPod, pod)
+}
+
+func TestFlagdContainerInjector_InjectUnknownSyncProvider(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+
+	flagSourceConfig.Sources = []api.Source{
+		{
+			Provider: "unknown",
+		},
+	}
+
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+
+	require.NotNil(t, err)
+	require.ErrorIs(t, err, common.ErrUnrecognizedSyncProvider)
+}
+
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_Standalone(t *testing.T) {
 	namespace, fakeClient := initContainerInjectionTestEnv()
 
 	fi := &FlagdContainerInjector{
@@ -855,6 +1383,360 @@ func TestFlagdContainerInjector_InjectDefaultSyncProvider_Standalone(t *testing.
 	require.Equal(t, expectedPod, pod)
 }
 
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithImageOverrides(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+	pod.Spec.ImagePullSecrets = []v1.LocalObjectReference{{Name: "app-registry"}}
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+	flagSourceConfig.DefaultSyncProvider = apicommon.SyncProviderGrpc
+	flagSourceConfig.Sources = []api.Source{{}}
+	flagSourceConfig.Image = "mirror.local/flagd"
+	flagSourceConfig.Tag = "v0.12.0"
+	flagSourceConfig.ImagePullPolicy = v1.PullIfNotPresent
+	flagSourceConfig.ImagePullSecrets = []v1.LocalObjectReference{{Name: "app-registry"}, {Name: "mirror"}}
+
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+
+	require.Equal(t, "mirror.local/flagd:v0.12.0", pod.Spec.InitContainers[0].Image)
+	require.Equal(t, v1.PullIfNotPresent, pod.Spec.InitContainers[0].ImagePullPolicy)
+	require.Equal(t, []v1.LocalObjectReference{{Name: "app-registry"}, {Name: "mirror"}}, pod.Spec.ImagePullSecrets)
+
+	// a digest pins the image regardless of the tag
+	flagSourceConfig.Digest = "sha256:0123456789abcdef"
+
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+
+	require.Len(t, pod.Spec.InitContainers, 1)
+	require.Equal(t, "mirror.local/flagd@sha256:0123456789abcdef", pod.Spec.InitContainers[0].Image)
+	require.Len(t, pod.Spec.ImagePullSecrets, 2)
+}
+
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithSocketPath(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	appSidecar := generateContainer()
+	appSidecar.Name = "app-sidecar"
+	appSidecar.RestartPolicy = ptr.To(v1.ContainerRestartPolicyAlways)
+	pod := generatePod([]v1.Container{generateContainer()}, []v1.Container{{Name: "init"}, appSidecar}, nil, namespace)
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+	flagSourceConfig.DefaultSyncProvider = apicommon.SyncProviderGrpc
+	flagSourceConfig.Sources = []api.Source{{}}
+	flagSourceConfig.SocketPath = "/var/run/flagd/flagd.sock"
+
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+
+	expectedMount := v1.VolumeMount{Name: common.SocketVolumeName, MountPath: "/var/run/flagd"}
+
+	require.Equal(t, []v1.Volume{{
+		Name:         common.SocketVolumeName,
+		VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+	}}, pod.Spec.Volumes)
+	require.Equal(t, []v1.VolumeMount{expectedMount}, pod.Spec.Containers[0].VolumeMounts)
+	require.Empty(t, pod.Spec.InitContainers[0].VolumeMounts)
+	require.Equal(t, []v1.VolumeMount{expectedMount}, pod.Spec.InitContainers[1].VolumeMounts)
+
+	flagd := pod.Spec.InitContainers[2]
+	require.Equal(t, "flagd", flagd.Name)
+	require.Equal(t, []v1.VolumeMount{expectedMount}, flagd.VolumeMounts)
+	require.Equal(t, []v1.ContainerPort{{Name: "management", ContainerPort: 8014}}, flagd.Ports)
+	require.Equal(t, int32(8014), flagd.ReadinessProbe.HTTPGet.Port.IntVal)
+	// the flagd port is not served in socket mode
+	require.Equal(t, []string{"start", "--management-port", "8014", "--sources", "[{\"uri\":\"\",\"provider\":\"grpc\"}]", "--socket-path", "/var/run/flagd/flagd.sock"}, flagd.Args)
+
+	// repeated injection does not duplicate the volume or the mounts
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+	require.Len(t, pod.Spec.Volumes, 1)
+	require.Len(t, pod.Spec.Containers[0].VolumeMounts, 1)
+
+	// a mount of the app using the name of the socket volume at another path is not overwritten
+	pod = generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+	pod.Spec.Volumes = []v1.Volume{{
+		Name:         common.SocketVolumeName,
+		VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+	}}
+	appMount := v1.VolumeMount{Name: common.SocketVolumeName, MountPath: "/var/run/app"}
+	pod.Spec.Containers[0].VolumeMounts = []v1.VolumeMount{appMount}
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.ErrorIs(t, err, common.ErrVolumeConflict)
+	require.Equal(t, []v1.VolumeMount{appMount}, pod.Spec.Containers[0].VolumeMounts)
+}
+
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithInvalidSocketPath(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	for _, socketPath := range []string{"flagd.sock", "/flagd.sock"} {
+		pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+
+		flagSourceConfig := getFlagSourceConfigSpec()
+		flagSourceConfig.DefaultSyncProvider = apicommon.SyncProviderGrpc
+		flagSourceConfig.Sources = []api.Source{{}}
+		flagSourceConfig.SocketPath = socketPath
+
+		err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+		require.True(t, errors.Is(err, common.ErrInvalidSocketPath))
+	}
+}
+
+func TestMountSecretVolume(t *testing.T) {
+	container := generateContainer()
+	podSpec := &v1.PodSpec{Containers: []v1.Container{container}}
+
+	err := MountSecretVolume(podSpec, "sync-ca", "ca-secret", "/etc/sync-ca")
+	require.Nil(t, err)
+	// mounting the same Secret again reuses the volume
+	err = MountSecretVolume(podSpec, "sync-ca", "ca-secret", "/etc/sync-ca")
+	require.Nil(t, err)
+	require.Equal(t, []v1.Volume{{
+		Name:         "sync-ca",
+		VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "ca-secret"}},
+	}}, podSpec.Volumes)
+	require.Equal(t, []v1.VolumeMount{{Name: "sync-ca", MountPath: "/etc/sync-ca", ReadOnly: true}}, podSpec.Containers[0].VolumeMounts)
+
+	// a volume of the same name mounting another Secret is not reused
+	err = MountSecretVolume(podSpec, "sync-ca", "other-secret", "/etc/sync-ca")
+	require.ErrorIs(t, err, common.ErrVolumeConflict)
+	require.Len(t, podSpec.Volumes, 1)
+}
+
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithSidecarOverrides(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		Image:                     testImage,
+		Tag:                       testTag,
+	}
+
+	pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+	pod.Spec.TerminationGracePeriodSeconds = ptr.To(int64(10))
+
+	securityContext := &v1.SecurityContext{
+		RunAsNonRoot: ptr.To(true),
+		Capabilities: &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+	}
+	preStop := &v1.LifecycleHandler{Sleep: &v1.SleepAction{Seconds: 5}}
+
+	flagSourceConfig := getFlagSourceConfigSpec()
+	flagSourceConfig.DefaultSyncProvider = apicommon.SyncProviderGrpc
+	flagSourceConfig.Sources = []api.Source{{}}
+	flagSourceConfig.ProbesEnabled = ptr.To(true)
+	flagSourceConfig.Sidecar = &api.SidecarSpec{
+		SecurityContext: securityContext,
+		LivenessProbe: &api.ProbeSpec{
+			PeriodSeconds:    ptr.To(int32(20)),
+			FailureThreshold: ptr.To(int32(6)),
+			SuccessThreshold: ptr.To(int32(2)),
+		},
+		ReadinessProbe: &api.ProbeSpec{
+			InitialDelaySeconds: ptr.To(int32(0)),
+			TimeoutSeconds:      ptr.To(int32(3)),
+			SuccessThreshold:    ptr.To(int32(2)),
+		},
+		StartupProbe: &api.ProbeSpec{
+			PeriodSeconds:    ptr.To(int32(2)),
+			FailureThreshold: ptr.To(int32(30)),
+			SuccessThreshold: ptr.To(int32(2)),
+		},
+		PreStop:                       preStop,
+		TerminationGracePeriodSeconds: ptr.To(int64(45)),
+	}
+
+	err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+
+	flagd := pod.Spec.InitContainers[0]
+	require.Equal(t, securityContext, flagd.SecurityContext)
+
+	require.Equal(t, int32(common.ProbeInitialDelay), flagd.LivenessProbe.InitialDelaySeconds)
+	require.Equal(t, int32(20), flagd.LivenessProbe.PeriodSeconds)
+	require.Equal(t, int32(6), flagd.LivenessProbe.FailureThreshold)
+	// the API server rejects a success threshold other than 1 for liveness and startup probes
+	require.Equal(t, int32(0), flagd.LivenessProbe.SuccessThreshold)
+
+	require.Equal(t, int32(0), flagd.ReadinessProbe.InitialDelaySeconds)
+	require.Equal(t, int32(3), flagd.ReadinessProbe.TimeoutSeconds)
+	require.Equal(t, int32(2), flagd.ReadinessProbe.SuccessThreshold)
+
+	require.NotNil(t, flagd.StartupProbe)
+	require.Equal(t, common.ProbeLiveness, flagd.StartupProbe.HTTPGet.Path)
+	require.Equal(t, int32(2), flagd.StartupProbe.PeriodSeconds)
+	require.Equal(t, int32(30), flagd.StartupProbe.FailureThreshold)
+	require.Equal(t, int32(0), flagd.StartupProbe.SuccessThreshold)
+
+	require.Equal(t, &v1.Lifecycle{PreStop: preStop}, flagd.Lifecycle)
+	require.Equal(t, int64(45), *pod.Spec.TerminationGracePeriodSeconds)
+
+	// a longer grace period of the workload is kept
+	pod.Spec.TerminationGracePeriodSeconds = ptr.To(int64(120))
+
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+	require.Equal(t, int64(120), *pod.Spec.TerminationGracePeriodSeconds)
+
+	// probe overrides are ignored if probes are disabled
+	flagSourceConfig.ProbesEnabled = ptr.To(false)
+
+	err = fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+	require.Nil(t, err)
+	require.Nil(t, pod.Spec.InitContainers[0].LivenessProbe)
+	require.Nil(t, pod.Spec.InitContainers[0].StartupProbe)
+}
+
+func TestFlagdContainerInjector_InjectDefaultSyncProvider_WithResourceAnnotations(t *testing.T) {
+	namespace, fakeClient := initContainerInjectionTestEnv()
+
+	fi := &FlagdContainerInjector{
+		Client:                    fakeClient,
+		Logger:                    testr.New(t),
+		FlagdProxyConfig:          getProxyConfig(),
+		FlagdResourceRequirements: getResourceRequirements(),
+		FlagdResourceBounds: ResourceBounds{
+			Min: v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("100m"),
+			},
+			Max: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("2"),
+				v1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		},
+		Image: testImage,
+		Tag:   testTag,
+	}
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantErr       bool
+		wantResources v1.ResourceRequirements
+	}{
+		{
+			name: "no annotations",
+			wantResources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("0.5"),
+					v1.ResourceMemory: resource.MustParse("20M"),
+				},
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("0.2"),
+					v1.ResourceMemory: resource.MustParse("10M"),
+				},
+			},
+		},
+		{
+			name: "overrides within bounds",
+			annotations: map[string]string{
+				"openfeature.dev/sidecar-cpu-request": "500m",
+				"openfeature.dev/sidecar-cpu-limit":   "1",
+				"openfeature.dev/sidecar-ram-limit":   "128Mi",
+			},
+			wantResources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("1"),
+					v1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Requests: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("500m"),
+					v1.ResourceMemory: resource.MustParse("10M"),
+				},
+			},
+		},
+		{
+			name: "above maximum",
+			annotations: map[string]string{
+				"openfeature.dev/sidecar-ram-limit": "1Gi",
+			},
+			wantErr: true,
+		},
+		{
+			name: "below minimum",
+			annotations: map[string]string{
+				"openfeature.dev/sidecar-cpu-request": "10m",
+			},
+			wantErr: true,
+		},
+		{
+			name: "request higher than limit",
+			annotations: map[string]string{
+				"openfeature.dev/sidecar-cpu-request": "1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid quantity",
+			annotations: map[string]string{
+				"openfeature.dev/sidecar-cpu-limit": "a lot",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := generatePod([]v1.Container{generateContainer()}, nil, nil, namespace)
+			pod.Annotations = tt.annotations
+
+			flagSourceConfig := getFlagSourceConfigSpec()
+			flagSourceConfig.DefaultSyncProvider = apicommon.SyncProviderGrpc
+			flagSourceConfig.Sources = []api.Source{{}}
+
+			err := fi.InjectFlagd(context.Background(), &pod.ObjectMeta, &pod.Spec, flagSourceConfig)
+			if tt.wantErr {
+				require.True(t, errors.Is(err, common.ErrInvalidSidecarResources))
+				return
+			}
+			require.Nil(t, err)
+
+			resources := pod.Spec.InitContainers[0].Resources
+			for name, want := range tt.wantResources.Limits {
+				require.Zero(t, want.Cmp(resources.Limits[name]), "limit %s", name)
+			}
+			for name, want := range tt.wantResources.Requests {
+				require.Zero(t, want.Cmp(resources.Requests[name]), "request %s", name)
+			}
+		})
+	}
+
+	// the operator defaults are not modified by the overrides
+	require.Equal(t, getResourceRequirements(), fi.FlagdResourceRequirements)
+}
+
 func TestFlagdContainerInjector_createConfigMap(t *testing.T) {
 	_ = api.AddToScheme(scheme.Scheme)
 
@@ -938,12 +1820,6 @@ func initContainerInjectionTestEnv() (string, client.WithWatch) {
 		},
 	}
 
-	cbr := &rbacv1.ClusterRoleBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: common.ClusterRoleBindingName,
-		},
-	}
-
 	ffConfig := &api.FeatureFlag{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "server-side",
@@ -953,7 +1829,7 @@ func initContainerInjectionTestEnv() (string, client.WithWatch) {
 	}
 
 	fakeClientBuilder := fake.NewClientBuilder().
-		WithScheme(scheme.Scheme).WithObjects(ffConfig, serviceAccount, cbr)
+		WithScheme(scheme.Scheme).WithObjects(ffConfig, serviceAccount)
 
 	fakeClient := fakeClientBuilder.Build()
 	return namespace, fakeClient
@@ -1294,16 +2170,16 @@ func Test_getSecurityContext(t *testing.T) {
 	}
 }
 
-func TestFlagdContainerInjector_EnableClusterRoleBinding_AddDefaultServiceAccountName(t *testing.T) {
-	enableClusterRoleBindingTest(t, "default", "")
+func TestFlagdContainerInjector_EnableKubernetesSyncPermissions_AddDefaultServiceAccountName(t *testing.T) {
+	enableKubernetesSyncPermissionsTest(t, "default", "")
 }
 
-func TestFlagdContainerInjector_EnableClusterRoleBinding_ServiceAccountName(t *testing.T) {
-	enableClusterRoleBindingTest(t, "my-serviceaccount", "my-serviceaccount")
+func TestFlagdContainerInjector_EnableKubernetesSyncPermissions_ServiceAccountName(t *testing.T) {
+	enableKubernetesSyncPermissionsTest(t, "my-serviceaccount", "my-serviceaccount")
 }
 
-func enableClusterRoleBindingTest(t *testing.T, name string, input string) {
-	namespace, fakeClient := initEnableClusterroleBindingTestEnv()
+func enableKubernetesSyncPermissionsTest(t *testing.T, name string, input string) {
+	namespace, fakeClient := initEnableKubernetesSyncPermissionsTestEnv()
 
 	serviceAccount := &v1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1312,18 +2188,9 @@ func enableClusterRoleBindingTest(t *testing.T, name string, input string) {
 		},
 	}
 
-	crb := &rbacv1.ClusterRoleBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: common.ClusterRoleBindingName,
-		},
-	}
-
 	err := fakeClient.Create(context.Background(), serviceAccount)
 	require.Nil(t, err)
 
-	err = fakeClient.Create(context.Background(), crb)
-	require.Nil(t, err)
-
 	fi := &FlagdContainerInjector{
 		Client:                    fakeClient,
 		Logger:                    testr.New(t),
@@ -1333,81 +2200,56 @@ func enableClusterRoleBindingTest(t *testing.T, name string, input string) {
 		Tag:                       testTag,
 	}
 
-	err = fi.EnableClusterRoleBinding(context.Background(), namespace, input)
+	err = fi.EnableKubernetesSyncPermissions(context.Background(), namespace, input, []client.ObjectKey{
+		{Namespace: "flags", Name: "my-flags"},
+	})
 	require.Nil(t, err)
 
-	updatedCrb := &rbacv1.ClusterRoleBinding{}
-	err = fakeClient.Get(context.Background(), client.ObjectKey{Name: crb.Name}, updatedCrb)
+	key := client.ObjectKey{Namespace: "flags", Name: kubernetessync.Name(client.ObjectKey{Namespace: namespace, Name: name})}
 
+	role := &rbacv1.Role{}
+	err = fakeClient.Get(context.Background(), key, role)
 	require.Nil(t, err)
+	require.Equal(t, []string{"my-flags"}, role.Rules[0].ResourceNames)
 
-	require.Len(t, updatedCrb.Subjects, 1)
-	require.Equal(t, name, updatedCrb.Subjects[0].Name)
-	require.Equal(t, namespace, updatedCrb.Subjects[0].Namespace)
-}
-
-func TestFlagdContainerInjector_EnableClusterRoleBinding_ServiceAccountAlreadyIncluded(t *testing.T) {
-	namespace, fakeClient := initEnableClusterroleBindingTestEnv()
-
-	serviceAccount := &v1.ServiceAccount{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "my-serviceaccount",
-			Namespace: namespace,
-		},
-	}
-
-	crb := &rbacv1.ClusterRoleBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: common.ClusterRoleBindingName,
-		},
-		Subjects: []rbacv1.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Name:      serviceAccount.Name,
-				Namespace: serviceAccount.Namespace,
-			},
-		},
-	}
-
-	err := fakeClient.Create(context.Background(), serviceAccount)
+	rb := &rbacv1.RoleBinding{}
+	err = fakeClient.Get(context.Background(), key, rb)
 	require.Nil(t, err)
+	require.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: name, Namespace: namespace}}, rb.Subjects)
+	require.Equal(t, key.Name, rb.RoleRef.Name)
 
-	err = fakeClient.Create(context.Background(), crb)
+	// no permissions are granted cluster wide
+	crbs := &rbacv1.ClusterRoleBindingList{}
+	err = fakeClient.List(context.Background(), crbs)
 	require.Nil(t, err)
+	require.Empty(t, crbs.Items)
+}
+
+func TestFlagdContainerInjector_EnableKubernetesSyncPermissions_ServiceAccountNotFound(t *testing.T) {
+	namespace, fakeClient := initEnableKubernetesSyncPermissionsTestEnv()
 
 	fi := &FlagdContainerInjector{
 		Client:                    fakeClient,
 		Logger:                    testr.New(t),
 		FlagdProxyConfig:          getProxyConfig(),
 		FlagdResourceRequirements: getResourceRequirements(),
-		Image:                     testImage,
-		Tag:                       testTag,
 	}
 
-	err = fi.EnableClusterRoleBinding(context.Background(), namespace, "my-serviceaccount")
-	require.Nil(t, err)
-
-	updatedCrb := &rbacv1.ClusterRoleBinding{}
-	err = fakeClient.Get(context.Background(), client.ObjectKey{Name: crb.Name}, updatedCrb)
-
-	require.Nil(t, err)
-
-	require.Len(t, updatedCrb.Subjects, 1)
-	require.Equal(t, "my-serviceaccount", updatedCrb.Subjects[0].Name)
-	require.Equal(t, namespace, updatedCrb.Subjects[0].Namespace)
+	err := fi.EnableKubernetesSyncPermissions(context.Background(), namespace, "my-serviceaccount", []client.ObjectKey{
+		{Namespace: namespace, Name: "my-flags"},
+	})
+	require.NotNil(t, err)
 }
 
-func TestFlagdContainerInjector_EnableClusterRoleBinding_ClusterRoleBindingNotFound(t *testing.T) {
-	namespace, fakeClient := initEnableClusterroleBindingTestEnv()
+func TestFlagdContainerInjector_EnableKubernetesSyncPermissions_NamespaceNotWatched(t *testing.T) {
+	namespace, fakeClient := initEnableKubernetesSyncPermissionsTestEnv()
 
-	serviceAccount := &v1.ServiceAccount{
+	err := fakeClient.Create(context.Background(), &v1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "my-serviceaccount",
+			Name:      "default",
 			Namespace: namespace,
 		},
-	}
-
-	err := fakeClient.Create(context.Background(), serviceAccount)
+	})
 	require.Nil(t, err)
 
 	fi := &FlagdContainerInjector{
@@ -1415,29 +2257,22 @@ func TestFlagdContainerInjector_EnableClusterRoleBinding_ClusterRoleBindingNotFo
 		Logger:                    testr.New(t),
 		FlagdProxyConfig:          getProxyConfig(),
 		FlagdResourceRequirements: getResourceRequirements(),
-		Image:                     testImage,
-		Tag:                       testTag,
+		Namespaces:                []string{namespace},
 	}
 
-	err = fi.EnableClusterRoleBinding(context.Background(), namespace, "my-serviceaccount")
-	require.NotNil(t, err)
-}
+	err = fi.EnableKubernetesSyncPermissions(context.Background(), namespace, "", []client.ObjectKey{
+		{Namespace: namespace, Name: "my-flags"},
+		{Namespace: "flags", Name: "my-flags"},
+	})
+	require.ErrorContains(t, err, "FeatureFlag flags/my-flags is not in a namespace watched by the operator")
 
-func TestFlagdContainerInjector_EnableClusterRoleBinding_ServiceAccountNotFound(t *testing.T) {
-	namespace, fakeClient := initEnableClusterroleBindingTestEnv()
-
-	fi := &FlagdContainerInjector{
-		Client:                    fakeClient,
-		Logger:                    testr.New(t),
-		FlagdProxyConfig:          getProxyConfig(),
-		FlagdResourceRequirements: getResourceRequirements(),
-	}
-
-	err := fi.EnableClusterRoleBinding(context.Background(), namespace, "my-serviceaccount")
-	require.NotNil(t, err)
+	roles := &rbacv1.RoleList{}
+	err = fakeClient.List(context.Background(), roles)
+	require.Nil(t, err)
+	require.Empty(t, roles.Items)
 }
 
-func initEnableClusterroleBindingTestEnv() (string, client.WithWatch) {
+func initEnableKubernetesSyncPermissionsTestEnv() (string, client.WithWatch) {
 	namespace := "my-namespace"
 
 	_ = api.AddToScheme(scheme.Scheme)
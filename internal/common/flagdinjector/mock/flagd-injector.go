@@ -12,6 +12,7 @@ import (
 	v1beta1 "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // MockIFlagdContainerInjector is a mock of IFlagdContainerInjector interface.
@@ -37,18 +38,32 @@ func (m *MockIFlagdContainerInjector) EXPECT() *MockIFlagdContainerInjectorMockR
 	return m.recorder
 }
 
-// EnableClusterRoleBinding mocks base method.
-func (m *MockIFlagdContainerInjector) EnableClusterRoleBinding(ctx context.Context, namespace, serviceAccountName string) error {
+// EnableKubernetesSyncPermissions mocks base method.
+func (m *MockIFlagdContainerInjector) EnableKubernetesSyncPermissions(ctx context.Context, namespace, serviceAccountName string, featureFlags []client.ObjectKey) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnableClusterRoleBinding", ctx, namespace, serviceAccountName)
+	ret := m.ctrl.Call(m, "EnableKubernetesSyncPermissions", ctx, namespace, serviceAccountName, featureFlags)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// EnableClusterRoleBinding indicates an expected call of EnableClusterRoleBinding.
-func (mr *MockIFlagdContainerInjectorMockRecorder) EnableClusterRoleBinding(ctx, namespace, serviceAccountName interface{}) *gomock.Call {
+// EnableKubernetesSyncPermissions indicates an expected call of EnableKubernetesSyncPermissions.
+func (mr *MockIFlagdContainerInjectorMockRecorder) EnableKubernetesSyncPermissions(ctx, namespace, serviceAccountName, featureFlags interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableClusterRoleBinding", reflect.TypeOf((*MockIFlagdContainerInjector)(nil).EnableClusterRoleBinding), ctx, namespace, serviceAccountName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableKubernetesSyncPermissions", reflect.TypeOf((*MockIFlagdContainerInjector)(nil).EnableKubernetesSyncPermissions), ctx, namespace, serviceAccountName, featureFlags)
+}
+
+// EnsureDependencies mocks base method.
+func (m *MockIFlagdContainerInjector) EnsureDependencies(ctx context.Context, objectMeta *v10.ObjectMeta, podSpec *v1.PodSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureDependencies", ctx, objectMeta, podSpec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureDependencies indicates an expected call of EnsureDependencies.
+func (mr *MockIFlagdContainerInjectorMockRecorder) EnsureDependencies(ctx, objectMeta, podSpec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureDependencies", reflect.TypeOf((*MockIFlagdContainerInjector)(nil).EnsureDependencies), ctx, objectMeta, podSpec)
 }
 
 // InjectFlagd mocks base method.
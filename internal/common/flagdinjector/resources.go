@@ -0,0 +1,72 @@
+package flagdinjector
+
+import (
+	"fmt"
+
+	"github.com/open-feature/open-feature-operator/internal/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceBounds defines the minimum and maximum sidecar resources which can be requested via pod annotations,
+// resources without a bound are not restricted
+type ResourceBounds struct {
+	Min corev1.ResourceList
+	Max corev1.ResourceList
+}
+
+type resourceAnnotation struct {
+	annotation string
+	resource   corev1.ResourceName
+	limit      bool
+}
+
+var resourceAnnotations = []resourceAnnotation{
+	{annotation: common.SidecarCpuRequestAnnotation, resource: corev1.ResourceCPU},
+	{annotation: common.SidecarCpuLimitAnnotation, resource: corev1.ResourceCPU, limit: true},
+	{annotation: common.SidecarRamRequestAnnotation, resource: corev1.ResourceMemory},
+	{annotation: common.SidecarRamLimitAnnotation, resource: corev1.ResourceMemory, limit: true},
+}
+
+// applyResourceAnnotations overrides the sidecar resources with the values of the sidecar resource annotations
+// of the pod, the values have to be within the configured bounds
+func (fi *FlagdContainerInjector) applyResourceAnnotations(objectMeta *metav1.ObjectMeta, resources *corev1.ResourceRequirements) error {
+	for _, ra := range resourceAnnotations {
+		key := fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, ra.annotation)
+		val, ok := objectMeta.Annotations[key]
+		if !ok {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(val)
+		if err != nil {
+			return fmt.Errorf("could not parse annotation %s=%q: %w", key, val, common.ErrInvalidSidecarResources)
+		}
+		if min, ok := fi.FlagdResourceBounds.Min[ra.resource]; ok && quantity.Cmp(min) < 0 {
+			return fmt.Errorf("annotation %s=%s is below the minimum of %s: %w", key, val, min.String(), common.ErrInvalidSidecarResources)
+		}
+		if max, ok := fi.FlagdResourceBounds.Max[ra.resource]; ok && quantity.Cmp(max) > 0 {
+			return fmt.Errorf("annotation %s=%s is above the maximum of %s: %w", key, val, max.String(), common.ErrInvalidSidecarResources)
+		}
+		if ra.limit {
+			resources.Limits = setResource(resources.Limits, ra.resource, quantity)
+		} else {
+			resources.Requests = setResource(resources.Requests, ra.resource, quantity)
+		}
+	}
+
+	for name, request := range resources.Requests {
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return fmt.Errorf("sidecar %s request %s is higher than the limit %s: %w", name, request.String(), limit.String(), common.ErrInvalidSidecarResources)
+		}
+	}
+	return nil
+}
+
+func setResource(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) corev1.ResourceList {
+	if list == nil {
+		list = corev1.ResourceList{}
+	}
+	list[name] = quantity
+	return list
+}
@@ -0,0 +1,200 @@
+package flagdinjector
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	telemetryTLSVolumeName = "flagd-otel-tls"
+	telemetryTLSMountPath  = "/etc/flagd/otel-tls"
+	appNameLabel           = "app.kubernetes.io/name"
+	defaultServiceName     = "flagd"
+	telemetryProtocolHTTP  = "http/protobuf"
+	metricsSignalPath      = "/v1/metrics"
+	tracesSignalPath       = "/v1/traces"
+)
+
+// resourceAttributeEscaper percent-encodes the characters separating the entries of OTEL_RESOURCE_ATTRIBUTES
+var resourceAttributeEscaper = strings.NewReplacer("%", "%25", ",", "%2C", "=", "%3D")
+
+// applyTelemetrySpec maps the telemetry configuration to flagd args and OTEL_* env vars of the flagd container,
+// the env vars are not shared with the other containers of the pod
+func applyTelemetrySpec(objectMeta *metav1.ObjectMeta, podSpec *corev1.PodSpec, container *corev1.Container, telemetry *api.TelemetrySpec) error {
+	insecure := telemetry.TLS != nil && telemetry.TLS.Insecure
+	if insecure && telemetry.TLS.SecretName != "" {
+		return fmt.Errorf("could not configure the telemetry export: %w", common.ErrInvalidTelemetryTLS)
+	}
+
+	collectorUri := telemetry.MetricsEndpoint
+	if collectorUri == "" {
+		collectorUri = telemetry.TracesEndpoint
+	}
+	if collectorUri != "" {
+		container.Args = append(container.Args, "--metrics-exporter", "otel", "--otel-collector-uri", collectorUri)
+	}
+
+	var env []corev1.EnvVar
+	if telemetry.Protocol != "" {
+		env = append(env, corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: telemetry.Protocol})
+	}
+	if telemetry.MetricsEndpoint != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+			Value: otlpSignalEndpoint(telemetry.MetricsEndpoint, telemetry.Protocol, insecure, metricsSignalPath),
+		})
+	}
+	if collectorUri != "" {
+		tracesEndpoint := telemetry.TracesEndpoint
+		if tracesEndpoint == "" {
+			tracesEndpoint = collectorUri
+		}
+		env = append(env, corev1.EnvVar{
+			Name:  "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+			Value: otlpSignalEndpoint(tracesEndpoint, telemetry.Protocol, insecure, tracesSignalPath),
+		})
+	}
+
+	env = append(env, telemetryHeaderEnvVars(telemetry.Headers)...)
+	env = append(env, telemetryResourceEnvVars(objectMeta, telemetry)...)
+
+	if telemetry.Sampler != nil {
+		env = append(env, corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER", Value: telemetry.Sampler.Type})
+		if telemetry.Sampler.Ratio != "" {
+			env = append(env, corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: telemetry.Sampler.Ratio})
+		}
+	}
+
+	if tls := telemetry.TLS; tls != nil {
+		if tls.Insecure {
+			env = append(env, corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_INSECURE", Value: "true"})
+		}
+		if tls.SecretName != "" {
+			if err := mountTelemetryTLSSecret(podSpec, container, tls.SecretName); err != nil {
+				return err
+			}
+			container.Args = append(container.Args, "--otel-ca-path", fmt.Sprintf("%s/%s", telemetryTLSMountPath, "ca.crt"))
+			if tls.ClientCertificate {
+				container.Args = append(container.Args,
+					"--otel-cert-path", fmt.Sprintf("%s/%s", telemetryTLSMountPath, corev1.TLSCertKey),
+					"--otel-key-path", fmt.Sprintf("%s/%s", telemetryTLSMountPath, corev1.TLSPrivateKeyKey),
+				)
+			}
+		}
+	}
+
+	container.Env = append(container.Env, env...)
+	return nil
+}
+
+// otlpSignalEndpoint returns the URL of a per-signal OTLP endpoint variable, which requires a scheme unlike the
+// collector address. The scheme defaults to https unless TLS is disabled, the signal path is appended for
+// http/protobuf if the endpoint has no path
+func otlpSignalEndpoint(endpoint, protocol string, insecure bool, signalPath string) string {
+	if !strings.Contains(endpoint, "://") {
+		scheme := "https"
+		if insecure {
+			scheme = "http"
+		}
+		endpoint = fmt.Sprintf("%s://%s", scheme, endpoint)
+	}
+	if protocol != telemetryProtocolHTTP {
+		return endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || strings.Trim(u.Path, "/") != "" {
+		return endpoint
+	}
+	u.Path = signalPath
+	return u.String()
+}
+
+// telemetryHeaderEnvVars reads each header value from its Secret into a dedicated env var, which is referenced
+// by OTEL_EXPORTER_OTLP_HEADERS through dependent env var expansion
+func telemetryHeaderEnvVars(headers []api.TelemetryHeader) []corev1.EnvVar {
+	if len(headers) == 0 {
+		return nil
+	}
+	var env []corev1.EnvVar
+	pairs := make([]string, 0, len(headers))
+	for i, header := range headers {
+		name := fmt.Sprintf("OTEL_EXPORTER_OTLP_HEADER_%d", i)
+		env = append(env, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: header.ValueFrom.DeepCopy(),
+			},
+		})
+		pairs = append(pairs, fmt.Sprintf("%s=$(%s)", header.Name, name))
+	}
+	return append(env, corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: strings.Join(pairs, ",")})
+}
+
+// telemetryResourceEnvVars sets the service name and the resource attributes, the pod name and namespace are
+// resolved through the downward API as they might not be known at admission time
+func telemetryResourceEnvVars(objectMeta *metav1.ObjectMeta, telemetry *api.TelemetrySpec) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		fieldRefEnvVar("OTEL_K8S_NAMESPACE_NAME", "metadata.namespace"),
+		fieldRefEnvVar("OTEL_K8S_POD_NAME", "metadata.name"),
+	}
+
+	switch {
+	case telemetry.ServiceName != "":
+		env = append(env, corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: telemetry.ServiceName})
+	case objectMeta.Labels[appNameLabel] != "":
+		env = append(env, fieldRefEnvVar("OTEL_SERVICE_NAME", fmt.Sprintf("metadata.labels['%s']", appNameLabel)))
+	default:
+		env = append(env, corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: defaultServiceName})
+	}
+
+	attributes := []string{
+		"k8s.namespace.name=$(OTEL_K8S_NAMESPACE_NAME)",
+		"k8s.pod.name=$(OTEL_K8S_POD_NAME)",
+	}
+	keys := make([]string, 0, len(telemetry.ResourceAttributes))
+	for k := range telemetry.ResourceAttributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attributes = append(attributes, fmt.Sprintf("%s=%s",
+			resourceAttributeEscaper.Replace(k), resourceAttributeEscaper.Replace(telemetry.ResourceAttributes[k])))
+	}
+	return append(env, corev1.EnvVar{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: strings.Join(attributes, ",")})
+}
+
+func fieldRefEnvVar(name, fieldPath string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fieldPath,
+			},
+		},
+	}
+}
+
+func mountTelemetryTLSSecret(podSpec *corev1.PodSpec, container *corev1.Container, secretName string) error {
+	if err := addVolume(podSpec, corev1.Volume{
+		Name: telemetryTLSVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}); err != nil {
+		return err
+	}
+	return addVolumeMount(container, corev1.VolumeMount{
+		Name:      telemetryTLSVolumeName,
+		MountPath: telemetryTLSMountPath,
+		ReadOnly:  true,
+	})
+}
@@ -3,10 +3,15 @@ package flagdproxy
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"sync"
 
 	"github.com/go-logr/logr"
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/apply"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
+	"github.com/open-feature/open-feature-operator/internal/common/monitoring"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
 	"github.com/open-feature/open-feature-operator/internal/common/types"
 	"golang.org/x/exp/maps"
 	appsV1 "k8s.io/api/apps/v1"
@@ -15,7 +20,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -25,12 +29,18 @@ const (
 	FlagdProxyServiceAccountName      = "open-feature-operator-flagd-proxy"
 	FlagdProxyServiceName             = "flagd-proxy-svc"
 	FlagdProxyPodDisruptionBudgetName = "flagd-proxy-pdb"
+	FlagdProxyPodMonitorName          = "flagd-proxy"
 )
 
 type FlagdProxyHandler struct {
 	client.Client
+	// Reader reads the operator Deployment, which is not cached. Defaults to the Client
+	Reader client.Reader
 	config *FlagdProxyConfiguration
 	Log    logr.Logger
+
+	// mu guards the configuration against changes of the OperatorConfiguration
+	mu sync.RWMutex
 }
 
 type CreateUpdateFunc func(ctx context.Context, obj client.Object) error
@@ -48,6 +58,7 @@ type FlagdProxyConfiguration struct {
 	Labels                 map[string]string
 	Annotations            map[string]string
 	ClusterDomain          string
+	Monitoring             api.MonitoringSpec
 }
 
 func NewFlagdProxyConfiguration(env types.EnvConfig, imagePullSecrets []string, labels map[string]string, annotations map[string]string) *FlagdProxyConfiguration {
@@ -64,6 +75,11 @@ func NewFlagdProxyConfiguration(env types.EnvConfig, imagePullSecrets []string,
 		Labels:                 labels,
 		Annotations:            annotations,
 		ClusterDomain:          env.FlagdClusterDomain,
+		Monitoring: api.MonitoringSpec{
+			Enabled:  env.FlagdProxyMonitoringEnabled,
+			Labels:   env.FlagdProxyMonitoringLabels,
+			Interval: env.FlagdProxyMonitoringInterval,
+		},
 	}
 }
 
@@ -76,70 +92,52 @@ func NewFlagdProxyHandler(config *FlagdProxyConfiguration, client client.Client,
 }
 
 func (f *FlagdProxyHandler) Config() *FlagdProxyConfiguration {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 	return f.config
 }
 
-func specDiffers(a, b client.Object) (bool, error) {
-	if a == nil || b == nil {
-		return false, fmt.Errorf("object is nil")
-	}
+// ApplyConfiguration replaces the configuration of the flagd-proxy, it is rolled out on the next reconciliation of a
+// FeatureFlagSource using the flagd-proxy
+func (f *FlagdProxyHandler) ApplyConfiguration(cfg operatorconfig.Configuration) {
+	config := NewFlagdProxyConfiguration(cfg.Env, cfg.ImagePullSecrets, cfg.Labels, cfg.Annotations)
 
-	// Compare only spec based on the object type
-	switch a.(type) {
-	case *corev1.Service:
-		return !reflect.DeepEqual(a.(*corev1.Service).Spec, b.(*corev1.Service).Spec), nil
-	case *appsV1.Deployment:
-		return !reflect.DeepEqual(a.(*appsV1.Deployment).Spec, b.(*appsV1.Deployment).Spec), nil
-	case *policyv1.PodDisruptionBudget:
-		return !reflect.DeepEqual(a.(*policyv1.PodDisruptionBudget).Spec, b.(*policyv1.PodDisruptionBudget).Spec), nil
-	default:
-		return false, fmt.Errorf("unsupported object type")
-	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
 }
 
-// ensureFlagdProxyResource ensures that the given object is reconciled in the cluster. If the object does not exist, it will be created.
+// ensureFlagdProxyResource applies the given object with server-side apply, unless the fields applied by the operator
+// already match it
 func (f *FlagdProxyHandler) ensureFlagdProxyResource(ctx context.Context, obj client.Object) error {
 	if obj == nil {
 		return fmt.Errorf("object is nil")
 	}
+	f.Log.Info("Ensuring object exists", "name", obj.GetName(), "namespace", obj.GetNamespace())
 
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		var old = obj.DeepCopyObject().(client.Object)
-		f.Log.Info("Ensuring object exists", "name", obj.GetName(), "namespace", obj.GetNamespace())
-
-		// Try to get the existing object
-		err := f.Client.Get(ctx, client.ObjectKey{Name: old.GetName(), Namespace: old.GetNamespace()}, old)
-		notFound := errors.IsNotFound(err)
-		if err != nil && !notFound {
-			return err
-		}
-
-		// If the object is not found, we will create it
-		if notFound {
-			return f.Client.Create(ctx, obj)
-		}
+	existing := obj.DeepCopyObject().(client.Object)
+	err := f.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err == nil {
 		// If the object exists but is not managed by OFO, return an error
-		if !common.IsManagedByOFO(old) {
+		if !common.IsManagedByOFO(existing) {
 			return fmt.Errorf("%s not managed by OFO", obj.GetName())
 		}
-
-		// If the object is found, update if necessary
-		needsUpdate, err := specDiffers(obj, old)
-		if err != nil {
+		differs, err := apply.Differs(existing, obj)
+		if err != nil || !differs {
 			return err
 		}
-
-		if needsUpdate {
-			obj.SetResourceVersion(old.GetResourceVersion())
-			return f.Client.Update(ctx, obj)
-		}
-
-		return nil
-	})
+	}
+	return apply.Apply(ctx, f.Client, obj)
 }
 
 // HandleFlagdProxy ensures flagd-proxy kubernetes components are configured properly
 func (f *FlagdProxyHandler) HandleFlagdProxy(ctx context.Context) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
 	var err error
 
 	ownerRef, err := f.getOwnerReference(ctx)
@@ -155,8 +153,41 @@ func (f *FlagdProxyHandler) HandleFlagdProxy(ctx context.Context) error {
 		return err
 	}
 
-	err = f.ensureFlagdProxyResource(ctx, f.newFlagdProxyPodDisruptionBudget(ownerRef))
-	return err
+	if err = f.ensureFlagdProxyResource(ctx, f.newFlagdProxyPodDisruptionBudget(ownerRef)); err != nil {
+		return err
+	}
+
+	return monitoring.Ensure(ctx, f.Client, f.newFlagdProxyPodMonitor(ownerRef))
+}
+
+// UpdateFlagdProxy rolls out the configuration to an existing flagd-proxy, it is not created if no FeatureFlagSource
+// required it so far
+func (f *FlagdProxyHandler) UpdateFlagdProxy(ctx context.Context) error {
+	deployment := &metav1.PartialObjectMetadata{}
+	deployment.SetGroupVersionKind(appsV1.SchemeGroupVersion.WithKind("Deployment"))
+	err := f.Client.Get(ctx, client.ObjectKey{Name: FlagdProxyDeploymentName, Namespace: f.Config().Namespace}, deployment)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return f.HandleFlagdProxy(ctx)
+}
+
+func (f *FlagdProxyHandler) newFlagdProxyPodMonitor(ownerReference *metav1.OwnerReference) monitoring.Monitor {
+	return monitoring.Monitor{
+		GVK:       monitoring.PodMonitorGVK,
+		Name:      FlagdProxyPodMonitorName,
+		Namespace: f.config.Namespace,
+		Owner:     *ownerReference,
+		Spec:      f.config.Monitoring,
+		Selector: map[string]string{
+			"app.kubernetes.io/name":      FlagdProxyDeploymentName,
+			common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+		},
+		Port: "management-port",
+	}
 }
 
 func (f *FlagdProxyHandler) newFlagdProxyService(ownerReference *metav1.OwnerReference) *corev1.Service {
@@ -267,6 +298,7 @@ func (f *FlagdProxyHandler) newFlagdProxyDeployment(ownerReference *metav1.Owner
 				"app":                         FlagdProxyDeploymentName,
 				common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
 				"app.kubernetes.io/version":   f.config.Tag,
+				cachelabel.Label:              cachelabel.Value,
 			},
 			OwnerReferences: []metav1.OwnerReference{*ownerReference},
 		},
@@ -321,9 +353,15 @@ func (f *FlagdProxyHandler) newFlagdProxyDeployment(ownerReference *metav1.Owner
 	}
 }
 
-func (f *FlagdProxyHandler) getOperatorDeployment(ctx context.Context) (*appsV1.Deployment, error) {
-	d := &appsV1.Deployment{}
-	if err := f.Client.Get(ctx, client.ObjectKey{Name: f.config.OperatorDeploymentName, Namespace: f.config.Namespace}, d); err != nil {
+// getOperatorDeployment reads the metadata of the operator Deployment, the owner of the flagd-proxy resources
+func (f *FlagdProxyHandler) getOperatorDeployment(ctx context.Context) (*metav1.PartialObjectMetadata, error) {
+	reader := f.Reader
+	if reader == nil {
+		reader = f.Client
+	}
+	d := &metav1.PartialObjectMetadata{}
+	d.SetGroupVersionKind(appsV1.SchemeGroupVersion.WithKind("Deployment"))
+	if err := reader.Get(ctx, client.ObjectKey{Name: f.config.OperatorDeploymentName, Namespace: f.config.Namespace}, d); err != nil {
 		return nil, fmt.Errorf("unable to fetch operator deployment: %w", err)
 	}
 	return d, nil
@@ -7,13 +7,22 @@ import (
 
 	"github.com/go-logr/logr/testr"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/apply"
+	applyfake "github.com/open-feature/open-feature-operator/internal/common/apply/fake"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
+	"github.com/open-feature/open-feature-operator/internal/common/monitoring"
 	"github.com/open-feature/open-feature-operator/internal/common/types"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -63,6 +72,7 @@ var (
 				"app":                          FlagdProxyDeploymentName,
 				"app.kubernetes.io/managed-by": common.ManagedByAnnotationValue,
 				"app.kubernetes.io/version":    testTag,
+				cachelabel.Label:               cachelabel.Value,
 			},
 			ResourceVersion: "1",
 			OwnerReferences: []metav1.OwnerReference{
@@ -255,7 +265,7 @@ func TestNewFlagdProxyHandler(t *testing.T) {
 
 	require.NotNil(t, kpConfig)
 
-	fakeClient := fake.NewClientBuilder().Build()
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(applyfake.Interceptor()).Build()
 
 	ph := NewFlagdProxyHandler(kpConfig, fakeClient, testr.New(t))
 
@@ -272,7 +282,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_ProxyExistsWithBadVersion(t *testing
 
 	require.NotNil(t, kpConfig)
 
-	fakeClient := fake.NewClientBuilder().WithObjects(createOFOTestDeployment(env.PodNamespace)).Build()
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(createOFOTestDeployment(env.PodNamespace)).Build()
 
 	ownerRef, err := getTestOFODeploymentOwnerRef(fakeClient, env.PodNamespace)
 	require.Nil(t, err)
@@ -349,7 +359,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_ProxyExistsWithoutLabel(t *testing.T
 		},
 	}
 
-	fakeClient := fake.NewClientBuilder().WithObjects(createOFOTestDeployment(env.PodNamespace), proxyDeployment).Build()
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(createOFOTestDeployment(env.PodNamespace), proxyDeployment).Build()
 
 	ph := NewFlagdProxyHandler(kpConfig, fakeClient, testr.New(t))
 
@@ -380,7 +390,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_ProxyExistsWithNewestVersion(t *test
 
 	require.NotNil(t, kpConfig)
 
-	fakeClient := fake.NewClientBuilder().WithObjects(createOFOTestDeployment(env.PodNamespace)).Build()
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(createOFOTestDeployment(env.PodNamespace)).Build()
 
 	ph := NewFlagdProxyHandler(kpConfig, fakeClient, testr.New(t))
 
@@ -416,7 +426,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_CreateProxy(t *testing.T) {
 
 	require.NotNil(t, kpConfig)
 
-	fakeClient := fake.NewClientBuilder().WithObjects(createOFOTestDeployment(testNamespace)).Build()
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(createOFOTestDeployment(testNamespace)).Build()
 
 	ph := NewFlagdProxyHandler(kpConfig, fakeClient, testr.New(t))
 
@@ -445,6 +455,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_CreateProxy(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, deployment)
 
+	requireApplied(t, deployment)
 	require.Equal(t, expectedDeployment, deployment)
 
 	service := &corev1.Service{}
@@ -456,6 +467,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_CreateProxy(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, service)
 
+	requireApplied(t, service)
 	require.Equal(t, expectedService, service)
 
 	pdb := &policyv1.PodDisruptionBudget{}
@@ -466,6 +478,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_CreateProxy(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, pdb)
 
+	requireApplied(t, pdb)
 	require.Equal(t, expectedPDB, pdb)
 }
 
@@ -473,7 +486,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_UpdateAllComponents(t *testing.T) {
 	kpConfig := NewFlagdProxyConfiguration(testEnvConfig, pullSecrets, labels, annotations)
 	require.NotNil(t, kpConfig)
 
-	fakeClient := fake.NewClientBuilder().WithObjects(createOFOTestDeployment(testNamespace)).Build()
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(createOFOTestDeployment(testNamespace)).Build()
 
 	ph := NewFlagdProxyHandler(kpConfig, fakeClient, testr.New(t))
 	require.NotNil(t, ph)
@@ -506,6 +519,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_UpdateAllComponents(t *testing.T) {
 	}, deploy))
 	updatedExpectedDeployment := expectedDeployment.DeepCopy()
 	updatedExpectedDeployment.ResourceVersion = "2"
+	requireApplied(t, deploy)
 	require.Equal(t, updatedExpectedDeployment, deploy)
 
 	require.Nil(t, fakeClient.Get(context.Background(), client.ObjectKey{
@@ -514,6 +528,7 @@ func TestFlagdProxyHandler_HandleFlagdProxy_UpdateAllComponents(t *testing.T) {
 	}, svc))
 	updatedExpectedService := expectedService.DeepCopy()
 	updatedExpectedService.ResourceVersion = "2"
+	requireApplied(t, svc)
 	require.Equal(t, updatedExpectedService, svc)
 
 	// pdb := expectedPDB.DeepCopy()
@@ -523,9 +538,17 @@ func TestFlagdProxyHandler_HandleFlagdProxy_UpdateAllComponents(t *testing.T) {
 	}, pdb))
 	updatedExpectedPDB := expectedPDB.DeepCopy()
 	updatedExpectedPDB.ResourceVersion = "2"
+	requireApplied(t, pdb)
 	require.Equal(t, updatedExpectedPDB, pdb)
 }
 
+// requireApplied checks that the object is applied by the operator and clears its managed fields
+func requireApplied(t *testing.T, obj client.Object) {
+	require.Len(t, obj.GetManagedFields(), 1)
+	require.Equal(t, apply.FieldManager, obj.GetManagedFields()[0].Manager)
+	obj.SetManagedFields(nil)
+}
+
 func createOFOTestDeployment(ns string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -551,3 +574,40 @@ func getTestOFODeploymentOwnerRef(c client.Client, ns string) (*metav1.OwnerRefe
 		Kind:       d.Kind,
 	}, nil
 }
+
+func TestFlagdProxyHandler_HandleFlagdProxy_CreatePodMonitor(t *testing.T) {
+	env := testEnvConfig
+	env.FlagdProxyMonitoringEnabled = true
+	env.FlagdProxyMonitoringLabels = map[string]string{"release": "prometheus"}
+	kpConfig := NewFlagdProxyConfiguration(env, pullSecrets, labels, annotations)
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+	mapper.Add(monitoring.PodMonitorGVK, meta.RESTScopeNamespace)
+	fakeClient := fake.NewClientBuilder().
+		WithInterceptorFuncs(applyfake.Interceptor()).
+		WithRESTMapper(meta.MultiRESTMapper{testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme), mapper}).
+		WithObjects(createOFOTestDeployment(testNamespace)).
+		Build()
+
+	ph := NewFlagdProxyHandler(kpConfig, fakeClient, testr.New(t))
+
+	err := ph.HandleFlagdProxy(context.Background())
+	require.Nil(t, err)
+
+	podMonitor := &unstructured.Unstructured{}
+	podMonitor.SetGroupVersionKind(monitoring.PodMonitorGVK)
+	err = fakeClient.Get(context.Background(), client.ObjectKey{
+		Namespace: testNamespace,
+		Name:      FlagdProxyPodMonitorName,
+	}, podMonitor)
+	require.Nil(t, err)
+	require.Equal(t, "prometheus", podMonitor.GetLabels()["release"])
+
+	selector, _, _ := unstructured.NestedStringMap(podMonitor.Object, "spec", "selector", "matchLabels")
+	require.Equal(t, map[string]string{
+		"app.kubernetes.io/name":      FlagdProxyDeploymentName,
+		common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+	}, selector)
+	endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+	require.Equal(t, "management-port", endpoints[0].(map[string]interface{})["port"])
+}
@@ -0,0 +1,295 @@
+package kubernetessync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/types"
+	"github.com/open-feature/open-feature-operator/internal/common/utils"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	NamePrefix = "open-feature-operator-flagd-kubernetes-sync-"
+	// Label marks the Roles and RoleBindings granting kubernetes sync permissions
+	Label = "openfeature.dev/kubernetes-sync"
+	// ServiceAccountAnnotation references the service account (namespace/name) a RoleBinding was created for
+	ServiceAccountAnnotation = "openfeature.dev/serviceaccount"
+	// GrantedAtAnnotation is the last time a RoleBinding was requested for a pod admission
+	GrantedAtAnnotation = "openfeature.dev/granted-at"
+	// GracePeriod during which a RoleBinding is kept without any pod using it, as permissions are granted
+	// before the admitted pod is created
+	GracePeriod = 5 * time.Minute
+	// refreshInterval limits the updates of the granted-at annotation
+	refreshInterval = time.Minute
+
+	flagdContainerName = "flagd"
+	maxNameLength      = 253
+)
+
+// Name returns the name of the Role and RoleBinding granting the given service account the kubernetes sync permissions,
+// the name is identical in every namespace holding FeatureFlags referenced by the service account
+func Name(serviceAccount client.ObjectKey) string {
+	sum := sha256.Sum256([]byte(serviceAccount.String()))
+	suffix := "-" + hex.EncodeToString(sum[:])[:10]
+	name := NamePrefix + serviceAccount.Name
+	if len(name)+len(suffix) > maxNameLength {
+		name = name[:maxNameLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// ServiceAccountOf returns the service account a Role or RoleBinding was created for
+func ServiceAccountOf(obj client.Object) (client.ObjectKey, bool) {
+	val, ok := obj.GetAnnotations()[ServiceAccountAnnotation]
+	if !ok {
+		return client.ObjectKey{}, false
+	}
+	ns, name := utils.ParseAnnotation(val, "")
+	if ns == "" || name == "" {
+		return client.ObjectKey{}, false
+	}
+	return client.ObjectKey{Namespace: ns, Name: name}, true
+}
+
+// PodFeatureFlags returns the FeatureFlags synced by the kubernetes provider of the flagd containers of the pod,
+// read from their --sources argument
+func PodFeatureFlags(pod *corev1.Pod) []client.ObjectKey {
+	return containerFeatureFlags(pod.Namespace, append(slices.Clone(pod.Spec.InitContainers), pod.Spec.Containers...))
+}
+
+// SidecarFeatureFlags returns the FeatureFlags synced by the kubernetes provider of the flagd sidecar injected by the
+// webhook, a restartable init container. flagd containers added to the pod spec by its author are ignored.
+func SidecarFeatureFlags(pod *corev1.Pod) []client.ObjectKey {
+	sidecars := slices.DeleteFunc(slices.Clone(pod.Spec.InitContainers), func(container corev1.Container) bool {
+		return container.RestartPolicy == nil || *container.RestartPolicy != corev1.ContainerRestartPolicyAlways
+	})
+	return containerFeatureFlags(pod.Namespace, sidecars)
+}
+
+func containerFeatureFlags(namespace string, containers []corev1.Container) []client.ObjectKey {
+	var featureFlags []client.ObjectKey
+	for _, container := range containers {
+		if container.Name != flagdContainerName {
+			continue
+		}
+		for i, arg := range container.Args {
+			if arg != common.SourceConfigParam || i+1 >= len(container.Args) {
+				continue
+			}
+			sources := []types.SourceConfig{}
+			if err := json.Unmarshal([]byte(container.Args[i+1]), &sources); err != nil {
+				continue
+			}
+			for _, source := range sources {
+				if source.Provider != string(apicommon.SyncProviderKubernetes) {
+					continue
+				}
+				ns, name := utils.ParseAnnotation(source.URI, namespace)
+				key := client.ObjectKey{Namespace: ns, Name: name}
+				if !slices.Contains(featureFlags, key) {
+					featureFlags = append(featureFlags, key)
+				}
+			}
+		}
+	}
+	return featureFlags
+}
+
+// Grant creates or updates a Role and RoleBinding in each namespace holding the given FeatureFlags, allowing the
+// service account to read these FeatureFlags only
+func Grant(ctx context.Context, c client.Client, serviceAccount client.ObjectKey, featureFlags []client.ObjectKey) error {
+	namesByNamespace := map[string][]string{}
+	for _, ff := range featureFlags {
+		if !slices.Contains(namesByNamespace[ff.Namespace], ff.Name) {
+			namesByNamespace[ff.Namespace] = append(namesByNamespace[ff.Namespace], ff.Name)
+		}
+	}
+	// the cache may not yet contain objects created for concurrent admissions
+	retriable := func(err error) bool {
+		return errors.IsAlreadyExists(err) || errors.IsConflict(err)
+	}
+	for ns, names := range namesByNamespace {
+		if err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+			return ensureRole(ctx, c, serviceAccount, ns, names)
+		}); err != nil {
+			return fmt.Errorf("could not grant %s access to FeatureFlags in namespace %s: %w", serviceAccount, ns, err)
+		}
+		if err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+			return ensureRoleBinding(ctx, c, serviceAccount, ns)
+		}); err != nil {
+			return fmt.Errorf("could not bind %s to FeatureFlags in namespace %s: %w", serviceAccount, ns, err)
+		}
+	}
+	return nil
+}
+
+// Revoke removes the Role and RoleBinding of the service account from the given namespace
+func Revoke(ctx context.Context, c client.Client, serviceAccount client.ObjectKey, namespace string) error {
+	key := client.ObjectKey{Namespace: namespace, Name: Name(serviceAccount)}
+	for _, obj := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}} {
+		if err := c.Get(ctx, key, obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !common.IsManagedByOFO(obj) {
+			continue
+		}
+		if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveClusterRoleBindingSubjects removes the given service accounts from the shared flagd-kubernetes-sync
+// ClusterRoleBinding used by previous versions of the operator
+func RemoveClusterRoleBindingSubjects(ctx context.Context, c client.Client, serviceAccounts []client.ObjectKey) error {
+	if len(serviceAccounts) == 0 {
+		return nil
+	}
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := c.Get(ctx, client.ObjectKey{Name: common.ClusterRoleBindingName}, crb); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	subjects := slices.DeleteFunc(slices.Clone(crb.Subjects), func(subject rbacv1.Subject) bool {
+		return subject.Kind == rbacv1.ServiceAccountKind &&
+			slices.Contains(serviceAccounts, client.ObjectKey{Namespace: subject.Namespace, Name: subject.Name})
+	})
+	if len(subjects) == len(crb.Subjects) {
+		return nil
+	}
+	crb.Subjects = subjects
+	return c.Update(ctx, crb)
+}
+
+func ensureRole(ctx context.Context, c client.Client, serviceAccount client.ObjectKey, namespace string, names []string) error {
+	existing := &rbacv1.Role{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: Name(serviceAccount)}, existing)
+	if errors.IsNotFound(err) {
+		slices.Sort(names)
+		return c.Create(ctx, &rbacv1.Role{
+			ObjectMeta: objectMeta(serviceAccount, namespace),
+			Rules:      rules(names),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if !common.IsManagedByOFO(existing) {
+		return fmt.Errorf("role %s/%s not managed by OFO", namespace, existing.Name)
+	}
+
+	// names granted for other pods of the service account are kept, they are removed with the Role
+	// once the service account is not used by any pod anymore
+	granted := grantedNames(existing)
+	for _, name := range names {
+		if !slices.Contains(granted, name) {
+			granted = append(granted, name)
+		}
+	}
+	slices.Sort(granted)
+	desired := rules(granted)
+	if reflect.DeepEqual(existing.Rules, desired) {
+		return nil
+	}
+	existing.Rules = desired
+	return c.Update(ctx, existing)
+}
+
+func ensureRoleBinding(ctx context.Context, c client.Client, serviceAccount client.ObjectKey, namespace string) error {
+	now := time.Now()
+	existing := &rbacv1.RoleBinding{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: Name(serviceAccount)}, existing)
+	if errors.IsNotFound(err) {
+		meta := objectMeta(serviceAccount, namespace)
+		meta.Annotations[GrantedAtAnnotation] = now.UTC().Format(time.RFC3339)
+		return c.Create(ctx, &rbacv1.RoleBinding{
+			ObjectMeta: meta,
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccount.Name,
+				Namespace: serviceAccount.Namespace,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     Name(serviceAccount),
+			},
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if !common.IsManagedByOFO(existing) {
+		return fmt.Errorf("rolebinding %s/%s not managed by OFO", namespace, existing.Name)
+	}
+	if grantedAt, ok := GrantedAt(existing); ok && now.Sub(grantedAt) < refreshInterval {
+		return nil
+	}
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[GrantedAtAnnotation] = now.UTC().Format(time.RFC3339)
+	return c.Update(ctx, existing)
+}
+
+// GrantedAt returns the last time the RoleBinding was requested
+func GrantedAt(rb *rbacv1.RoleBinding) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, rb.Annotations[GrantedAtAnnotation])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func objectMeta(serviceAccount client.ObjectKey, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      Name(serviceAccount),
+		Namespace: namespace,
+		Labels: map[string]string{
+			common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+			Label:                         "true",
+		},
+		Annotations: map[string]string{
+			ServiceAccountAnnotation: serviceAccount.String(),
+		},
+	}
+}
+
+// rules allows reading the given FeatureFlags. List and watch requests are authorized against the resource names
+// when they select a single object through a metadata.name field selector, which is how flagd syncs a FeatureFlag
+func rules(names []string) []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{"core.openfeature.dev"},
+			Resources:     []string{"featureflags"},
+			Verbs:         []string{"get", "list", "watch"},
+			ResourceNames: names,
+		},
+	}
+}
+
+func grantedNames(role *rbacv1.Role) []string {
+	for _, rule := range role.Rules {
+		if len(rule.ResourceNames) > 0 {
+			return slices.Clone(rule.ResourceNames)
+		}
+	}
+	return nil
+}
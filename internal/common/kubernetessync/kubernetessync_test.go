@@ -0,0 +1,156 @@
+package kubernetessync
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var serviceAccount = client.ObjectKey{Namespace: "app", Name: "my-sa"}
+
+func TestName(t *testing.T) {
+	name := Name(serviceAccount)
+	require.True(t, strings.HasPrefix(name, NamePrefix+"my-sa-"))
+	require.NotEqual(t, name, Name(client.ObjectKey{Namespace: "other", Name: "my-sa"}))
+
+	long := Name(client.ObjectKey{Namespace: "app", Name: strings.Repeat("a", 300)})
+	require.Len(t, long, maxNameLength)
+}
+
+func TestPodFeatureFlags(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name: "flagd",
+				Args: []string{
+					"start",
+					common.SourceConfigParam,
+					`[{"uri":"my-flags","provider":"kubernetes"},{"uri":"flags/shared","provider":"kubernetes"},{"uri":"/etc/flagd/flags.json","provider":"file"}]`,
+				},
+			}},
+			Containers: []corev1.Container{{
+				Name: "app",
+				Args: []string{common.SourceConfigParam, `[{"uri":"ignored","provider":"kubernetes"}]`},
+			}},
+		},
+	}
+	require.Equal(t, []client.ObjectKey{
+		{Namespace: "app", Name: "my-flags"},
+		{Namespace: "flags", Name: "shared"},
+	}, PodFeatureFlags(pod))
+
+	require.Empty(t, PodFeatureFlags(&corev1.Pod{}))
+}
+
+func TestSidecarFeatureFlags(t *testing.T) {
+	args := []string{common.SourceConfigParam, `[{"uri":"flags/shared","provider":"kubernetes"}]`}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name:          "flagd",
+				RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+				Args:          []string{common.SourceConfigParam, `[{"uri":"my-flags","provider":"kubernetes"}]`},
+			}},
+			Containers: []corev1.Container{{Name: "flagd", Args: args}},
+		},
+	}
+	require.Equal(t, []client.ObjectKey{{Namespace: "app", Name: "my-flags"}}, SidecarFeatureFlags(pod))
+
+	// a flagd init container running to completion is not the injected sidecar
+	pod.Spec.InitContainers = []corev1.Container{{Name: "flagd", Args: args}}
+	require.Empty(t, SidecarFeatureFlags(pod))
+}
+
+func TestGrant(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	ctx := context.Background()
+
+	require.Nil(t, Grant(ctx, c, serviceAccount, []client.ObjectKey{
+		{Namespace: "app", Name: "b"},
+		{Namespace: "flags", Name: "shared"},
+	}))
+	require.Nil(t, Grant(ctx, c, serviceAccount, []client.ObjectKey{
+		{Namespace: "app", Name: "a"},
+		{Namespace: "app", Name: "b"},
+	}))
+
+	role := &rbacv1.Role{}
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Namespace: "app", Name: Name(serviceAccount)}, role))
+	require.True(t, common.IsManagedByOFO(role))
+	require.Equal(t, []rbacv1.PolicyRule{{
+		APIGroups:     []string{"core.openfeature.dev"},
+		Resources:     []string{"featureflags"},
+		Verbs:         []string{"get", "list", "watch"},
+		ResourceNames: []string{"a", "b"},
+	}}, role.Rules)
+
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Namespace: "flags", Name: Name(serviceAccount)}, role))
+	require.Equal(t, []string{"shared"}, role.Rules[0].ResourceNames)
+
+	rb := &rbacv1.RoleBinding{}
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Namespace: "flags", Name: Name(serviceAccount)}, rb))
+	require.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "my-sa", Namespace: "app"}}, rb.Subjects)
+	require.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: Name(serviceAccount)}, rb.RoleRef)
+	sa, ok := ServiceAccountOf(rb)
+	require.True(t, ok)
+	require.Equal(t, serviceAccount, sa)
+	grantedAt, ok := GrantedAt(rb)
+	require.True(t, ok)
+	require.WithinDuration(t, time.Now(), grantedAt, time.Minute)
+}
+
+func TestGrant_NotManaged(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: Name(serviceAccount)},
+	}).Build()
+
+	err := Grant(context.Background(), c, serviceAccount, []client.ObjectKey{{Namespace: "app", Name: "a"}})
+	require.NotNil(t, err)
+}
+
+func TestRevoke(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	ctx := context.Background()
+	require.Nil(t, Grant(ctx, c, serviceAccount, []client.ObjectKey{{Namespace: "flags", Name: "shared"}}))
+
+	require.Nil(t, Revoke(ctx, c, serviceAccount, "flags"))
+	err := c.Get(ctx, client.ObjectKey{Namespace: "flags", Name: Name(serviceAccount)}, &rbacv1.Role{})
+	require.True(t, errors.IsNotFound(err))
+	err = c.Get(ctx, client.ObjectKey{Namespace: "flags", Name: Name(serviceAccount)}, &rbacv1.RoleBinding{})
+	require.True(t, errors.IsNotFound(err))
+
+	// revoking twice is a no-op
+	require.Nil(t, Revoke(ctx, c, serviceAccount, "flags"))
+}
+
+func TestRemoveClusterRoleBindingSubjects(t *testing.T) {
+	operator := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "controller-manager", Namespace: "open-feature-operator-system"}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ClusterRoleBindingName},
+		Subjects: []rbacv1.Subject{
+			operator,
+			{Kind: rbacv1.ServiceAccountKind, Name: "my-sa", Namespace: "app"},
+		},
+	}).Build()
+	ctx := context.Background()
+
+	require.Nil(t, RemoveClusterRoleBindingSubjects(ctx, c, []client.ObjectKey{serviceAccount}))
+
+	crb := &rbacv1.ClusterRoleBinding{}
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Name: common.ClusterRoleBindingName}, crb))
+	require.Equal(t, []rbacv1.Subject{operator}, crb.Subjects)
+}
@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/prometheus/client_golang/prometheus"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=core.openfeature.dev,resources=featureflags,verbs=get;list;watch
+
+const collectTimeout = 5 * time.Second
+
+// ResourceCollector reports gauges about FeatureFlags, injected pods and the flagd-proxy.
+// The values are read from the cache of the given reader at scrape time.
+type ResourceCollector struct {
+	Client client.Reader
+	Log    logr.Logger
+	// FlagdProxyNamespace and FlagdProxyDeploymentName identify the flagd-proxy deployment
+	FlagdProxyNamespace      string
+	FlagdProxyDeploymentName string
+
+	featureFlags    *prometheus.Desc
+	featureFlagSize *prometheus.Desc
+	injectedPods    *prometheus.Desc
+	flagdProxyReady *prometheus.Desc
+}
+
+func NewResourceCollector(c client.Reader, log logr.Logger, flagdProxyNamespace, flagdProxyDeploymentName string) *ResourceCollector {
+	return &ResourceCollector{
+		Client:                   c,
+		Log:                      log,
+		FlagdProxyNamespace:      flagdProxyNamespace,
+		FlagdProxyDeploymentName: flagdProxyDeploymentName,
+		featureFlags: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "featureflags"),
+			"Number of FeatureFlags by namespace",
+			[]string{"namespace"}, nil,
+		),
+		featureFlagSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "featureflag_flags"),
+			"Number of flags defined in a FeatureFlag",
+			[]string{"namespace", "name"}, nil,
+		),
+		injectedPods: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "injected_pods"),
+			"Number of pods configured by the operator by namespace and mode (rpc, in-process)",
+			[]string{"namespace", "mode"}, nil,
+		),
+		flagdProxyReady: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "flagd_proxy_ready"),
+			"Whether the flagd-proxy has at least one ready replica",
+			nil, nil,
+		),
+	}
+}
+
+func (c *ResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.featureFlags
+	ch <- c.featureFlagSize
+	ch <- c.injectedPods
+	ch <- c.flagdProxyReady
+}
+
+func (c *ResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	if err := c.collectFeatureFlags(ctx, ch); err != nil {
+		c.Log.V(1).Info(fmt.Sprintf("could not collect FeatureFlag metrics: %s", err.Error()))
+	}
+	if err := c.collectInjectedPods(ctx, ch); err != nil {
+		c.Log.V(1).Info(fmt.Sprintf("could not collect pod metrics: %s", err.Error()))
+	}
+	if err := c.collectFlagdProxy(ctx, ch); err != nil {
+		c.Log.V(1).Info(fmt.Sprintf("could not collect flagd-proxy metrics: %s", err.Error()))
+	}
+}
+
+func (c *ResourceCollector) collectFeatureFlags(ctx context.Context, ch chan<- prometheus.Metric) error {
+	list := &api.FeatureFlagList{}
+	if err := c.Client.List(ctx, list); err != nil {
+		return err
+	}
+	perNamespace := map[string]int{}
+	for _, ff := range list.Items {
+		perNamespace[ff.Namespace]++
+		ch <- prometheus.MustNewConstMetric(c.featureFlagSize, prometheus.GaugeValue, float64(len(ff.Spec.FlagSpec.FlagsMap)), ff.Namespace, ff.Name)
+	}
+	for ns, count := range perNamespace {
+		ch <- prometheus.MustNewConstMetric(c.featureFlags, prometheus.GaugeValue, float64(count), ns)
+	}
+	return nil
+}
+
+func (c *ResourceCollector) collectInjectedPods(ctx context.Context, ch chan<- prometheus.Metric) error {
+	list := &corev1.PodList{}
+	if err := c.Client.List(ctx, list); err != nil {
+		return err
+	}
+	type key struct{ namespace, mode string }
+	counts := map[key]int{}
+	for _, pod := range list.Items {
+		if mode := podMode(&pod); mode != ModeNone {
+			counts[key{pod.Namespace, mode}]++
+		}
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.injectedPods, prometheus.GaugeValue, float64(count), k.namespace, k.mode)
+	}
+	return nil
+}
+
+func (c *ResourceCollector) collectFlagdProxy(ctx context.Context, ch chan<- prometheus.Metric) error {
+	ready := 0.0
+	deployment := &appsV1.Deployment{}
+	err := c.Client.Get(ctx, client.ObjectKey{Namespace: c.FlagdProxyNamespace, Name: c.FlagdProxyDeploymentName}, deployment)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && deployment.Status.ReadyReplicas > 0 {
+		ready = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.flagdProxyReady, prometheus.GaugeValue, ready)
+	return nil
+}
+
+// podMode returns the mode in which the pod has been configured by the operator, pods in rpc mode
+// are only counted if the flagd sidecar is present
+func podMode(pod *corev1.Pod) string {
+	annotations := pod.GetAnnotations()
+	if annotations[annotation(common.EnabledAnnotation)] != "true" {
+		return ModeNone
+	}
+	if _, ok := annotations[annotation(common.FeatureFlagSourceAnnotation)]; ok {
+		if hasFlagdContainer(pod.Spec.InitContainers) || hasFlagdContainer(pod.Spec.Containers) {
+			return ModeRPC
+		}
+		return ModeNone
+	}
+	if _, ok := annotations[annotation(common.InProcessConfigurationAnnotation)]; ok {
+		return ModeInProcess
+	}
+	return ModeNone
+}
+
+func hasFlagdContainer(containers []corev1.Container) bool {
+	for _, container := range containers {
+		if container.Name == "flagd" {
+			return true
+		}
+	}
+	return false
+}
+
+func annotation(name string) string {
+	return fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, name)
+}
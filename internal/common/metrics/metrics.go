@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	namespace = "openfeature_operator"
+
+	OutcomeInjected = "injected"
+	OutcomeSkipped  = "skipped"
+	OutcomeDenied   = "denied"
+	OutcomeErrored  = "errored"
+
+	ModeRPC       = "rpc"
+	ModeInProcess = "in-process"
+	ModeNone      = "none"
+
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+var (
+	// PodAdmissions counts the pod admission requests handled by the mutating webhook
+	PodAdmissions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pod_admissions_total",
+		Help:      "Number of pod admission requests by outcome (injected, skipped, denied, errored) and mode (rpc, in-process, none)",
+	}, []string{"outcome", "mode"})
+
+	// PodAdmissionDuration observes the latency of the pod admission requests handled by the mutating webhook
+	PodAdmissionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "pod_admission_duration_seconds",
+		Help:      "Latency of pod admission requests by mode (rpc, in-process, none)",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"mode"})
+
+	// FeatureFlagValidationFailures counts the FeatureFlags rejected by the validating webhook
+	FeatureFlagValidationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "featureflag_validation_failures_total",
+		Help:      "Number of FeatureFlags rejected by the validating webhook by operation (create, update)",
+	}, []string{"operation"})
+
+	// RolloutRestarts counts the deployment restarts triggered by FeatureFlagSource changes
+	RolloutRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rollout_restarts_total",
+		Help:      "Number of deployment restarts triggered by FeatureFlagSource changes by result (success, failure)",
+	}, []string{"result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		PodAdmissions,
+		PodAdmissionDuration,
+		FeatureFlagValidationFailures,
+		RolloutRestarts,
+	)
+}
+
+// RecordPodAdmission records the outcome and latency of a pod admission request based on its response
+func RecordPodAdmission(mode string, response admission.Response, duration time.Duration) {
+	PodAdmissions.WithLabelValues(admissionOutcome(response), mode).Inc()
+	PodAdmissionDuration.WithLabelValues(mode).Observe(duration.Seconds())
+}
+
+func admissionOutcome(response admission.Response) string {
+	switch {
+	case response.Allowed && len(response.Patches) > 0:
+		return OutcomeInjected
+	case response.Allowed:
+		return OutcomeSkipped
+	case response.Result != nil && response.Result.Code == http.StatusForbidden:
+		return OutcomeDenied
+	default:
+		return OutcomeErrored
+	}
+}
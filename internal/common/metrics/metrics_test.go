@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func Test_admissionOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		response admission.Response
+		want     string
+	}{
+		{
+			name:     "patched",
+			response: admission.PatchResponseFromRaw([]byte(`{"spec":{}}`), []byte(`{"spec":{"initContainers":[]}}`)),
+			want:     OutcomeInjected,
+		},
+		{
+			name:     "allowed without patches",
+			response: admission.Allowed("OpenFeature is disabled"),
+			want:     OutcomeSkipped,
+		},
+		{
+			name:     "denied",
+			response: admission.Denied("static or orphaned pods cannot be mutated"),
+			want:     OutcomeDenied,
+		},
+		{
+			name:     "errored",
+			response: admission.Errored(http.StatusNotFound, errors.New("not found")),
+			want:     OutcomeErrored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, admissionOutcome(tt.response))
+		})
+	}
+}
+
+func TestRecordPodAdmission(t *testing.T) {
+	before := testutil.ToFloat64(PodAdmissions.WithLabelValues(OutcomeDenied, ModeRPC))
+
+	RecordPodAdmission(ModeRPC, admission.Denied("flagd-proxy is not ready"), 10*time.Millisecond)
+
+	require.Equal(t, before+1, testutil.ToFloat64(PodAdmissions.WithLabelValues(OutcomeDenied, ModeRPC)))
+}
+
+func TestResourceCollector(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	enabled := map[string]string{
+		"openfeature.dev/enabled":           "true",
+		"openfeature.dev/featureflagsource": "source",
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&api.FeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{Name: "flags", Namespace: "ns"},
+			Spec: api.FeatureFlagSpec{
+				FlagSpec: api.FlagSpec{
+					Flags: api.Flags{FlagsMap: map[string]api.Flag{"a": {}, "b": {}}},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "injected", Namespace: "ns", Annotations: enabled},
+			Spec:       corev1.PodSpec{InitContainers: []corev1.Container{{Name: "flagd"}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-injected", Namespace: "ns", Annotations: enabled},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "in-process", Namespace: "ns", Annotations: map[string]string{
+				"openfeature.dev/enabled":                "true",
+				"openfeature.dev/inprocessconfiguration": "config",
+			}},
+		},
+		&appsV1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "flagd-proxy", Namespace: "system"},
+			Status:     appsV1.DeploymentStatus{ReadyReplicas: 1},
+		},
+	).Build()
+
+	collector := NewResourceCollector(fakeClient, testr.New(t), "system", "flagd-proxy")
+	registry := prometheus.NewPedanticRegistry()
+	require.Nil(t, registry.Register(collector))
+
+	expected := `
+# HELP openfeature_operator_featureflag_flags Number of flags defined in a FeatureFlag
+# TYPE openfeature_operator_featureflag_flags gauge
+openfeature_operator_featureflag_flags{name="flags",namespace="ns"} 2
+# HELP openfeature_operator_featureflags Number of FeatureFlags by namespace
+# TYPE openfeature_operator_featureflags gauge
+openfeature_operator_featureflags{namespace="ns"} 1
+# HELP openfeature_operator_flagd_proxy_ready Whether the flagd-proxy has at least one ready replica
+# TYPE openfeature_operator_flagd_proxy_ready gauge
+openfeature_operator_flagd_proxy_ready 1
+# HELP openfeature_operator_injected_pods Number of pods configured by the operator by namespace and mode (rpc, in-process)
+# TYPE openfeature_operator_injected_pods gauge
+openfeature_operator_injected_pods{mode="in-process",namespace="ns"} 1
+openfeature_operator_injected_pods{mode="rpc",namespace="ns"} 1
+`
+	require.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+
+	// the flagd-proxy is reported as not ready if it has not been deployed
+	require.Nil(t, fakeClient.Delete(context.Background(), &appsV1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "flagd-proxy", Namespace: "system"},
+	}))
+	require.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP openfeature_operator_flagd_proxy_ready Whether the flagd-proxy has at least one ready replica
+# TYPE openfeature_operator_flagd_proxy_ready gauge
+openfeature_operator_flagd_proxy_ready 0
+`), "openfeature_operator_flagd_proxy_ready"))
+}
@@ -0,0 +1,153 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/apply"
+	"golang.org/x/exp/maps"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const MetricsPath = "/metrics"
+
+var (
+	PodMonitorGVK = schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    "PodMonitor",
+	}
+	ServiceMonitorGVK = schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    "ServiceMonitor",
+	}
+)
+
+// Monitor describes a PodMonitor or ServiceMonitor scraping a single named port
+type Monitor struct {
+	GVK       schema.GroupVersionKind
+	Name      string
+	Namespace string
+	Owner     metav1.OwnerReference
+	Spec      api.MonitoringSpec
+	// Selector matches the labels of the scraped pods or services
+	Selector map[string]string
+	// Port is the name of the container or service port exposing the metrics
+	Port string
+	// AllNamespaces selects matching pods or services in all namespaces instead of the monitor namespace only
+	AllNamespaces bool
+}
+
+// IsAvailable reports whether the CRD of the given kind, e.g. of the Prometheus Operator, is installed in the cluster
+func IsAvailable(c client.Client, gvk schema.GroupVersionKind) (bool, error) {
+	if _, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ToUnstructured builds the monitor object, the Prometheus Operator types are not registered in the scheme
+func (m Monitor) ToUnstructured() *unstructured.Unstructured {
+	labels := map[string]string{
+		common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+	}
+	maps.Copy(labels, m.Spec.Labels)
+
+	endpoint := map[string]interface{}{
+		"port": m.Port,
+		"path": MetricsPath,
+	}
+	if m.Spec.Interval != "" {
+		endpoint["interval"] = m.Spec.Interval
+	}
+	endpointsKey := "podMetricsEndpoints"
+	if m.GVK == ServiceMonitorGVK {
+		endpointsKey = "endpoints"
+	}
+
+	namespaceSelector := map[string]interface{}{
+		"matchNames": []interface{}{m.Namespace},
+	}
+	if m.AllNamespaces {
+		namespaceSelector = map[string]interface{}{
+			"any": true,
+		}
+	}
+
+	matchLabels := map[string]interface{}{}
+	for k, v := range m.Selector {
+		matchLabels[k] = v
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(m.GVK)
+	u.SetName(m.Name)
+	u.SetNamespace(m.Namespace)
+	u.SetLabels(labels)
+	u.SetOwnerReferences([]metav1.OwnerReference{m.Owner})
+	u.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"namespaceSelector": namespaceSelector,
+		endpointsKey:        []interface{}{endpoint},
+	}
+	return u
+}
+
+// Ensure applies the monitor if monitoring is enabled and the CRD is installed,
+// otherwise a monitor previously created by the operator is removed
+func Ensure(ctx context.Context, c client.Client, m Monitor) error {
+	available, err := IsAvailable(c, m.GVK)
+	if err != nil {
+		return fmt.Errorf("could not check for the %s CRD: %w", m.GVK.Kind, err)
+	}
+	if !available {
+		return nil
+	}
+	if !m.Spec.Enabled {
+		return Delete(ctx, c, m.GVK, types.NamespacedName{Name: m.Name, Namespace: m.Namespace})
+	}
+
+	desired := m.ToUnstructured()
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(m.GVK)
+	err = c.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err == nil {
+		if !common.IsManagedByOFO(existing) {
+			return fmt.Errorf("%s %s not managed by OFO", m.GVK.Kind, m.Name)
+		}
+		differs, err := apply.Differs(existing, desired)
+		if err != nil || !differs {
+			return err
+		}
+	}
+	return apply.Apply(ctx, c, desired)
+}
+
+// Delete removes the monitor if it exists and is managed by the operator
+func Delete(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, key types.NamespacedName) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	if err := c.Get(ctx, key, existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !common.IsManagedByOFO(existing) {
+		return nil
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, existing))
+}
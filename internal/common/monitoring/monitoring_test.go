@@ -0,0 +1,141 @@
+package monitoring
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	applyfake "github.com/open-feature/open-feature-operator/internal/common/apply/fake"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeClient(withCRDs bool, objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+	if withCRDs {
+		mapper.Add(PodMonitorGVK, meta.RESTScopeNamespace)
+		mapper.Add(ServiceMonitorGVK, meta.RESTScopeNamespace)
+	}
+	return fake.NewClientBuilder().WithInterceptorFuncs(applyfake.Interceptor()).WithRESTMapper(mapper).WithObjects(objs...).Build()
+}
+
+func testMonitor(spec api.MonitoringSpec) Monitor {
+	return Monitor{
+		GVK:       PodMonitorGVK,
+		Name:      "my-monitor",
+		Namespace: "my-namespace",
+		Owner: metav1.OwnerReference{
+			APIVersion: "core.openfeature.dev/v1beta1",
+			Kind:       "FeatureFlagSource",
+			Name:       "my-source",
+			UID:        "my-uid",
+		},
+		Spec: spec,
+		Selector: map[string]string{
+			common.FlagdMonitorLabel: "my-uid",
+		},
+		Port:          "management",
+		AllNamespaces: true,
+	}
+}
+
+func getMonitor(t *testing.T, c client.Client) (*unstructured.Unstructured, error) {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(PodMonitorGVK)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: "my-monitor", Namespace: "my-namespace"}, u)
+	return u, err
+}
+
+func TestMonitor_ToUnstructured(t *testing.T) {
+	u := testMonitor(api.MonitoringSpec{
+		Enabled: true,
+		Labels:  map[string]string{"release": "prometheus"},
+	}).ToUnstructured()
+
+	require.Equal(t, PodMonitorGVK, u.GroupVersionKind())
+	require.Equal(t, map[string]string{
+		common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+		"release":                     "prometheus",
+	}, u.GetLabels())
+	require.Equal(t, "my-source", u.GetOwnerReferences()[0].Name)
+	require.Equal(t, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				common.FlagdMonitorLabel: "my-uid",
+			},
+		},
+		"namespaceSelector": map[string]interface{}{
+			"any": true,
+		},
+		"podMetricsEndpoints": []interface{}{
+			map[string]interface{}{
+				"port": "management",
+				"path": "/metrics",
+			},
+		},
+	}, u.Object["spec"])
+}
+
+func TestEnsure_CRDNotInstalled(t *testing.T) {
+	c := newFakeClient(false)
+
+	err := Ensure(context.TODO(), c, testMonitor(api.MonitoringSpec{Enabled: true}))
+	require.Nil(t, err)
+
+	available, err := IsAvailable(c, PodMonitorGVK)
+	require.Nil(t, err)
+	require.False(t, available)
+}
+
+func TestEnsure_CreateUpdateDelete(t *testing.T) {
+	c := newFakeClient(true)
+	ctx := context.TODO()
+
+	err := Ensure(ctx, c, testMonitor(api.MonitoringSpec{Enabled: true}))
+	require.Nil(t, err)
+	_, err = getMonitor(t, c)
+	require.Nil(t, err)
+
+	err = Ensure(ctx, c, testMonitor(api.MonitoringSpec{Enabled: true, Interval: "30s"}))
+	require.Nil(t, err)
+	u, err := getMonitor(t, c)
+	require.Nil(t, err)
+	endpoints, _, _ := unstructured.NestedSlice(u.Object, "spec", "podMetricsEndpoints")
+	require.Equal(t, "30s", endpoints[0].(map[string]interface{})["interval"])
+
+	// the monitor is not updated if the applied fields did not change
+	err = Ensure(ctx, c, testMonitor(api.MonitoringSpec{Enabled: true, Interval: "30s"}))
+	require.Nil(t, err)
+	unchanged, err := getMonitor(t, c)
+	require.Nil(t, err)
+	require.Equal(t, u.GetResourceVersion(), unchanged.GetResourceVersion())
+
+	err = Ensure(ctx, c, testMonitor(api.MonitoringSpec{Enabled: false}))
+	require.Nil(t, err)
+	_, err = getMonitor(t, c)
+	require.True(t, client.IgnoreNotFound(err) == nil && err != nil)
+}
+
+func TestEnsure_NotManagedByOFO(t *testing.T) {
+	existing := testMonitor(api.MonitoringSpec{}).ToUnstructured()
+	existing.SetLabels(nil)
+	c := newFakeClient(true, existing)
+	ctx := context.TODO()
+
+	err := Ensure(ctx, c, testMonitor(api.MonitoringSpec{Enabled: true}))
+	require.ErrorContains(t, err, "not managed by OFO")
+
+	// monitors which are not managed by the operator are not removed
+	err = Ensure(ctx, c, testMonitor(api.MonitoringSpec{Enabled: false}))
+	require.Nil(t, err)
+	_, err = getMonitor(t, c)
+	require.Nil(t, err)
+}
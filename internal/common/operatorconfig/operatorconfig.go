@@ -0,0 +1,235 @@
+package operatorconfig
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	"github.com/open-feature/open-feature-operator/internal/common/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Configuration holds the settings of the operator which can be changed at runtime through an OperatorConfiguration
+type Configuration struct {
+	Env              types.EnvConfig
+	ImagePullSecrets []string
+	Labels           map[string]string
+	Annotations      map[string]string
+	SidecarResources corev1.ResourceRequirements
+}
+
+// Applier is implemented by the components of the operator depending on the Configuration
+type Applier interface {
+	// ApplyConfiguration replaces the configuration of the component, it is called whenever the effective
+	// configuration changes
+	ApplyConfiguration(cfg Configuration)
+}
+
+var syncProviders = []apicommon.SyncProviderType{
+	apicommon.SyncProviderKubernetes,
+	apicommon.SyncProviderFilepath,
+	apicommon.SyncProviderAzureBlob,
+	apicommon.SyncProviderGcs,
+	apicommon.SyncProviderS3,
+	apicommon.SyncProviderHttp,
+	apicommon.SyncProviderGrpc,
+	apicommon.SyncProviderFlagdProxy,
+	apicommon.SyncProviderFlagd,
+}
+
+// Merge applies the set fields of the spec on top of the defaults and validates the result. A field is set when it
+// is not nil, like in the layered merge of the api common package, so it can reset a value to its zero value. Empty
+// lists and maps replace the defaults as well
+func Merge(defaults Configuration, spec api.OperatorConfigurationSpec) (Configuration, error) {
+	cfg := defaults.DeepCopy()
+	env := &cfg.Env
+
+	if s := spec.Sidecar; s != nil {
+		set(&env.SidecarImage, s.Image)
+		set(&env.SidecarTag, s.Tag)
+		setInt(&env.SidecarPort, s.Port)
+		setInt(&env.SidecarManagementPort, s.ManagementPort)
+		set(&env.SidecarSocketPath, s.SocketPath)
+		set(&env.SidecarEvaluator, s.Evaluator)
+		if s.ProviderArgs != nil {
+			env.SidecarProviderArgs = strings.Join(s.ProviderArgs, ",")
+		}
+		setSyncProvider(&env.SidecarSyncProvider, s.DefaultSyncProvider)
+		set(&env.SidecarEnvVarPrefix, s.EnvVarPrefix)
+		set(&env.SidecarLogFormat, s.LogFormat)
+		set(&env.SidecarProbesEnabled, s.ProbesEnabled)
+		if s.Resources != nil {
+			cfg.SidecarResources = *s.Resources.DeepCopy()
+		}
+	}
+
+	if p := spec.FlagdProxy; p != nil {
+		set(&env.FlagdProxyImage, p.Image)
+		set(&env.FlagdProxyTag, p.Tag)
+		setInt(&env.FlagdProxyPort, p.Port)
+		setInt(&env.FlagdProxyManagementPort, p.ManagementPort)
+		setInt(&env.FlagdProxyReplicaCount, p.Replicas)
+		set(&env.FlagdProxyDebugLogging, p.DebugLogging)
+	}
+
+	if f := spec.Flagd; f != nil {
+		set(&env.FlagdImage, f.Image)
+		set(&env.FlagdTag, f.Tag)
+		setInt(&env.FlagdPort, f.Port)
+		setInt(&env.FlagdOFREPPort, f.OFREPPort)
+		setInt(&env.FlagdSyncPort, f.SyncPort)
+		setInt(&env.FlagdManagementPort, f.ManagementPort)
+		set(&env.FlagdDebugLogging, f.DebugLogging)
+	}
+
+	if i := spec.InProcess; i != nil {
+		setInt(&env.InProcessPort, i.Port)
+		set(&env.InProcessSocketPath, i.SocketPath)
+		set(&env.InProcessHost, i.Host)
+		set(&env.InProcessTLS, i.TLS)
+		set(&env.InProcessOfflineFlagSourcePath, i.OfflineFlagSourcePath)
+		set(&env.InProcessSelector, i.Selector)
+		set(&env.InProcessCache, i.Cache)
+		setInt(&env.InProcessCacheMaxSize, i.CacheMaxSize)
+		set(&env.InProcessEnvVarPrefix, i.EnvVarPrefix)
+	}
+
+	if spec.ImagePullSecrets != nil {
+		cfg.ImagePullSecrets = slices.Clone(spec.ImagePullSecrets)
+	}
+	if spec.Labels != nil {
+		cfg.Labels = maps.Clone(spec.Labels)
+	}
+	if spec.Annotations != nil {
+		cfg.Annotations = maps.Clone(spec.Annotations)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Configuration{}, err
+	}
+	return cfg, nil
+}
+
+// Validate reports the settings which cannot be applied
+func (c Configuration) Validate() error {
+	var errs []error
+	env := c.Env
+
+	if env.SidecarPort == env.SidecarManagementPort {
+		errs = append(errs, fmt.Errorf("sidecar port and management port must differ, both are %d", env.SidecarPort))
+	}
+	if !slices.Contains(syncProviders, apicommon.SyncProviderType(env.SidecarSyncProvider)) {
+		errs = append(errs, fmt.Errorf("unknown default sync provider %q of the sidecar", env.SidecarSyncProvider))
+	}
+	for name, request := range c.SidecarResources.Requests {
+		if limit, ok := c.SidecarResources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			errs = append(errs, fmt.Errorf("sidecar %s request %s exceeds the limit %s", name, request.String(), limit.String()))
+		}
+	}
+
+	if env.FlagdProxyPort == env.FlagdProxyManagementPort {
+		errs = append(errs, fmt.Errorf("flagd-proxy port and management port must differ, both are %d", env.FlagdProxyPort))
+	}
+	if env.FlagdProxyReplicaCount < 1 {
+		errs = append(errs, fmt.Errorf("flagd-proxy replicas must be at least 1, got %d", env.FlagdProxyReplicaCount))
+	}
+
+	flagdPorts := []int{env.FlagdPort, env.FlagdOFREPPort, env.FlagdSyncPort, env.FlagdManagementPort}
+	if len(slices.Compact(slices.Sorted(slices.Values(flagdPorts)))) != len(flagdPorts) {
+		errs = append(errs, fmt.Errorf("flagd ports must differ, got port %d, ofrep port %d, sync port %d and management port %d",
+			env.FlagdPort, env.FlagdOFREPPort, env.FlagdSyncPort, env.FlagdManagementPort))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Spec returns the configuration in the representation of an OperatorConfiguration, it is reported as the effective
+// configuration in its status
+func (c Configuration) Spec() *api.OperatorConfigurationSpec {
+	env := c.Env
+	var providerArgs []string
+	if env.SidecarProviderArgs != "" {
+		providerArgs = strings.Split(env.SidecarProviderArgs, ",")
+	}
+	return &api.OperatorConfigurationSpec{
+		Sidecar: &api.OperatorSidecarConfiguration{
+			Image:               ptr.To(env.SidecarImage),
+			Tag:                 ptr.To(env.SidecarTag),
+			Port:                ptr.To(int32(env.SidecarPort)),
+			ManagementPort:      ptr.To(int32(env.SidecarManagementPort)),
+			SocketPath:          ptr.To(env.SidecarSocketPath),
+			Evaluator:           ptr.To(env.SidecarEvaluator),
+			ProviderArgs:        providerArgs,
+			DefaultSyncProvider: ptr.To(apicommon.SyncProviderType(env.SidecarSyncProvider)),
+			EnvVarPrefix:        ptr.To(env.SidecarEnvVarPrefix),
+			LogFormat:           ptr.To(env.SidecarLogFormat),
+			ProbesEnabled:       ptr.To(env.SidecarProbesEnabled),
+			Resources:           c.SidecarResources.DeepCopy(),
+		},
+		FlagdProxy: &api.OperatorFlagdProxyConfiguration{
+			Image:          ptr.To(env.FlagdProxyImage),
+			Tag:            ptr.To(env.FlagdProxyTag),
+			Port:           ptr.To(int32(env.FlagdProxyPort)),
+			ManagementPort: ptr.To(int32(env.FlagdProxyManagementPort)),
+			Replicas:       ptr.To(int32(env.FlagdProxyReplicaCount)),
+			DebugLogging:   ptr.To(env.FlagdProxyDebugLogging),
+		},
+		Flagd: &api.OperatorFlagdConfiguration{
+			Image:          ptr.To(env.FlagdImage),
+			Tag:            ptr.To(env.FlagdTag),
+			Port:           ptr.To(int32(env.FlagdPort)),
+			OFREPPort:      ptr.To(int32(env.FlagdOFREPPort)),
+			SyncPort:       ptr.To(int32(env.FlagdSyncPort)),
+			ManagementPort: ptr.To(int32(env.FlagdManagementPort)),
+			DebugLogging:   ptr.To(env.FlagdDebugLogging),
+		},
+		InProcess: &api.OperatorInProcessConfiguration{
+			Port:                  ptr.To(int32(env.InProcessPort)),
+			SocketPath:            ptr.To(env.InProcessSocketPath),
+			Host:                  ptr.To(env.InProcessHost),
+			TLS:                   ptr.To(env.InProcessTLS),
+			OfflineFlagSourcePath: ptr.To(env.InProcessOfflineFlagSourcePath),
+			Selector:              ptr.To(env.InProcessSelector),
+			Cache:                 ptr.To(env.InProcessCache),
+			CacheMaxSize:          ptr.To(int32(env.InProcessCacheMaxSize)),
+			EnvVarPrefix:          ptr.To(env.InProcessEnvVarPrefix),
+		},
+		ImagePullSecrets: slices.Clone(c.ImagePullSecrets),
+		Labels:           maps.Clone(c.Labels),
+		Annotations:      maps.Clone(c.Annotations),
+	}
+}
+
+// DeepCopy returns a copy of the configuration which shares no maps or slices with it
+func (c Configuration) DeepCopy() Configuration {
+	out := c
+	out.Env.FlagdProxyMonitoringLabels = maps.Clone(c.Env.FlagdProxyMonitoringLabels)
+	out.ImagePullSecrets = slices.Clone(c.ImagePullSecrets)
+	out.Labels = maps.Clone(c.Labels)
+	out.Annotations = maps.Clone(c.Annotations)
+	out.SidecarResources = *c.SidecarResources.DeepCopy()
+	return out
+}
+
+func set[T any](dst *T, val *T) {
+	if val != nil {
+		*dst = *val
+	}
+}
+
+func setInt(dst *int, val *int32) {
+	if val != nil {
+		*dst = int(*val)
+	}
+}
+
+func setSyncProvider(dst *string, val *apicommon.SyncProviderType) {
+	if val != nil {
+		*dst = string(*val)
+	}
+}
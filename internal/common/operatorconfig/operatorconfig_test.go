@@ -0,0 +1,171 @@
+package operatorconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kelseyhightower/envconfig"
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	"github.com/open-feature/open-feature-operator/internal/common/types"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func defaultConfiguration(t *testing.T) Configuration {
+	env := types.EnvConfig{}
+	require.Nil(t, envconfig.Process("", &env))
+	return Configuration{
+		Env:              env,
+		ImagePullSecrets: []string{"registry"},
+		Labels:           map[string]string{"team": "flags"},
+		SidecarResources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("64M"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("200m"),
+				corev1.ResourceMemory: resource.MustParse("32M"),
+			},
+		},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	defaults := defaultConfiguration(t)
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}
+
+	cfg, err := Merge(defaults, api.OperatorConfigurationSpec{
+		Sidecar: &api.OperatorSidecarConfiguration{
+			Tag:           ptr.To("v1.0.0"),
+			ProviderArgs:  []string{"a=b", "c=d"},
+			ProbesEnabled: ptr.To(false),
+			Resources:     &resources,
+		},
+		FlagdProxy: &api.OperatorFlagdProxyConfiguration{
+			Replicas: ptr.To(int32(3)),
+		},
+		Flagd: &api.OperatorFlagdConfiguration{
+			Image: ptr.To("registry.example.com/flagd"),
+		},
+		InProcess: &api.OperatorInProcessConfiguration{
+			Host:         ptr.To("flagd.flags.svc"),
+			CacheMaxSize: ptr.To(int32(50)),
+		},
+		Labels: map[string]string{},
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, "v1.0.0", cfg.Env.SidecarTag)
+	require.Equal(t, defaults.Env.SidecarImage, cfg.Env.SidecarImage)
+	require.Equal(t, "a=b,c=d", cfg.Env.SidecarProviderArgs)
+	require.False(t, cfg.Env.SidecarProbesEnabled)
+	require.Equal(t, resources, cfg.SidecarResources)
+	require.Equal(t, 3, cfg.Env.FlagdProxyReplicaCount)
+	require.Equal(t, "registry.example.com/flagd", cfg.Env.FlagdImage)
+	require.Equal(t, defaults.Env.FlagdTag, cfg.Env.FlagdTag)
+	require.Equal(t, "flagd.flags.svc", cfg.Env.InProcessHost)
+	require.Equal(t, 50, cfg.Env.InProcessCacheMaxSize)
+	require.Equal(t, defaults.ImagePullSecrets, cfg.ImagePullSecrets)
+	// an empty map clears the labels of the operator
+	require.Empty(t, cfg.Labels)
+
+	// the defaults are not modified
+	require.Equal(t, defaultConfiguration(t), defaults)
+}
+
+func TestMerge_Reset(t *testing.T) {
+	defaults := defaultConfiguration(t)
+	defaults.Env.SidecarTag = "v1.0.0"
+	defaults.Env.SidecarPort = 9013
+	defaults.Env.InProcessSelector = "my-flags"
+
+	// fields set to their zero value or to the default of the operator replace the configuration in effect
+	var spec api.OperatorConfigurationSpec
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"sidecar": {"tag": "", "port": 8013},
+		"inProcess": {"selector": ""},
+		"imagePullSecrets": [],
+		"labels": {}
+	}`), &spec))
+
+	cfg, err := Merge(defaults, spec)
+	require.Nil(t, err)
+	require.Equal(t, "", cfg.Env.SidecarTag)
+	require.Equal(t, 8013, cfg.Env.SidecarPort)
+	require.Equal(t, "", cfg.Env.InProcessSelector)
+	require.Empty(t, cfg.ImagePullSecrets)
+	require.Empty(t, cfg.Labels)
+
+	// empty lists and maps are kept when the spec is serialized, unset ones are not
+	out, err := json.Marshal(api.OperatorConfigurationSpec{Labels: map[string]string{}})
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(out, &spec))
+	require.NotNil(t, spec.Labels)
+	require.Nil(t, spec.Annotations)
+}
+
+func TestMerge_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec api.OperatorConfigurationSpec
+		err  string
+	}{
+		{
+			name: "sidecar ports",
+			spec: api.OperatorConfigurationSpec{
+				Sidecar: &api.OperatorSidecarConfiguration{Port: ptr.To(int32(8014))},
+			},
+			err: "sidecar port and management port must differ",
+		},
+		{
+			name: "sync provider",
+			spec: api.OperatorConfigurationSpec{
+				Sidecar: &api.OperatorSidecarConfiguration{DefaultSyncProvider: ptr.To(apicommon.SyncProviderType("ftp"))},
+			},
+			err: `unknown default sync provider "ftp"`,
+		},
+		{
+			name: "sidecar resources",
+			spec: api.OperatorConfigurationSpec{
+				Sidecar: &api.OperatorSidecarConfiguration{Resources: &corev1.ResourceRequirements{
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32M")},
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64M")},
+				}},
+			},
+			err: "sidecar memory request 64M exceeds the limit 32M",
+		},
+		{
+			name: "flagd-proxy ports",
+			spec: api.OperatorConfigurationSpec{
+				FlagdProxy: &api.OperatorFlagdProxyConfiguration{ManagementPort: ptr.To(int32(8015))},
+			},
+			err: "flagd-proxy port and management port must differ",
+		},
+		{
+			name: "flagd ports",
+			spec: api.OperatorConfigurationSpec{
+				Flagd: &api.OperatorFlagdConfiguration{SyncPort: ptr.To(int32(8013))},
+			},
+			err: "flagd ports must differ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Merge(defaultConfiguration(t), tt.spec)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestConfiguration_Spec(t *testing.T) {
+	// the effective configuration applied as a spec results in the same configuration
+	cfg, err := Merge(defaultConfiguration(t), *defaultConfiguration(t).Spec())
+	require.Nil(t, err)
+	require.Equal(t, defaultConfiguration(t), cfg)
+}
@@ -0,0 +1,54 @@
+package referencegrant
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	KindPod                    = "Pod"
+	KindFlagd                  = "Flagd"
+	KindFeatureFlagSource      = "FeatureFlagSource"
+	KindInProcessConfiguration = "InProcessConfiguration"
+	KindFeatureFlag            = "FeatureFlag"
+)
+
+// Reference describes a reference from a workload to a resource of the core.openfeature.dev group
+type Reference struct {
+	// FromKind is the kind of the referencing workload, Pod or Flagd
+	FromKind      string
+	FromNamespace string
+	// ToKind is the kind of the referenced resource, FeatureFlagSource, InProcessConfiguration, FeatureFlag or Flagd
+	ToKind      string
+	ToNamespace string
+	ToName      string
+}
+
+// Check returns an error wrapping common.ErrReferenceNotGranted if the reference crosses namespaces and no
+// ReferenceGrant in the namespace of the referenced resource allows it
+func Check(ctx context.Context, c client.Reader, ref Reference) error {
+	if ref.ToNamespace == ref.FromNamespace {
+		return nil
+	}
+	grants := &api.ReferenceGrantList{}
+	if err := c.List(ctx, grants, client.InNamespace(ref.ToNamespace)); err != nil {
+		return fmt.Errorf("could not list the ReferenceGrants of namespace %s: %w", ref.ToNamespace, err)
+	}
+	fromGroup := ""
+	if ref.FromKind != KindPod {
+		fromGroup = api.GroupVersion.Group
+	}
+	for i := range grants.Items {
+		if grants.Items[i].Allows(fromGroup, ref.FromKind, ref.FromNamespace, api.GroupVersion.Group, ref.ToKind, ref.ToName) {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"%w: %s %s/%s cannot be referenced from a %s in namespace %s without a ReferenceGrant in namespace %s",
+		common.ErrReferenceNotGranted, ref.ToKind, ref.ToNamespace, ref.ToName, ref.FromKind, ref.FromNamespace, ref.ToNamespace,
+	)
+}
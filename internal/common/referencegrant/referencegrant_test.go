@@ -0,0 +1,86 @@
+package referencegrant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheck(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, api.AddToScheme(scheme))
+
+	grants := []client.Object{
+		&api.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: "flags"},
+			Spec: api.ReferenceGrantSpec{
+				From: []api.ReferenceGrantFrom{{Kind: KindPod, Namespace: "app"}},
+				To:   []api.ReferenceGrantTo{{Kind: KindFeatureFlag, Name: ptr.To("shared")}},
+			},
+		},
+		&api.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "flagd", Namespace: "flags"},
+			Spec: api.ReferenceGrantSpec{
+				From: []api.ReferenceGrantFrom{{Group: api.GroupVersion.Group, Kind: KindFlagd, Namespace: "app"}},
+				To:   []api.ReferenceGrantTo{{Kind: KindFeatureFlag}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(grants...).Build()
+
+	tests := []struct {
+		name    string
+		ref     Reference
+		allowed bool
+	}{
+		{
+			name:    "same namespace",
+			ref:     Reference{FromKind: KindPod, FromNamespace: "app", ToKind: KindFeatureFlagSource, ToNamespace: "app", ToName: "source"},
+			allowed: true,
+		},
+		{
+			name:    "granted by name",
+			ref:     Reference{FromKind: KindPod, FromNamespace: "app", ToKind: KindFeatureFlag, ToNamespace: "flags", ToName: "shared"},
+			allowed: true,
+		},
+		{
+			name:    "other name",
+			ref:     Reference{FromKind: KindPod, FromNamespace: "app", ToKind: KindFeatureFlag, ToNamespace: "flags", ToName: "private"},
+			allowed: false,
+		},
+		{
+			name:    "other kind",
+			ref:     Reference{FromKind: KindPod, FromNamespace: "app", ToKind: KindFeatureFlagSource, ToNamespace: "flags", ToName: "shared"},
+			allowed: false,
+		},
+		{
+			name:    "other namespace",
+			ref:     Reference{FromKind: KindPod, FromNamespace: "other", ToKind: KindFeatureFlag, ToNamespace: "flags", ToName: "shared"},
+			allowed: false,
+		},
+		{
+			name:    "all resources of the kind granted to Flagd",
+			ref:     Reference{FromKind: KindFlagd, FromNamespace: "app", ToKind: KindFeatureFlag, ToNamespace: "flags", ToName: "private"},
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(context.Background(), c, tt.ref)
+			if tt.allowed {
+				require.Nil(t, err)
+				return
+			}
+			require.True(t, errors.Is(err, common.ErrReferenceNotGranted))
+		})
+	}
+}
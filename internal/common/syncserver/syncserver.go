@@ -0,0 +1,104 @@
+// Package syncserver resolves the flag sync servers deployed by the operator, a Flagd or the flagd-proxy, to the
+// in-cluster address of their sync port.
+package syncserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SyncPortName is the name of the sync port of the Service of a Flagd
+const SyncPortName = "sync"
+
+// Address is the in-cluster address of a sync server
+type Address struct {
+	Host string
+	Port int32
+}
+
+func (a Address) String() string {
+	return fmt.Sprintf("%s:%d", a.Host, a.Port)
+}
+
+// Flagd returns the address of the sync port of the Service of a Flagd. An error wrapping common.ErrFlagdNotReady is
+// returned as long as its Deployment has no ready replica.
+func Flagd(ctx context.Context, c client.Reader, clusterDomain string, flagd client.ObjectKey) (Address, error) {
+	if err := c.Get(ctx, flagd, &api.Flagd{}); err != nil {
+		return Address{}, fmt.Errorf("could not retrieve flagd %s/%s: %w", flagd.Namespace, flagd.Name, err)
+	}
+
+	// the Deployment and the Service of a Flagd are named after it
+	d := appsV1.Deployment{}
+	err := c.Get(ctx, flagd, &d)
+	if err != nil && !errors.IsNotFound(err) {
+		return Address{}, err
+	}
+	if errors.IsNotFound(err) || d.Status.ReadyReplicas == 0 {
+		return Address{}, fmt.Errorf("%w: flagd %s/%s has no ready replica", common.ErrFlagdNotReady, flagd.Namespace, flagd.Name)
+	}
+
+	svc := corev1.Service{}
+	if err := c.Get(ctx, flagd, &svc); err != nil {
+		return Address{}, fmt.Errorf("could not retrieve the service of flagd %s/%s: %w", flagd.Namespace, flagd.Name, err)
+	}
+	for _, p := range svc.Spec.Ports {
+		if p.Name == SyncPortName {
+			return Address{
+				Host: fmt.Sprintf("%s.%s.svc.%s", flagd.Name, flagd.Namespace, clusterDomain),
+				Port: p.Port,
+			}, nil
+		}
+	}
+	return Address{}, fmt.Errorf("service of flagd %s/%s exposes no sync port", flagd.Namespace, flagd.Name)
+}
+
+// FlagdProxy returns the address of the flagd-proxy Service. An error wrapping common.ErrFlagdProxyNotReady is
+// returned as long as the flagd-proxy has no ready replica.
+func FlagdProxy(ctx context.Context, c client.Reader, cfg *flagdproxy.FlagdProxyConfiguration) (Address, error) {
+	exists, ready, err := flagdProxyReady(ctx, c, cfg)
+	if err != nil {
+		return Address{}, err
+	}
+	if !exists || !ready {
+		return Address{}, common.ErrFlagdProxyNotReady
+	}
+	return Address{
+		Host: fmt.Sprintf("%s.%s.svc.%s", flagdproxy.FlagdProxyServiceName, cfg.Namespace, cfg.ClusterDomain),
+		Port: int32(cfg.Port),
+	}, nil
+}
+
+func flagdProxyReady(ctx context.Context, c client.Reader, cfg *flagdproxy.FlagdProxyConfiguration) (bool, bool, error) {
+	d := appsV1.Deployment{}
+	err := c.Get(ctx, client.ObjectKey{Name: flagdproxy.FlagdProxyDeploymentName, Namespace: cfg.Namespace}, &d)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// does not exist, is not ready, no error
+			return false, false, nil
+		}
+		// does not exist, is not ready, is in error
+		return false, false, err
+	}
+	if d.Status.ReadyReplicas == 0 {
+		// exists, not ready, no error
+		if d.CreationTimestamp.Time.Before(time.Now().Add(-3 * time.Minute)) {
+			return true, false, fmt.Errorf(
+				"flagd-proxy not ready after 3 minutes, was created at %s: %w",
+				d.CreationTimestamp.Time.String(),
+				common.ErrFlagdProxyNotReady,
+			)
+		}
+		return true, false, nil
+	}
+	// exists, at least one replica ready, no error
+	return true, true, nil
+}
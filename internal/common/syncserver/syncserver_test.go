@@ -0,0 +1,71 @@
+package syncserver
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/stretchr/testify/require"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFlagd(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, clientgoscheme.AddToScheme(scheme))
+	require.Nil(t, api.AddToScheme(scheme))
+	key := client.ObjectKey{Namespace: "flags", Name: "central-flagd"}
+	meta := metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}
+	ctx := context.Background()
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	_, err := Flagd(ctx, c, "cluster.local", key)
+	require.True(t, errors.IsNotFound(err))
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&api.Flagd{ObjectMeta: meta}, &appsV1.Deployment{ObjectMeta: meta}).Build()
+	_, err = Flagd(ctx, c, "cluster.local", key)
+	require.ErrorIs(t, err, common.ErrFlagdNotReady)
+
+	ready := &appsV1.Deployment{ObjectMeta: meta, Status: appsV1.DeploymentStatus{ReadyReplicas: 1}}
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&api.Flagd{ObjectMeta: meta}, ready, &corev1.Service{
+		ObjectMeta: meta,
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "flagd", Port: 8013}}},
+	}).Build()
+	_, err = Flagd(ctx, c, "cluster.local", key)
+	require.ErrorContains(t, err, "exposes no sync port")
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&api.Flagd{ObjectMeta: meta}, ready, &corev1.Service{
+		ObjectMeta: meta,
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "flagd", Port: 8013}, {Name: SyncPortName, Port: 8015}}},
+	}).Build()
+	address, err := Flagd(ctx, c, "cluster.local", key)
+	require.Nil(t, err)
+	require.Equal(t, "central-flagd.flags.svc.cluster.local:8015", address.String())
+}
+
+func TestFlagdProxy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, clientgoscheme.AddToScheme(scheme))
+	cfg := &flagdproxy.FlagdProxyConfiguration{Namespace: "ofo-system", Port: 8015, ClusterDomain: "cluster.local"}
+	ctx := context.Background()
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	_, err := FlagdProxy(ctx, c, cfg)
+	require.ErrorIs(t, err, common.ErrFlagdProxyNotReady)
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&appsV1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ofo-system", Name: flagdproxy.FlagdProxyDeploymentName},
+		Status:     appsV1.DeploymentStatus{ReadyReplicas: 1},
+	}).Build()
+	address, err := FlagdProxy(ctx, c, cfg)
+	require.Nil(t, err)
+	require.Equal(t, Address{Host: "flagd-proxy-svc.ofo-system.svc.cluster.local", Port: 8015}, address)
+}
@@ -10,6 +10,10 @@ type EnvConfig struct {
 	FlagdProxyPort           int    `envconfig:"FLAGD_PROXY_PORT" default:"8015"`
 	FlagdProxyManagementPort int    `envconfig:"FLAGD_PROXY_MANAGEMENT_PORT" default:"8016"`
 	FlagdProxyDebugLogging   bool   `envconfig:"FLAGD_PROXY_DEBUG_LOGGING" default:"false"`
+	// PodMonitor for the flagd-proxy, created only if the Prometheus Operator CRDs are installed
+	FlagdProxyMonitoringEnabled  bool              `envconfig:"FLAGD_PROXY_MONITORING_ENABLED" default:"false"`
+	FlagdProxyMonitoringLabels   map[string]string `envconfig:"FLAGD_PROXY_MONITORING_LABELS" default:""`
+	FlagdProxyMonitoringInterval string            `envconfig:"FLAGD_PROXY_MONITORING_INTERVAL" default:""`
 	// renamed from CLUSTER_DOMAIN to FLAGD_CLUSTER_DOMAIN in 0.8.10
 	FlagdClusterDomain string `envconfig:"FLAGD_CLUSTER_DOMAIN" default:"cluster.local"`
 
@@ -44,4 +48,13 @@ type EnvConfig struct {
 	InProcessCache                 string `envconfig:"IN_PROCESS_CACHE" default:"lru"`
 	InProcessEnvVarPrefix          string `envconfig:"IN_PROCESS_ENV_VAR_PREFIX" default:"FLAGD"`
 	InProcessCacheMaxSize          int    `envconfig:"IN_PROCESS_CACHE_MAX_SIZE" default:"1000"`
+	// webhook configuration maintained for namespaces with the fail-closed admission policy
+	MutatingWebhookConfigurationName string `envconfig:"MUTATING_WEBHOOK_CONFIGURATION_NAME" default:"open-feature-operator-mutating-webhook-configuration"`
+	// name of the OperatorConfiguration in the operator namespace applied at runtime
+	OperatorConfigurationName string `envconfig:"OPERATOR_CONFIGURATION_NAME" default:"open-feature-operator-configuration"`
+	// tracing of the operator
+	TracingEnabled       bool    `envconfig:"TRACING_ENABLED" default:"false"`
+	TracingEndpoint      string  `envconfig:"TRACING_ENDPOINT" default:""`
+	TracingInsecure      bool    `envconfig:"TRACING_INSECURE" default:"false"`
+	TracingSamplingRatio float64 `envconfig:"TRACING_SAMPLING_RATIO" default:"1"`
 }
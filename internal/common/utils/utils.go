@@ -1,12 +1,18 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync/atomic"
 	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// pageSize limits the objects held in memory by ForEachPage
+const pageSize = 500
+
 func TrueVal() *bool {
 	b := true
 	return &b
@@ -63,3 +69,20 @@ func (e *ExponentialBackoff) Next() time.Duration {
 func (e *ExponentialBackoff) Reset() {
 	e.counter = 0
 }
+
+// ForEachPage lists the objects of the namespace page by page into the given list, calling fn after each page
+func ForEachPage(ctx context.Context, reader client.Reader, list client.ObjectList, namespace string, fn func() error) error {
+	continueToken := ""
+	for {
+		if err := reader.List(ctx, list, client.InNamespace(namespace), client.Limit(pageSize), client.Continue(continueToken)); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package webhookcert
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CACertKey holds the CA certificate in the Secret
+	CACertKey = "ca.crt"
+	// PreviousCACertKey holds the CA certificate replaced by the last CA rotation, it is kept in the CA bundle until it
+	// expires as serving certificates signed by it may still be in use
+	PreviousCACertKey = "ca-previous.crt"
+	caKeyKey          = "ca.key"
+
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 365 * 24 * time.Hour
+	// certificates are rotated once their remaining validity falls below the threshold
+	caRotationThreshold   = 365 * 24 * time.Hour
+	certRotationThreshold = 30 * 24 * time.Hour
+
+	checkInterval = time.Hour
+	retryInterval = 10 * time.Second
+)
+
+var errNotReady = errors.New("webhook certificate is not ready")
+
+// Rotator generates the CA and serving certificate of the webhook server into a Secret, rotates them before they
+// expire and injects the CA bundle into the webhook configurations and the conversion webhooks of the CRDs
+type Rotator struct {
+	// Client writes the Secret and the webhook configurations
+	Client client.Client
+	// Reader reads the Secret and the webhook configurations, Secrets are not cached by the operator
+	Reader client.Reader
+	Log    logr.Logger
+	// Secret holding the certificates, shared by all replicas of the operator
+	Secret client.ObjectKey
+	// Service of the webhook server, the serving certificate is issued for its DNS names
+	Service       client.ObjectKey
+	ClusterDomain string
+	// MutatingWebhookConfigurations and ValidatingWebhookConfigurations receive the CA bundle, CRDs receive the CA
+	// bundle if their conversion webhook is served by the Service
+	MutatingWebhookConfigurations   []string
+	ValidatingWebhookConfigurations []string
+	// Now returns the current time, defaults to time.Now
+	Now func() time.Time
+
+	certificate atomic.Pointer[tls.Certificate]
+}
+
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;patch
+
+// NeedLeaderElection is false as every replica serves the webhooks
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start ensures the certificates periodically until the context is done
+func (r *Rotator) Start(ctx context.Context) error {
+	for {
+		interval := checkInterval
+		if err := r.Ensure(ctx); err != nil {
+			r.Log.Error(err, "Failed to ensure the webhook certificate")
+			interval = retryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// GetCertificate returns the current serving certificate to the webhook server
+func (r *Rotator) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if cert := r.certificate.Load(); cert != nil {
+		return cert, nil
+	}
+	return nil, errNotReady
+}
+
+// IsReady reports whether a serving certificate has been loaded
+func (r *Rotator) IsReady(_ *http.Request) error {
+	if r.certificate.Load() == nil {
+		return errNotReady
+	}
+	return nil
+}
+
+// Ensure creates or rotates the certificates, loads the serving certificate and injects the CA bundle
+func (r *Rotator) Ensure(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	err := r.Reader.Get(ctx, r.Secret, secret)
+	switch {
+	case k8serrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      r.Secret.Name,
+				Namespace: r.Secret.Namespace,
+				Labels: map[string]string{
+					common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{},
+		}
+		if _, err := r.rotate(secret); err != nil {
+			return err
+		}
+		r.Log.Info(fmt.Sprintf("Creating webhook certificate secret %s", r.Secret))
+		// the creation fails if another replica created the Secret meanwhile, the Secret is read on the next attempt
+		if err := r.Client.Create(ctx, secret); err != nil {
+			return fmt.Errorf("could not create the webhook certificate secret %s: %w", r.Secret, err)
+		}
+	case err != nil:
+		return fmt.Errorf("could not get the webhook certificate secret %s: %w", r.Secret, err)
+	default:
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		rotated, err := r.rotate(secret)
+		if err != nil {
+			return err
+		}
+		if rotated {
+			r.Log.Info(fmt.Sprintf("Rotating the webhook certificates in secret %s", r.Secret))
+			if err := r.Client.Update(ctx, secret); err != nil {
+				return fmt.Errorf("could not update the webhook certificate secret %s: %w", r.Secret, err)
+			}
+		}
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("could not load the webhook serving certificate: %w", err)
+	}
+	r.certificate.Store(&cert)
+
+	return r.injectCABundle(ctx, r.caBundle(secret.Data))
+}
+
+// rotate generates the certificates of the Secret which are missing, invalid or about to expire
+func (r *Rotator) rotate(secret *corev1.Secret) (bool, error) {
+	now := r.now()
+	ca, caKey, err := parseKeyPair(secret.Data[CACertKey], secret.Data[caKeyKey])
+	rotateCA := err != nil || now.Add(caRotationThreshold).After(ca.NotAfter)
+	if rotateCA {
+		if err == nil {
+			secret.Data[PreviousCACertKey] = secret.Data[CACertKey]
+		}
+		var caPEM, caKeyPEM []byte
+		ca, caKey, caPEM, caKeyPEM, err = generate(now, caValidity, caTemplate(), nil, nil)
+		if err != nil {
+			return false, fmt.Errorf("could not generate the webhook CA: %w", err)
+		}
+		secret.Data[CACertKey] = caPEM
+		secret.Data[caKeyKey] = caKeyPEM
+	}
+
+	cert, _, err := parseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if !rotateCA && err == nil &&
+		!now.Add(certRotationThreshold).After(cert.NotAfter) &&
+		cert.CheckSignatureFrom(ca) == nil &&
+		slices.Equal(cert.DNSNames, r.dnsNames()) {
+		return false, nil
+	}
+	_, _, certPEM, keyPEM, err := generate(now, certValidity, r.servingTemplate(), ca, caKey)
+	if err != nil {
+		return false, fmt.Errorf("could not generate the webhook serving certificate: %w", err)
+	}
+	secret.Data[corev1.TLSCertKey] = certPEM
+	secret.Data[corev1.TLSPrivateKeyKey] = keyPEM
+	return true, nil
+}
+
+// caBundle returns the current CA and the previous CA as long as it is valid
+func (r *Rotator) caBundle(data map[string][]byte) []byte {
+	bundle := slices.Clone(data[CACertKey])
+	if previous, err := parseCertificate(data[PreviousCACertKey]); err == nil && r.now().Before(previous.NotAfter) {
+		bundle = append(bundle, data[PreviousCACertKey]...)
+	}
+	return bundle
+}
+
+func (r *Rotator) injectCABundle(ctx context.Context, bundle []byte) error {
+	for _, name := range r.MutatingWebhookConfigurations {
+		cfg := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := r.Reader.Get(ctx, client.ObjectKey{Name: name}, cfg); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		patch := client.MergeFromWithOptions(cfg.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		changed := false
+		for i := range cfg.Webhooks {
+			changed = setCABundle(&cfg.Webhooks[i].ClientConfig, bundle) || changed
+		}
+		if !changed {
+			continue
+		}
+		if err := r.Client.Patch(ctx, cfg, patch); err != nil {
+			return fmt.Errorf("could not inject the CA bundle into %s: %w", name, err)
+		}
+	}
+
+	for _, name := range r.ValidatingWebhookConfigurations {
+		cfg := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := r.Reader.Get(ctx, client.ObjectKey{Name: name}, cfg); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		patch := client.MergeFromWithOptions(cfg.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		changed := false
+		for i := range cfg.Webhooks {
+			changed = setCABundle(&cfg.Webhooks[i].ClientConfig, bundle) || changed
+		}
+		if !changed {
+			continue
+		}
+		if err := r.Client.Patch(ctx, cfg, patch); err != nil {
+			return fmt.Errorf("could not inject the CA bundle into %s: %w", name, err)
+		}
+	}
+
+	crds := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := r.Reader.List(ctx, crds); err != nil {
+		return err
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		conversion := crd.Spec.Conversion
+		if conversion == nil || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+			continue
+		}
+		service := conversion.Webhook.ClientConfig.Service
+		if service == nil || service.Name != r.Service.Name || service.Namespace != r.Service.Namespace ||
+			bytes.Equal(conversion.Webhook.ClientConfig.CABundle, bundle) {
+			continue
+		}
+		patch := client.MergeFromWithOptions(crd.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		conversion.Webhook.ClientConfig.CABundle = bundle
+		if err := r.Client.Patch(ctx, crd, patch); err != nil {
+			return fmt.Errorf("could not inject the CA bundle into CRD %s: %w", crd.Name, err)
+		}
+	}
+	return nil
+}
+
+func setCABundle(clientConfig *admissionregistrationv1.WebhookClientConfig, bundle []byte) bool {
+	if bytes.Equal(clientConfig.CABundle, bundle) {
+		return false
+	}
+	clientConfig.CABundle = bundle
+	return true
+}
+
+func (r *Rotator) dnsNames() []string {
+	name, ns := r.Service.Name, r.Service.Namespace
+	return []string{
+		name,
+		fmt.Sprintf("%s.%s", name, ns),
+		fmt.Sprintf("%s.%s.svc", name, ns),
+		fmt.Sprintf("%s.%s.svc.%s", name, ns, r.ClusterDomain),
+	}
+}
+
+func (r *Rotator) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func caTemplate() *x509.Certificate {
+	return &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "open-feature-operator-webhook-ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+}
+
+func (r *Rotator) servingTemplate() *x509.Certificate {
+	dnsNames := r.dnsNames()
+	return &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[2]},
+		DNSNames:    dnsNames,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+}
+
+// generate creates a key and a certificate from the template, self-signed if no parent is given
+func generate(now time.Time, validity time.Duration, template, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer, []byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	template.SerialNumber = serial
+	// tolerate clock skew between the operator and the API server
+	template.NotBefore = now.Add(-time.Hour)
+	template.NotAfter = now.Add(validity)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, key, certPEM, keyPEM, nil
+}
+
+func parseKeyPair(certPEM, keyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("unsupported private key")
+	}
+	return cert, key, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("no certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,170 @@
+package webhookcert
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	namespace         = "open-feature-operator-system"
+	serviceName       = "open-feature-operator-webhook-service"
+	mutatingName      = "open-feature-operator-mutating-webhook-configuration"
+	validatingName    = "open-feature-operator-validating-webhook-configuration"
+	conversionCRDName = "featureflags.core.openfeature.dev"
+)
+
+func TestRotator_Ensure(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: mutatingName},
+			Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "mutate.openfeature.dev"}},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: validatingName},
+			Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "validate.featureflag.openfeature.dev"}},
+		},
+		conversionCRD(conversionCRDName, serviceName),
+		conversionCRD("other.example.com", "other-service"),
+	).Build()
+
+	now := time.Now()
+	r := &Rotator{
+		Client:                          c,
+		Reader:                          c,
+		Log:                             testr.New(t),
+		Secret:                          client.ObjectKey{Namespace: namespace, Name: "open-feature-operator-webhook-server-cert"},
+		Service:                         client.ObjectKey{Namespace: namespace, Name: serviceName},
+		ClusterDomain:                   "cluster.local",
+		MutatingWebhookConfigurations:   []string{mutatingName, mutatingName + "-fail-closed"},
+		ValidatingWebhookConfigurations: []string{validatingName},
+		Now:                             func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	require.NotNil(t, r.IsReady(nil))
+	require.Nil(t, r.Ensure(ctx))
+	require.Nil(t, r.IsReady(nil))
+
+	secret := &corev1.Secret{}
+	require.Nil(t, c.Get(ctx, r.Secret, secret))
+	require.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	ca, err := parseCertificate(secret.Data[CACertKey])
+	require.Nil(t, err)
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	require.Nil(t, err)
+	require.Nil(t, cert.CheckSignatureFrom(ca))
+	require.Contains(t, cert.DNSNames, "open-feature-operator-webhook-service.open-feature-operator-system.svc")
+
+	served, err := r.GetCertificate(nil)
+	require.Nil(t, err)
+	require.Equal(t, cert.Raw, served.Certificate[0])
+
+	requireCABundle(t, c, secret.Data[CACertKey])
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Name: "other.example.com"}, crd))
+	require.Empty(t, crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+
+	// the certificates are kept while they are valid
+	require.Nil(t, r.Ensure(ctx))
+	unchanged := &corev1.Secret{}
+	require.Nil(t, c.Get(ctx, r.Secret, unchanged))
+	require.Equal(t, secret.Data, unchanged.Data)
+
+	// the serving certificate is rotated before it expires
+	now = cert.NotAfter.Add(-certRotationThreshold / 2)
+	require.Nil(t, r.Ensure(ctx))
+	rotated := &corev1.Secret{}
+	require.Nil(t, c.Get(ctx, r.Secret, rotated))
+	require.Equal(t, secret.Data[CACertKey], rotated.Data[CACertKey])
+	require.NotEqual(t, secret.Data[corev1.TLSCertKey], rotated.Data[corev1.TLSCertKey])
+
+	// the CA is rotated before it expires, the previous CA stays in the bundle
+	now = ca.NotAfter.Add(-caRotationThreshold / 2)
+	require.Nil(t, r.Ensure(ctx))
+	require.Nil(t, c.Get(ctx, r.Secret, rotated))
+	require.Equal(t, secret.Data[CACertKey], rotated.Data[PreviousCACertKey])
+	require.NotEqual(t, secret.Data[CACertKey], rotated.Data[CACertKey])
+	newCA, err := parseCertificate(rotated.Data[CACertKey])
+	require.Nil(t, err)
+	newCert, err := parseCertificate(rotated.Data[corev1.TLSCertKey])
+	require.Nil(t, err)
+	require.Nil(t, newCert.CheckSignatureFrom(newCA))
+	requireCABundle(t, c, append(rotated.Data[CACertKey], rotated.Data[PreviousCACertKey]...))
+}
+
+func TestRotator_Ensure_ServiceChanged(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme(t)).Build()
+	r := &Rotator{
+		Client:        c,
+		Reader:        c,
+		Log:           testr.New(t),
+		Secret:        client.ObjectKey{Namespace: namespace, Name: "webhook-cert"},
+		Service:       client.ObjectKey{Namespace: namespace, Name: serviceName},
+		ClusterDomain: "cluster.local",
+	}
+	ctx := context.Background()
+	require.Nil(t, r.Ensure(ctx))
+
+	r.Service.Name = "renamed-service"
+	require.Nil(t, r.Ensure(ctx))
+
+	secret := &corev1.Secret{}
+	require.Nil(t, c.Get(ctx, r.Secret, secret))
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	require.Nil(t, err)
+	require.Equal(t, r.dnsNames(), cert.DNSNames)
+}
+
+func requireCABundle(t *testing.T, c client.Client, bundle []byte) {
+	ctx := context.Background()
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Name: mutatingName}, mutating))
+	require.Equal(t, bundle, mutating.Webhooks[0].ClientConfig.CABundle)
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Name: validatingName}, validating))
+	require.Equal(t, bundle, validating.Webhooks[0].ClientConfig.CABundle)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.Nil(t, c.Get(ctx, client.ObjectKey{Name: conversionCRDName}, crd))
+	require.Equal(t, bundle, crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(bundle))
+}
+
+func conversionCRD(name, service string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig: &apiextensionsv1.WebhookClientConfig{
+						Service: &apiextensionsv1.ServiceReference{Namespace: namespace, Name: service},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	require.Nil(t, clientgoscheme.AddToScheme(s))
+	require.Nil(t, apiextensionsv1.AddToScheme(s))
+	return s
+}
@@ -0,0 +1,197 @@
+package failclosed
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// NameSuffix is appended to the name of the mutating webhook configuration of the operator
+	NameSuffix = "-fail-closed"
+	// webhookNamePrefix keeps the names of the webhooks unique across both configurations
+	webhookNamePrefix = "fail-closed."
+	// readyInterval is the interval in which the readiness of the pod mutator is checked
+	readyInterval = 5 * time.Second
+
+	certManagerInjectAnnotation = "cert-manager.io/inject-ca-from"
+	namespaceNameLabel          = "kubernetes.io/metadata.name"
+)
+
+// FailClosedWebhookReconciler maintains a copy of the mutating webhook configuration of the operator with the Fail
+// failure policy, scoped to the namespaces with the fail-closed admission policy
+type FailClosedWebhookReconciler struct {
+	client.Client
+	// ReqLogger contains the Logger of this controller
+	Log logr.Logger
+	// Name of the mutating webhook configuration of the operator, which ignores failures
+	Name string
+	// OperatorNamespace is excluded from the fail-closed webhooks, so the operator can always be started
+	OperatorNamespace string
+	// Ready reports whether the pod mutator serves admissions, the fail-closed configuration is only created once it does
+	Ready func() bool
+}
+
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch;create;update;delete
+
+// Reconcile creates or updates the fail-closed mutating webhook configuration from the configuration of the operator
+func (r *FailClosedWebhookReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "FailClosedWebhookReconciler.Reconcile", attribute.String("name", r.failClosedName()))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	// creating the configuration before the webhook serves admissions would deny the pods of the affected namespaces
+	if r.Ready != nil && !r.Ready() {
+		return ctrl.Result{RequeueAfter: readyInterval}, nil
+	}
+
+	source := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err = r.Client.Get(ctx, client.ObjectKey{Name: r.Name}, source); err != nil {
+		if errors.IsNotFound(err) {
+			r.Log.Info(fmt.Sprintf("Mutating webhook configuration %s not found, not maintaining %s", r.Name, r.failClosedName()))
+			return ctrl.Result{}, nil
+		}
+		r.Log.Error(err, fmt.Sprintf("Failed to get the mutating webhook configuration %s", r.Name))
+		return ctrl.Result{}, err
+	}
+
+	desired := r.desired(source)
+	existing := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	err = r.Client.Get(ctx, client.ObjectKey{Name: desired.Name}, existing)
+	if errors.IsNotFound(err) {
+		r.Log.Info(fmt.Sprintf("Creating mutating webhook configuration %s", desired.Name))
+		if err = r.Client.Create(ctx, desired); err != nil {
+			r.Log.Error(err, fmt.Sprintf("Failed to create the mutating webhook configuration %s", desired.Name))
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !common.IsManagedByOFO(existing) {
+		err = fmt.Errorf("mutating webhook configuration %s not managed by OFO", existing.Name)
+		r.Log.Error(err, "Failed to update the fail-closed mutating webhook configuration")
+		return ctrl.Result{}, err
+	}
+
+	if reflect.DeepEqual(existing.Webhooks, desired.Webhooks) &&
+		existing.Annotations[certManagerInjectAnnotation] == desired.Annotations[certManagerInjectAnnotation] &&
+		reflect.DeepEqual(existing.OwnerReferences, desired.OwnerReferences) {
+		return ctrl.Result{}, nil
+	}
+	existing.Webhooks = desired.Webhooks
+	existing.OwnerReferences = desired.OwnerReferences
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	if val, ok := desired.Annotations[certManagerInjectAnnotation]; ok {
+		existing.Annotations[certManagerInjectAnnotation] = val
+	} else {
+		delete(existing.Annotations, certManagerInjectAnnotation)
+	}
+	r.Log.Info(fmt.Sprintf("Updating mutating webhook configuration %s", desired.Name))
+	if err = r.Client.Update(ctx, existing); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to update the mutating webhook configuration %s", desired.Name))
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// desired copies the webhooks of the source configuration, failing the admissions of the pods in the namespaces with
+// the fail-closed admission policy if the webhook cannot be called
+func (r *FailClosedWebhookReconciler) desired(source *admissionregistrationv1.MutatingWebhookConfiguration) *admissionregistrationv1.MutatingWebhookConfiguration {
+	excluded := []string{metav1.NamespaceSystem}
+	if r.OperatorNamespace != "" {
+		excluded = append(excluded, r.OperatorNamespace)
+	}
+
+	webhooks := make([]admissionregistrationv1.MutatingWebhook, 0, len(source.Webhooks))
+	for i := range source.Webhooks {
+		// only the injection into pods is enforced, labeling the Deployments for the cache is best effort
+		if !mutatesPods(&source.Webhooks[i]) {
+			continue
+		}
+		webhook := *source.Webhooks[i].DeepCopy()
+		webhook.Name = webhookNamePrefix + webhook.Name
+		webhook.FailurePolicy = ptr.To(admissionregistrationv1.Fail)
+		webhook.NamespaceSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      common.AdmissionPolicyLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{common.AdmissionPolicyFailClosed},
+				},
+				{
+					Key:      namespaceNameLabel,
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   excluded,
+				},
+			},
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	configuration := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: r.failClosedName(),
+			Labels: map[string]string{
+				common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+			},
+			Annotations: map[string]string{},
+			// removed together with the configuration of the operator
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+				Kind:       "MutatingWebhookConfiguration",
+				Name:       source.Name,
+				UID:        source.UID,
+			}},
+		},
+		Webhooks: webhooks,
+	}
+	// cert-manager keeps the CA bundle of both configurations up to date
+	if val, ok := source.Annotations[certManagerInjectAnnotation]; ok {
+		configuration.Annotations[certManagerInjectAnnotation] = val
+	}
+	return configuration
+}
+
+func mutatesPods(webhook *admissionregistrationv1.MutatingWebhook) bool {
+	for _, rule := range webhook.Rules {
+		if slices.Contains(rule.Resources, "pods") || slices.Contains(rule.Resources, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *FailClosedWebhookReconciler) failClosedName() string {
+	return r.Name + NameSuffix
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FailClosedWebhookReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// changes of either configuration are reconciled into the fail-closed configuration
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("failclosedwebhook").
+		For(&admissionregistrationv1.MutatingWebhookConfiguration{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == r.Name || obj.GetName() == r.failClosedName()
+		}))).
+		Complete(r)
+}
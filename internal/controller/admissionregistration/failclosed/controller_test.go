@@ -0,0 +1,147 @@
+package failclosed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const configurationName = "open-feature-operator-mutating-webhook-configuration"
+
+func TestFailClosedWebhookReconciler_Reconcile(t *testing.T) {
+	source := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: configurationName,
+			UID:  "1234",
+			Annotations: map[string]string{
+				certManagerInjectAnnotation: "open-feature-operator-system/open-feature-operator-serving-cert",
+			},
+		},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{{
+			Name: "mutate-deployment.openfeature.dev",
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Rule: admissionregistrationv1.Rule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+			}},
+		}, {
+			Name: "mutate.openfeature.dev",
+			Rules: []admissionregistrationv1.RuleWithOperations{{
+				Rule: admissionregistrationv1.Rule{APIGroups: []string{""}, Resources: []string{"pods"}},
+			}},
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{
+					Namespace: "open-feature-operator-system",
+					Name:      "open-feature-operator-webhook-service",
+					Path:      ptr.To("/mutate-v1-pod"),
+				},
+				CABundle: []byte("ca"),
+			},
+			FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      common.AdmissionPolicyLabel,
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   []string{common.AdmissionPolicyFailClosed},
+				}},
+			},
+		}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(source).Build()
+	ready := false
+	r := &FailClosedWebhookReconciler{
+		Client:            c,
+		Log:               testr.New(t),
+		Name:              configurationName,
+		OperatorNamespace: "open-feature-operator-system",
+		Ready:             func() bool { return ready },
+	}
+	ctx := context.Background()
+	key := client.ObjectKey{Name: configurationName + NameSuffix}
+
+	// not created before the pod mutator is ready
+	result, err := r.Reconcile(ctx, ctrl.Request{})
+	require.Nil(t, err)
+	require.Equal(t, readyInterval, result.RequeueAfter)
+	err = c.Get(ctx, key, &admissionregistrationv1.MutatingWebhookConfiguration{})
+	require.True(t, errors.IsNotFound(err))
+
+	ready = true
+	_, err = r.Reconcile(ctx, ctrl.Request{})
+	require.Nil(t, err)
+
+	failClosed := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	require.Nil(t, c.Get(ctx, key, failClosed))
+	require.True(t, common.IsManagedByOFO(failClosed))
+	require.Equal(t, source.Annotations[certManagerInjectAnnotation], failClosed.Annotations[certManagerInjectAnnotation])
+	require.Equal(t, configurationName, failClosed.OwnerReferences[0].Name)
+	require.Len(t, failClosed.Webhooks, 1)
+	webhook := failClosed.Webhooks[0]
+	require.Equal(t, "fail-closed.mutate.openfeature.dev", webhook.Name)
+	require.Equal(t, admissionregistrationv1.Fail, *webhook.FailurePolicy)
+	require.Equal(t, source.Webhooks[1].ClientConfig, webhook.ClientConfig)
+	require.Equal(t, []metav1.LabelSelectorRequirement{
+		{
+			Key:      common.AdmissionPolicyLabel,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{common.AdmissionPolicyFailClosed},
+		},
+		{
+			Key:      namespaceNameLabel,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{"kube-system", "open-feature-operator-system"},
+		},
+	}, webhook.NamespaceSelector.MatchExpressions)
+
+	// changes of the source configuration are applied
+	require.Nil(t, c.Get(ctx, client.ObjectKeyFromObject(source), source))
+	source.Webhooks[1].ClientConfig.CABundle = []byte("rotated")
+	require.Nil(t, c.Update(ctx, source))
+
+	_, err = r.Reconcile(ctx, ctrl.Request{})
+	require.Nil(t, err)
+	require.Nil(t, c.Get(ctx, key, failClosed))
+	require.Equal(t, []byte("rotated"), failClosed.Webhooks[0].ClientConfig.CABundle)
+}
+
+func TestFailClosedWebhookReconciler_Reconcile_NotManaged(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: configurationName},
+		},
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: configurationName + NameSuffix},
+		},
+	).Build()
+	r := &FailClosedWebhookReconciler{
+		Client: c,
+		Log:    testr.New(t),
+		Name:   configurationName,
+	}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.NotNil(t, err)
+}
+
+func TestFailClosedWebhookReconciler_Reconcile_SourceNotFound(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	r := &FailClosedWebhookReconciler{
+		Client: c,
+		Log:    testr.New(t),
+		Name:   configurationName,
+	}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{})
+	require.Nil(t, err)
+	err = c.Get(context.Background(), client.ObjectKey{Name: configurationName + NameSuffix}, &admissionregistrationv1.MutatingWebhookConfiguration{})
+	require.True(t, errors.IsNotFound(err))
+}
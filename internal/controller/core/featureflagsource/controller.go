@@ -25,11 +25,20 @@ import (
 	"github.com/go-logr/logr"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/metrics"
+	"github.com/open-feature/open-feature-operator/internal/common/monitoring"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
 	"github.com/open-feature/open-feature-operator/internal/common/utils"
+	"go.opentelemetry.io/otel/attribute"
 	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,6 +56,9 @@ type FeatureFlagSourceReconciler struct {
 	// FlagdProxy is the handler for the flagd-proxy deployment
 	FlagdProxy        *flagdproxy.FlagdProxyHandler
 	FlagdProxyBackoff *utils.ExponentialBackoff
+
+	// Recorder emits events on the FeatureFlagSource and the restarted deployments
+	Recorder record.EventRecorder
 }
 
 // renovate: datasource=github-tags depName=open-feature/flagd/flagd-proxy
@@ -57,13 +69,20 @@ const flagdProxyTag = "v0.9.4"
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;create
 //+kubebuilder:rbac:groups=core.openfeature.dev,resources=featureflagsources/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors;servicemonitors,verbs=get;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.13.0/pkg/reconcile
-func (r *FeatureFlagSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *FeatureFlagSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "FeatureFlagSourceReconciler.Reconcile", attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	r.Log.Info("Searching for FeatureFlagSource")
 
 	// Fetch the FeatureFlagSource from the cache
@@ -90,30 +109,61 @@ func (r *FeatureFlagSourceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		r.Log.Info(fmt.Sprintf("featureflagsource %s uses flagd-proxy, checking deployment", req.NamespacedName))
 		if err := r.FlagdProxy.HandleFlagdProxy(ctx); err != nil {
 			r.Log.Error(err, "error handling the flagd-proxy deployment")
+			common.RecordEvent(r.Recorder, fsConfig, corev1.EventTypeWarning, common.EventReasonFlagdProxyFailed, "could not deploy flagd-proxy: %s", err.Error())
 			return reconcile.Result{RequeueAfter: r.FlagdProxyBackoff.Next()}, err
 		} else {
 			r.FlagdProxyBackoff.Reset()
 		}
 	}
 
+	if err := r.handleMonitoring(ctx, fsConfig); err != nil {
+		common.RecordEvent(r.Recorder, fsConfig, corev1.EventTypeWarning, common.EventReasonMonitorFailed, "could not reconcile the PodMonitor of the flagd sidecars: %s", err.Error())
+		return r.finishReconcile(err, false)
+	}
+
 	if fsConfig.Spec.RolloutOnChange == nil || !*fsConfig.Spec.RolloutOnChange {
 		return r.finishReconcile(nil, false)
 	}
 
-	err := r.handleDeploymentUpdate(ctx, fsConfig)
+	err = r.handleDeploymentUpdate(ctx, fsConfig)
 
 	return r.finishReconcile(err, false)
 }
 
+// handleMonitoring reconciles the PodMonitor scraping the sidecars which the webhook labeled with the UID
+// of this FeatureFlagSource, pods may live in any namespace
+func (r *FeatureFlagSourceReconciler) handleMonitoring(ctx context.Context, fsConfig *api.FeatureFlagSource) error {
+	spec := api.MonitoringSpec{}
+	if fsConfig.Spec.Monitoring != nil {
+		spec = *fsConfig.Spec.Monitoring
+	}
+	return monitoring.Ensure(ctx, r.Client, monitoring.Monitor{
+		GVK:       monitoring.PodMonitorGVK,
+		Name:      fmt.Sprintf("%s-flagd", fsConfig.Name),
+		Namespace: fsConfig.Namespace,
+		Owner: metav1.OwnerReference{
+			APIVersion: api.GroupVersion.String(),
+			Kind:       "FeatureFlagSource",
+			Name:       fsConfig.Name,
+			UID:        fsConfig.UID,
+			Controller: ptr.To(true),
+		},
+		Spec: spec,
+		Selector: map[string]string{
+			common.FlagdMonitorLabel: string(fsConfig.UID),
+		},
+		Port:          "management",
+		AllNamespaces: true,
+	})
+}
+
 func (r *FeatureFlagSourceReconciler) handleDeploymentUpdate(ctx context.Context, fsConfig *api.FeatureFlagSource) error {
 	// Object has been updated, so, we can restart any deployments that are using this annotation
 	// => 	we know there has been an update because we are using the GenerationChangedPredicate filter
 	// 		and our resource exists within the cluster
 	deployList := &appsV1.DeploymentList{}
-	if err := r.Client.List(ctx, deployList, client.MatchingFields{
-		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPath, common.FeatureFlagSourceAnnotation): "true",
-	}); err != nil {
-		r.Log.Error(err, fmt.Sprintf("Failed to get the pods with annotation %s/%s", common.OpenFeatureAnnotationPath, common.FeatureFlagSourceAnnotation))
+	if err := r.Client.List(ctx, deployList, client.MatchingLabelsSelector{Selector: cachelabel.Selector()}); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to get the deployments with label %s", cachelabel.Label))
 		return err
 	}
 
@@ -130,8 +180,13 @@ func (r *FeatureFlagSourceReconciler) handleDeploymentUpdate(ctx context.Context
 			deployment.Spec.Template.ObjectMeta.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
 			if err := r.Client.Update(ctx, &deployment); err != nil {
 				r.Log.V(1).Error(err, fmt.Sprintf("Failed to update Deployment: %s/%s", deployment.Namespace, deployment.Name))
+				metrics.RolloutRestarts.WithLabelValues(metrics.ResultFailure).Inc()
+				common.RecordEvent(r.Recorder, fsConfig, corev1.EventTypeWarning, common.EventReasonRolloutRestartFailed, "could not restart deployment %s/%s: %s", deployment.Namespace, deployment.Name, err.Error())
 				continue
 			}
+			metrics.RolloutRestarts.WithLabelValues(metrics.ResultSuccess).Inc()
+			common.RecordEvent(r.Recorder, fsConfig, corev1.EventTypeNormal, common.EventReasonRolloutRestarted, "restarted deployment %s/%s", deployment.Namespace, deployment.Name)
+			common.RecordEvent(r.Recorder, &deployment, corev1.EventTypeNormal, common.EventReasonRolloutRestarted, "restarted due to a change of FeatureFlagSource %s/%s", fsConfig.Namespace, fsConfig.Name)
 		}
 	}
 
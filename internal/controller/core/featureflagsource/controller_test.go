@@ -9,15 +9,24 @@ import (
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	applyfake "github.com/open-feature/open-feature-operator/internal/common/apply/fake"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/monitoring"
 	commontypes "github.com/open-feature/open-feature-operator/internal/common/types"
 	"github.com/open-feature/open-feature-operator/internal/common/utils"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -48,6 +57,7 @@ func TestFeatureFlagSourceReconciler_Reconcile(t *testing.T) {
 		restartedAtValueBeforeReconcile string
 		restartedAtValueAfterReconcile  string
 		flagdProxyDeployment            bool
+		wantEvents                      []string
 	}{
 		{
 			name:                            "deployment gets restarted with rollout",
@@ -55,6 +65,10 @@ func TestFeatureFlagSourceReconciler_Reconcile(t *testing.T) {
 			deployment:                      createTestDeployment(fsConfigName, testNamespace, deploymentName),
 			restartedAtValueBeforeReconcile: "",
 			restartedAtValueAfterReconcile:  time.Now().Format(time.RFC3339),
+			wantEvents: []string{
+				fmt.Sprintf("Normal RolloutRestarted restarted deployment %s/%s", testNamespace, deploymentName),
+				fmt.Sprintf("Normal RolloutRestarted restarted due to a change of FeatureFlagSource %s/%s", testNamespace, fsConfigName),
+			},
 		},
 		{
 			name:                            "deployment without rollout",
@@ -97,9 +111,9 @@ func TestFeatureFlagSourceReconciler_Reconcile(t *testing.T) {
 			// setting up fake k8s client
 			var fakeClient client.Client
 			if tt.deployment != nil {
-				fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(createOFOTestDeployment(testNamespace), tt.fsConfig, tt.deployment).WithIndex(&appsv1.Deployment{}, fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPath, common.FeatureFlagSourceAnnotation), common.FeatureFlagSourceIndex).Build()
+				fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(createOFOTestDeployment(testNamespace), tt.fsConfig, tt.deployment).Build()
 			} else {
-				fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(createOFOTestDeployment(testNamespace), tt.fsConfig).WithIndex(&appsv1.Deployment{}, fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPath, common.FeatureFlagSourceAnnotation), common.FeatureFlagSourceIndex).Build()
+				fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(createOFOTestDeployment(testNamespace), tt.fsConfig).Build()
 			}
 			kpConfig := flagdproxy.NewFlagdProxyConfiguration(commontypes.EnvConfig{
 				FlagdProxyImage: "ghcr.io/open-feature/flagd-proxy",
@@ -113,12 +127,14 @@ func TestFeatureFlagSourceReconciler_Reconcile(t *testing.T) {
 				ctrl.Log.WithName("featureflagsource-FlagdProxyhandler"),
 			)
 
+			recorder := record.NewFakeRecorder(10)
 			r := &FeatureFlagSourceReconciler{
 				Client:            fakeClient,
 				Log:               ctrl.Log.WithName("featureflagsource-controller"),
 				Scheme:            fakeClient.Scheme(),
 				FlagdProxy:        kph,
 				FlagdProxyBackoff: &utils.ExponentialBackoff{StartDelay: time.Duration(0), MaxDelay: time.Duration(0)},
+				Recorder:          recorder,
 			}
 
 			if tt.deployment != nil {
@@ -134,6 +150,16 @@ func TestFeatureFlagSourceReconciler_Reconcile(t *testing.T) {
 			_, err = r.Reconcile(ctx, req)
 			require.Nil(t, err)
 
+			close(recorder.Events)
+			events := []string{}
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			require.Equal(t, len(tt.wantEvents), len(events))
+			for i, want := range tt.wantEvents {
+				require.Equal(t, want, events[i])
+			}
+
 			if tt.deployment != nil {
 				// checking that the deployment does have 'restartedAt' set to the expected value after reconciliation
 				deployment := &appsv1.Deployment{}
@@ -168,12 +194,14 @@ func createTestDeployment(fsConfigName string, testNamespace string, deploymentN
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      deploymentName,
 			Namespace: testNamespace,
+			Labels: map[string]string{
+				cachelabel.Label: cachelabel.Value,
+			},
 		},
 		Spec: appsv1.DeploymentSpec{
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPath, common.FeatureFlagSourceAnnotation): "true",
 						fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationRoot, common.FeatureFlagSourceAnnotation): fmt.Sprintf("%s/%s", testNamespace, fsConfigName),
 					},
 					Labels: map[string]string{
@@ -205,6 +233,56 @@ func createTestDeployment(fsConfigName string, testNamespace string, deploymentN
 	return deployment
 }
 
+func TestFeatureFlagSourceReconciler_Reconcile_PodMonitor(t *testing.T) {
+	const (
+		testNamespace = "test-namespace"
+		fsConfigName  = "test-config"
+	)
+	require.Nil(t, api.AddToScheme(scheme.Scheme))
+
+	fsConfig := createTestFSConfig(fsConfigName, testNamespace, false, apicommon.SyncProviderHttp)
+	fsConfig.UID = "test-uid"
+	fsConfig.Spec.Monitoring = &api.MonitoringSpec{
+		Enabled: true,
+		Labels:  map[string]string{"release": "prometheus"},
+	}
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+	mapper.Add(monitoring.PodMonitorGVK, meta.RESTScopeNamespace)
+	fakeClient := fake.NewClientBuilder().
+		WithInterceptorFuncs(applyfake.Interceptor()).
+		WithScheme(scheme.Scheme).
+		WithRESTMapper(meta.MultiRESTMapper{testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme), mapper}).
+		WithObjects(fsConfig).
+		Build()
+
+	r := &FeatureFlagSourceReconciler{
+		Client: fakeClient,
+		Log:    ctrl.Log.WithName("featureflagsource-controller"),
+		Scheme: fakeClient.Scheme(),
+	}
+
+	ctx := context.TODO()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: fsConfigName}}
+	_, err := r.Reconcile(ctx, req)
+	require.Nil(t, err)
+
+	podMonitor := &unstructured.Unstructured{}
+	podMonitor.SetGroupVersionKind(monitoring.PodMonitorGVK)
+	key := types.NamespacedName{Namespace: testNamespace, Name: fsConfigName + "-flagd"}
+	require.Nil(t, fakeClient.Get(ctx, key, podMonitor))
+	selector, _, _ := unstructured.NestedStringMap(podMonitor.Object, "spec", "selector", "matchLabels")
+	require.Equal(t, map[string]string{common.FlagdMonitorLabel: "test-uid"}, selector)
+
+	// disabling the monitoring removes the PodMonitor
+	require.Nil(t, fakeClient.Get(ctx, req.NamespacedName, fsConfig))
+	fsConfig.Spec.Monitoring.Enabled = false
+	require.Nil(t, fakeClient.Update(ctx, fsConfig))
+	_, err = r.Reconcile(ctx, req)
+	require.Nil(t, err)
+	require.True(t, errors.IsNotFound(fakeClient.Get(ctx, key, podMonitor)))
+}
+
 func createTestFSConfig(fsConfigName string, testNamespace string, rollout bool, provider apicommon.SyncProviderType) *api.FeatureFlagSource {
 	fsConfig := &api.FeatureFlagSource{
 		ObjectMeta: metav1.ObjectMeta{
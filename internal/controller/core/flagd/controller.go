@@ -19,26 +19,43 @@ package flagd
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/monitoring"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
+	"github.com/open-feature/open-feature-operator/internal/common/utils"
 	resources2 "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/common"
 	"github.com/open-feature/open-feature-operator/internal/controller/core/flagd/resources"
+	"go.opentelemetry.io/otel/attribute"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayApiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // FlagdReconciler reconciles a Flagd object
 type FlagdReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
 
 	FlagdConfig resources2.FlagdConfiguration
 
@@ -48,6 +65,12 @@ type FlagdReconciler struct {
 	FlagdService             resources.IFlagdResource
 	FlagdIngress             resources.IFlagdResource
 	FlagdGatewayApiHttpRoute resources.IFlagdResource
+	FlagdServiceMonitor      resources.IFlagdResource
+
+	// mu guards the configuration against changes of the OperatorConfiguration
+	mu sync.RWMutex
+	// configChanged triggers the reconciliation of all Flagd resources once the configuration changed
+	configChanged chan event.GenericEvent
 }
 
 type IFlagdResourceReconciler interface {
@@ -61,14 +84,56 @@ type IFlagdResourceReconciler interface {
 //+kubebuilder:rbac:groups=core,resources=services;services/finalizers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core.openfeature.dev,resources=featureflagsources,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core.openfeature.dev,resources=featureflagsources/finalizers,verbs=get
+//+kubebuilder:rbac:groups=core.openfeature.dev,resources=referencegrants,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+
+// ApplyConfiguration replaces the configuration of the resources created for Flagd resources, existing resources are
+// updated on their next reconciliation
+func (r *FlagdReconciler) ApplyConfiguration(cfg operatorconfig.Configuration) {
+	flagdConfig := NewFlagdConfiguration(cfg.Env, cfg.ImagePullSecrets, cfg.Labels, cfg.Annotations)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.FlagdConfig = flagdConfig
+	for _, resource := range []resources.IFlagdResource{r.FlagdDeployment, r.FlagdService, r.FlagdIngress, r.FlagdGatewayApiHttpRoute} {
+		switch res := resource.(type) {
+		case *resources.FlagdDeployment:
+			res.FlagdConfig = flagdConfig
+		case *resources.FlagdService:
+			res.FlagdConfig = flagdConfig
+		case *resources.FlagdIngress:
+			res.FlagdConfig = flagdConfig
+		case *resources.FlagdGatewayApiHttpRoute:
+			res.FlagdConfig = flagdConfig
+		}
+	}
+	if r.configChanged == nil {
+		return
+	}
+	select {
+	case r.configChanged <- event.GenericEvent{}:
+	default:
+		// a reconciliation of all Flagd resources is already pending
+	}
+}
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
-func (r *FlagdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *FlagdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "FlagdReconciler.Reconcile", attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	r.Log.Info("Searching for FeatureFlagSource")
 
 	// Fetch the Flagd resource
@@ -89,6 +154,7 @@ func (r *FlagdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		&appsv1.Deployment{},
 		r.FlagdDeployment,
 	); err != nil {
+		r.recordReconcileError(flagd, "Deployment", err)
 		return ctrl.Result{}, err
 	}
 
@@ -98,6 +164,7 @@ func (r *FlagdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		&v1.Service{},
 		r.FlagdService,
 	); err != nil {
+		r.recordReconcileError(flagd, "Service", err)
 		return ctrl.Result{}, err
 	}
 
@@ -108,8 +175,12 @@ func (r *FlagdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			&networkingv1.Ingress{},
 			r.FlagdIngress,
 		); err != nil {
+			r.recordReconcileError(flagd, "Ingress", err)
 			return ctrl.Result{}, err
 		}
+	} else if err := r.deleteResource(ctx, flagd, &networkingv1.Ingress{}); err != nil {
+		r.recordReconcileError(flagd, "Ingress", err)
+		return ctrl.Result{}, err
 	}
 
 	if flagd.Spec.GatewayApiRoutes.Enabled {
@@ -119,16 +190,185 @@ func (r *FlagdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			&gatewayApiv1.HTTPRoute{},
 			r.FlagdGatewayApiHttpRoute,
 		); err != nil {
+			r.recordReconcileError(flagd, "HTTPRoute", err)
 			return ctrl.Result{}, err
 		}
+	} else if err := r.deleteResource(ctx, flagd, &gatewayApiv1.HTTPRoute{}); err != nil {
+		r.recordReconcileError(flagd, "HTTPRoute", err)
+		return ctrl.Result{}, err
+	}
+
+	if flagd.Spec.Monitoring.Enabled {
+		if err := r.reconcileServiceMonitor(ctx, flagd); err != nil {
+			r.recordReconcileError(flagd, "ServiceMonitor", err)
+			return ctrl.Result{}, err
+		}
+	} else if err := r.deleteResource(ctx, flagd, newServiceMonitor()); err != nil {
+		r.recordReconcileError(flagd, "ServiceMonitor", err)
+		return ctrl.Result{}, err
 	}
 
 	return ctrl.Result{}, nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// reconcileServiceMonitor creates the ServiceMonitor only if the Prometheus Operator CRDs are installed
+func (r *FlagdReconciler) reconcileServiceMonitor(ctx context.Context, flagd *api.Flagd) error {
+	available, err := monitoring.IsAvailable(r.Client, monitoring.ServiceMonitorGVK)
+	if err != nil {
+		return err
+	}
+	if !available {
+		r.Log.Info(fmt.Sprintf("ServiceMonitor CRD not installed, skipping monitoring of Flagd '%s/%s'", flagd.Namespace, flagd.Name))
+		return nil
+	}
+	return r.ResourceReconciler.Reconcile(ctx, flagd, newServiceMonitor(), r.FlagdServiceMonitor)
+}
+
+// newServiceMonitor returns an empty ServiceMonitor, the Prometheus Operator types are not registered in the scheme
+func newServiceMonitor() *unstructured.Unstructured {
+	serviceMonitor := &unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(monitoring.ServiceMonitorGVK)
+	return serviceMonitor
+}
+
+// deleteResource removes a resource of the Flagd which is not desired anymore, e.g. its Ingress once the Ingress has
+// been disabled. Resources which are not managed by the operator or owned by another object are kept, resources of
+// kinds which are not installed in the cluster are skipped.
+func (r *FlagdReconciler) deleteResource(ctx context.Context, flagd *api.Flagd, obj client.Object) error {
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(flagd), obj)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !common.IsManagedByOFO(obj) || !ownedBy(obj, flagd) {
+		return nil
+	}
+
+	if err := r.Client.Delete(ctx, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	common.RecordEvent(r.Recorder, flagd, v1.EventTypeNormal, common.EventReasonResourceDeleted, "deleted %s %s/%s", kindOf(obj), obj.GetNamespace(), obj.GetName())
+	return nil
+}
+
+// ownedBy reports whether the object is owned by the Flagd, resources created by earlier versions of the operator are
+// not marked as controlled by their Flagd
+func ownedBy(obj client.Object, flagd *api.Flagd) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == flagd.UID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *FlagdReconciler) recordReconcileError(flagd *api.Flagd, kind string, err error) {
+	common.RecordEvent(r.Recorder, flagd, v1.EventTypeWarning, common.EventReasonReconcileFailed, "could not reconcile %s: %s", kind, err.Error())
+}
+
+// requestsForAllFlagds enqueues all Flagd resources, so they are reconciled with the current configuration
+func (r *FlagdReconciler) requestsForAllFlagds(ctx context.Context, _ client.Object) []reconcile.Request {
+	flagds := &api.FlagdList{}
+	if err := r.Client.List(ctx, flagds); err != nil {
+		r.Log.Error(err, "Failed to list the Flagd resources")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(flagds.Items))
+	for _, flagd := range flagds.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&flagd)})
+	}
+	return requests
+}
+
+// requestsForFeatureFlagSource enqueues the Flagd resources referencing the FeatureFlagSource, so that their Deployment
+// is rendered with its current spec
+func (r *FlagdReconciler) requestsForFeatureFlagSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	// FeatureFlagSources can be referenced across namespaces
+	flagds := &api.FlagdList{}
+	if err := r.Client.List(ctx, flagds); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to list the Flagd resources of FeatureFlagSource '%s/%s'", obj.GetNamespace(), obj.GetName()))
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, flagd := range flagds.Items {
+		for _, reference := range flagd.Spec.FeatureFlagSourceReferences() {
+			if ns, name := utils.ParseAnnotation(reference, flagd.Namespace); ns == obj.GetNamespace() && name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&flagd)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// requestsForReferenceGrant enqueues the Flagd resources referencing a FeatureFlagSource of the namespace of the
+// ReferenceGrant from another namespace, so that a granted or revoked reference is rolled out to their Deployment
+func (r *FlagdReconciler) requestsForReferenceGrant(ctx context.Context, obj client.Object) []reconcile.Request {
+	flagds := &api.FlagdList{}
+	if err := r.Client.List(ctx, flagds); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to list the Flagd resources of ReferenceGrant '%s/%s'", obj.GetNamespace(), obj.GetName()))
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, flagd := range flagds.Items {
+		if flagd.Namespace == obj.GetNamespace() {
+			continue
+		}
+		for _, reference := range flagd.Spec.FeatureFlagSourceReferences() {
+			if ns, _ := utils.ParseAnnotation(reference, flagd.Namespace); ns == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&flagd)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager. The HTTPRoutes and ServiceMonitors are only watched if
+// their CRDs are installed when the operator starts.
 func (r *FlagdReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	r.mu.Lock()
+	r.configChanged = make(chan event.GenericEvent, 1)
+	r.mu.Unlock()
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&api.Flagd{}).
-		Complete(r)
+		// status updates of the Deployment are frequent and do not need a reconciliation, the Deployments are only
+		// watched as metadata, the existing Deployment is read from the API server
+		Owns(&appsv1.Deployment{}, builder.OnlyMetadata, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Owns(&v1.Service{}).
+		Owns(&networkingv1.Ingress{}).
+		Watches(
+			&api.FeatureFlagSource{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForFeatureFlagSource),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&api.ReferenceGrant{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferenceGrant),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		WatchesRawSource(source.Channel(r.configChanged, handler.EnqueueRequestsFromMapFunc(r.requestsForAllFlagds)))
+
+	available, err := monitoring.IsAvailable(mgr.GetClient(), gatewayApiv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+	if err != nil {
+		return err
+	}
+	if available {
+		b = b.Owns(&gatewayApiv1.HTTPRoute{})
+	}
+	available, err = monitoring.IsAvailable(mgr.GetClient(), monitoring.ServiceMonitorGVK)
+	if err != nil {
+		return err
+	}
+	if available {
+		b = b.Owns(newServiceMonitor())
+	}
+	return b.Complete(r)
 }
@@ -10,19 +10,27 @@ import (
 
 	"github.com/golang/mock/gomock"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
+	commontypes "github.com/open-feature/open-feature-operator/internal/common/types"
 	resources "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/common"
 	commonmock "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/mock"
+	flagdresources "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/resources"
 	resourcemock "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/resources/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayApiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -473,3 +481,151 @@ func setupReconciler(fakeClient client.WithWatch, deploymentReconciler, serviceR
 		ResourceReconciler:       resourceReconciler,
 	}
 }
+
+func TestFlagdReconciler_ApplyConfiguration(t *testing.T) {
+	deployment := &flagdresources.FlagdDeployment{FlagdConfig: testFlagdConfig}
+	service := &flagdresources.FlagdService{FlagdConfig: testFlagdConfig}
+	ingress := &flagdresources.FlagdIngress{FlagdConfig: testFlagdConfig}
+	route := &flagdresources.FlagdGatewayApiHttpRoute{FlagdConfig: testFlagdConfig}
+	r := &FlagdReconciler{
+		FlagdDeployment:          deployment,
+		FlagdService:             service,
+		FlagdIngress:             ingress,
+		FlagdGatewayApiHttpRoute: route,
+		configChanged:            make(chan event.GenericEvent, 1),
+	}
+
+	env := commontypes.EnvConfig{
+		FlagdImage:          "registry.example.com/flagd",
+		FlagdTag:            "v1.0.0",
+		FlagdPort:           9013,
+		FlagdOFREPPort:      9016,
+		FlagdSyncPort:       9015,
+		FlagdManagementPort: 9014,
+	}
+	r.ApplyConfiguration(operatorconfig.Configuration{Env: env, Labels: map[string]string{"team": "flags"}})
+	r.ApplyConfiguration(operatorconfig.Configuration{Env: env, Labels: map[string]string{"team": "flags"}})
+
+	expected := NewFlagdConfiguration(env, nil, map[string]string{"team": "flags"}, nil)
+	require.Equal(t, expected, r.FlagdConfig)
+	require.Equal(t, expected, deployment.FlagdConfig)
+	require.Equal(t, expected, service.FlagdConfig)
+	require.Equal(t, expected, ingress.FlagdConfig)
+	require.Equal(t, expected, route.FlagdConfig)
+	// a single reconciliation of all Flagd resources is pending
+	require.Len(t, r.configChanged, 1)
+}
+
+func TestFlagdReconciler_ReconcileDeletesDisabledResources(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+	require.Nil(t, gatewayApiv1.Install(scheme.Scheme))
+
+	flagdObj := &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flagd",
+			Namespace: "my-namespace",
+			UID:       "flagd-uid",
+		},
+	}
+	ownedMeta := metav1.ObjectMeta{
+		Name:            flagdObj.Name,
+		Namespace:       flagdObj.Namespace,
+		Labels:          map[string]string{common.ManagedByAnnotationKey: common.ManagedByAnnotationValue},
+		OwnerReferences: []metav1.OwnerReference{{Name: flagdObj.Name, UID: flagdObj.UID}},
+	}
+	ingress := &networkingv1.Ingress{ObjectMeta: ownedMeta}
+	// resources which are not managed by the operator are kept
+	route := &gatewayApiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: flagdObj.Name, Namespace: flagdObj.Namespace}}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(flagdObj, ingress, route).Build()
+
+	ctrl := gomock.NewController(t)
+
+	deploymentResource := resourcemock.NewMockIFlagdResource(ctrl)
+	serviceResource := resourcemock.NewMockIFlagdResource(ctrl)
+
+	resourceReconciler := commonmock.NewMockIFlagdResourceReconciler(ctrl)
+
+	resourceReconciler.EXPECT().
+		Reconcile(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&appsv1.Deployment{}), deploymentResource).
+		Times(1).Return(nil)
+
+	resourceReconciler.EXPECT().
+		Reconcile(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&v1.Service{}), serviceResource).
+		Times(1).Return(nil)
+
+	r := setupReconciler(fakeClient, deploymentResource, serviceResource, nil, nil, resourceReconciler)
+	recorder := record.NewFakeRecorder(1)
+	r.Recorder = recorder
+
+	_, err = r.Reconcile(context.Background(), controllerruntime.Request{
+		NamespacedName: client.ObjectKeyFromObject(flagdObj),
+	})
+	require.Nil(t, err)
+
+	err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(ingress), &networkingv1.Ingress{})
+	require.True(t, k8serrors.IsNotFound(err))
+	require.Nil(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(route), &gatewayApiv1.HTTPRoute{}))
+	require.Equal(t, "Normal ResourceDeleted deleted Ingress my-namespace/my-flagd", <-recorder.Events)
+}
+
+func TestFlagdReconciler_requestsForFeatureFlagSource(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	flagd := func(namespace, name, source string) *api.Flagd {
+		return &api.Flagd{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       api.FlagdSpec{FeatureFlagSource: source},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		flagd("my-namespace", "flagd-1", "my-source"),
+		flagd("my-namespace", "flagd-2", "other-source"),
+		flagd("other-namespace", "flagd-3", "my-source"),
+		&api.Flagd{
+			ObjectMeta: metav1.ObjectMeta{Name: "flagd-4", Namespace: "other-namespace"},
+			Spec:       api.FlagdSpec{FeatureFlagSources: []string{"other-source", "my-namespace/my-source"}},
+		},
+	).Build()
+	r := setupReconciler(fakeClient, nil, nil, nil, nil, nil)
+
+	requests := r.requestsForFeatureFlagSource(context.Background(), &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-source", Namespace: "my-namespace"},
+	})
+
+	require.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "my-namespace", Name: "flagd-1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "other-namespace", Name: "flagd-4"}},
+	}, requests)
+}
+
+func TestFlagdReconciler_requestsForReferenceGrant(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&api.Flagd{
+			ObjectMeta: metav1.ObjectMeta{Name: "flagd-1", Namespace: "my-namespace"},
+			Spec:       api.FlagdSpec{FeatureFlagSource: "my-source"},
+		},
+		&api.Flagd{
+			ObjectMeta: metav1.ObjectMeta{Name: "flagd-2", Namespace: "other-namespace"},
+			Spec:       api.FlagdSpec{FeatureFlagSource: "other-source"},
+		},
+		&api.Flagd{
+			ObjectMeta: metav1.ObjectMeta{Name: "flagd-3", Namespace: "other-namespace"},
+			Spec:       api.FlagdSpec{FeatureFlagSources: []string{"other-source", "my-namespace/my-source"}},
+		},
+	).Build()
+	r := setupReconciler(fakeClient, nil, nil, nil, nil, nil)
+
+	requests := r.requestsForReferenceGrant(context.Background(), &api.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-grant", Namespace: "my-namespace"},
+	})
+
+	require.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "other-namespace", Name: "flagd-3"}},
+	}, requests)
+}
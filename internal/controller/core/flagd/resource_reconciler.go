@@ -3,26 +3,39 @@ package flagd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/go-logr/logr"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/apply"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
 	"github.com/open-feature/open-feature-operator/internal/controller/core/flagd/resources"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type ResourceReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
 }
 
-func (r *ResourceReconciler) Reconcile(ctx context.Context, flagd *api.Flagd, obj client.Object, resource resources.IFlagdResource) error {
+func (r *ResourceReconciler) Reconcile(ctx context.Context, flagd *api.Flagd, obj client.Object, resource resources.IFlagdResource) (err error) {
+	ctx, span := tracing.Start(ctx, "ResourceReconciler.Reconcile", attribute.String("kind", kindOf(obj)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	exists := false
 	existingObj := obj
-	err := r.Client.Get(ctx, client.ObjectKey{
+	err = r.Client.Get(ctx, client.ObjectKey{
 		Namespace: flagd.Namespace,
 		Name:      flagd.Name,
 	}, existingObj)
@@ -47,28 +60,38 @@ func (r *ResourceReconciler) Reconcile(ctx context.Context, flagd *api.Flagd, ob
 		return err
 	}
 
-	if !exists {
-		return r.createResource(ctx, flagd, obj, newObj)
-	} else if !resource.AreObjectsEqual(existingObj, newObj) {
-		return r.updateResource(ctx, flagd, obj, newObj)
+	if exists {
+		differs, err := apply.Differs(existingObj, newObj)
+		if err != nil {
+			r.Log.Error(err, fmt.Sprintf("Failed to compare flagd %s '%s/%s'", obj.GetObjectKind(), flagd.Namespace, flagd.Name))
+			return err
+		}
+		if !differs {
+			return nil
+		}
 	}
-	return nil
+	return r.applyResource(ctx, flagd, newObj, exists)
 }
 
-func (r *ResourceReconciler) createResource(ctx context.Context, flagd *api.Flagd, obj client.Object, newObj client.Object) error {
-	r.Log.Info(fmt.Sprintf("Creating %v", newObj))
-	if err := r.Client.Create(ctx, newObj); err != nil {
-		r.Log.Error(err, fmt.Sprintf("Failed to create Flagd %s '%s/%s'", obj.GetObjectKind(), flagd.Namespace, flagd.Name))
+// applyResource applies the resource with server-side apply, only the fields set by the operator are owned by it
+func (r *ResourceReconciler) applyResource(ctx context.Context, flagd *api.Flagd, newObj client.Object, exists bool) error {
+	r.Log.Info(fmt.Sprintf("Applying %s %s/%s", kindOf(newObj), newObj.GetNamespace(), newObj.GetName()))
+	if err := apply.Apply(ctx, r.Client, newObj); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to apply Flagd %s '%s/%s'", kindOf(newObj), flagd.Namespace, flagd.Name))
 		return err
 	}
+	if exists {
+		common.RecordEvent(r.Recorder, flagd, corev1.EventTypeNormal, common.EventReasonResourceUpdated, "updated %s %s/%s", kindOf(newObj), newObj.GetNamespace(), newObj.GetName())
+	} else {
+		common.RecordEvent(r.Recorder, flagd, corev1.EventTypeNormal, common.EventReasonResourceCreated, "created %s %s/%s", kindOf(newObj), newObj.GetNamespace(), newObj.GetName())
+	}
 	return nil
 }
 
-func (r *ResourceReconciler) updateResource(ctx context.Context, flagd *api.Flagd, obj client.Object, newObj client.Object) error {
-	r.Log.Info(fmt.Sprintf("Updating %v", newObj))
-	if err := r.Client.Update(ctx, newObj); err != nil {
-		r.Log.Error(err, fmt.Sprintf("Failed to update Flagd %s '%s/%s'", obj.GetObjectKind(), flagd.Namespace, flagd.Name))
-		return err
+// kindOf returns the kind of the object based on its go type, as the type meta of typed objects is usually empty
+func kindOf(obj client.Object) string {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.GetKind()
 	}
-	return nil
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
 }
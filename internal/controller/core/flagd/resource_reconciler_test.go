@@ -8,11 +8,13 @@ import (
 	"github.com/golang/mock/gomock"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	applyfake "github.com/open-feature/open-feature-operator/internal/common/apply/fake"
 	resourcemock "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/resources/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -22,12 +24,14 @@ func TestResourceReconciler_Reconcile_CreateResource(t *testing.T) {
 	err := api.AddToScheme(scheme.Scheme)
 	require.Nil(t, err)
 
-	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).Build()
 
+	recorder := record.NewFakeRecorder(1)
 	r := &ResourceReconciler{
-		Client: fakeClient,
-		Scheme: fakeClient.Scheme(),
-		Log:    controllerruntime.Log.WithName("resource-reconciler"),
+		Client:   fakeClient,
+		Scheme:   fakeClient.Scheme(),
+		Log:      controllerruntime.Log.WithName("resource-reconciler"),
+		Recorder: recorder,
 	}
 
 	flagdObj := &api.Flagd{
@@ -43,6 +47,7 @@ func TestResourceReconciler_Reconcile_CreateResource(t *testing.T) {
 		GetResource(gomock.Any(), flagdMatcher{flagdObj: *flagdObj}).
 		Times(1).
 		Return(&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: flagdObj.Namespace,
 				Name:      flagdObj.Name,
@@ -66,6 +71,7 @@ func TestResourceReconciler_Reconcile_CreateResource(t *testing.T) {
 	}, result)
 
 	require.Nil(t, err)
+	require.Equal(t, "Normal ResourceCreated created ConfigMap my-namespace/my-flagd", <-recorder.Events)
 }
 
 func TestResourceReconciler_Reconcile_UpdateManagedResource(t *testing.T) {
@@ -79,7 +85,7 @@ func TestResourceReconciler_Reconcile_UpdateManagedResource(t *testing.T) {
 		},
 	}
 
-	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.ConfigMap{
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(&corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: flagdObj.Namespace,
 			Name:      flagdObj.Name,
@@ -90,10 +96,12 @@ func TestResourceReconciler_Reconcile_UpdateManagedResource(t *testing.T) {
 		Data: map[string]string{},
 	}).Build()
 
+	recorder := record.NewFakeRecorder(1)
 	r := &ResourceReconciler{
-		Client: fakeClient,
-		Scheme: fakeClient.Scheme(),
-		Log:    controllerruntime.Log.WithName("resource-reconciler"),
+		Client:   fakeClient,
+		Scheme:   fakeClient.Scheme(),
+		Log:      controllerruntime.Log.WithName("resource-reconciler"),
+		Recorder: recorder,
 	}
 
 	ctrl := gomock.NewController(t)
@@ -102,6 +110,7 @@ func TestResourceReconciler_Reconcile_UpdateManagedResource(t *testing.T) {
 		GetResource(gomock.Any(), flagdMatcher{flagdObj: *flagdObj}).
 		Times(1).
 		Return(&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: flagdObj.Namespace,
 				Name:      flagdObj.Name,
@@ -111,8 +120,6 @@ func TestResourceReconciler_Reconcile_UpdateManagedResource(t *testing.T) {
 			},
 		}, nil)
 
-	mockRes.EXPECT().AreObjectsEqual(gomock.Any(), gomock.Any()).Return(false)
-
 	err = r.Reconcile(
 		context.Background(),
 		flagdObj,
@@ -132,6 +139,65 @@ func TestResourceReconciler_Reconcile_UpdateManagedResource(t *testing.T) {
 
 	// verify the resource was updated
 	require.Equal(t, "bar", result.Data["foo"])
+	require.Equal(t, "Normal ResourceUpdated updated ConfigMap my-namespace/my-flagd", <-recorder.Events)
+}
+
+func TestResourceReconciler_Reconcile_AppliedResourceUnchanged(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).Build()
+
+	recorder := record.NewFakeRecorder(2)
+	r := &ResourceReconciler{
+		Client:   fakeClient,
+		Scheme:   fakeClient.Scheme(),
+		Log:      controllerruntime.Log.WithName("resource-reconciler"),
+		Recorder: recorder,
+	}
+
+	flagdObj := &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flagd",
+			Namespace: "my-namespace",
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRes := resourcemock.NewMockIFlagdResource(ctrl)
+	mockRes.EXPECT().
+		GetResource(gomock.Any(), flagdMatcher{flagdObj: *flagdObj}).
+		Times(2).
+		DoAndReturn(func(context.Context, *api.Flagd) (client.Object, error) {
+			return &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: flagdObj.Namespace,
+					Name:      flagdObj.Name,
+					Labels: map[string]string{
+						common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+					},
+				},
+				Data: map[string]string{
+					"foo": "bar",
+				},
+			}, nil
+		})
+
+	require.Nil(t, r.Reconcile(context.Background(), flagdObj, &corev1.ConfigMap{}, mockRes))
+	require.Equal(t, "Normal ResourceCreated created ConfigMap my-namespace/my-flagd", <-recorder.Events)
+
+	// fields set by other field managers are kept and do not cause an update
+	result := &corev1.ConfigMap{}
+	require.Nil(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: flagdObj.Namespace, Name: flagdObj.Name}, result))
+	result.Annotations = map[string]string{"sidecar.istio.io/status": "injected"}
+	require.Nil(t, fakeClient.Update(context.Background(), result))
+
+	require.Nil(t, r.Reconcile(context.Background(), flagdObj, &corev1.ConfigMap{}, mockRes))
+	require.Empty(t, recorder.Events)
+
+	require.Nil(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: flagdObj.Namespace, Name: flagdObj.Name}, result))
+	require.Equal(t, "injected", result.Annotations["sidecar.istio.io/status"])
 }
 
 func TestResourceReconciler_Reconcile_CannotCreateResource(t *testing.T) {
@@ -145,7 +211,7 @@ func TestResourceReconciler_Reconcile_CannotCreateResource(t *testing.T) {
 		},
 	}
 
-	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.ConfigMap{
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(&corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: flagdObj.Namespace,
 			Name:      flagdObj.Name,
@@ -190,7 +256,7 @@ func TestResourceReconciler_Reconcile_UnmanagedResourceAlreadyExists(t *testing.
 		},
 	}
 
-	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.ConfigMap{
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(&corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: flagdObj.Namespace,
 			Name:      flagdObj.Name,
@@ -4,17 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 
 	"github.com/go-logr/logr"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdinjector"
+	"github.com/open-feature/open-feature-operator/internal/common/referencegrant"
+	"github.com/open-feature/open-feature-operator/internal/common/utils"
 	"github.com/open-feature/open-feature-operator/internal/controller/core/flagd/common"
 	"golang.org/x/exp/maps"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,20 +29,6 @@ type FlagdDeployment struct {
 	FlagdConfig   resources.FlagdConfiguration
 }
 
-func (r *FlagdDeployment) AreObjectsEqual(o1 client.Object, o2 client.Object) bool {
-	oldDeployment, ok := o1.(*appsv1.Deployment)
-	if !ok {
-		return false
-	}
-
-	newDeployment, ok := o2.(*appsv1.Deployment)
-	if !ok {
-		return false
-	}
-
-	return reflect.DeepEqual(oldDeployment.Spec, newDeployment.Spec)
-}
-
 func (r *FlagdDeployment) GetResource(ctx context.Context, flagd *api.Flagd) (client.Object, error) {
 	labels := map[string]string{
 		"app":                          flagd.Name,
@@ -50,12 +39,18 @@ func (r *FlagdDeployment) GetResource(ctx context.Context, flagd *api.Flagd) (cl
 	if len(r.FlagdConfig.Labels) > 0 {
 		maps.Copy(labels, r.FlagdConfig.Labels)
 	}
+	// the Deployment and its pods are cached by the operator
+	labels[cachelabel.Label] = cachelabel.Value
 	// No "built-in" annotations to merge at this time. If adding them follow the same pattern as labels.
 	annotations := map[string]string{}
 	if len(r.FlagdConfig.Annotations) > 0 {
 		maps.Copy(annotations, r.FlagdConfig.Annotations)
 	}
 	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        flagd.Name,
 			Namespace:   flagd.Namespace,
@@ -66,6 +61,7 @@ func (r *FlagdDeployment) GetResource(ctx context.Context, flagd *api.Flagd) (cl
 				Kind:       flagd.Kind,
 				Name:       flagd.Name,
 				UID:        flagd.UID,
+				Controller: ptr.To(true),
 			}},
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -87,35 +83,36 @@ func (r *FlagdDeployment) GetResource(ctx context.Context, flagd *api.Flagd) (cl
 		},
 	}
 
-	featureFlagSource := &api.FeatureFlagSource{}
 	imagePullSecrets := make([]corev1.LocalObjectReference, len(r.FlagdConfig.ImagePullSecrets))
 	for i, secret := range r.FlagdConfig.ImagePullSecrets {
 		imagePullSecrets[i] = corev1.LocalObjectReference{Name: secret}
 	}
 
-	if err := r.Client.Get(ctx, client.ObjectKey{
-		Namespace: flagd.Namespace,
-		Name:      flagd.Spec.FeatureFlagSource,
-	}, featureFlagSource); err != nil {
-		return nil, fmt.Errorf("could not look up feature flag source for flagd: %w", err)
+	featureFlagSourceSpec, err := r.featureFlagSourceSpec(ctx, flagd)
+	if err != nil {
+		return nil, err
 	}
 
-	err := r.FlagdInjector.InjectFlagd(ctx, &deployment.ObjectMeta, &deployment.Spec.Template.Spec, &featureFlagSource.Spec)
+	err = r.FlagdInjector.InjectFlagd(ctx, &deployment.ObjectMeta, &deployment.Spec.Template.Spec, featureFlagSourceSpec)
 	if err != nil {
 		return nil, fmt.Errorf("could not inject flagd container into deployment: %w", err)
 	}
 
+	if err := r.FlagdInjector.EnsureDependencies(ctx, &deployment.ObjectMeta, &deployment.Spec.Template.Spec); err != nil {
+		return nil, fmt.Errorf("could not create the dependencies of the flagd container: %w", err)
+	}
+
 	if len(deployment.Spec.Template.Spec.Containers) == 0 {
 		return nil, errors.New("no flagd container has been injected into deployment")
 	}
 
 	// override settings for the injected container for flagd standalone deployment mode
-	deployment.Spec.Template.Spec.ImagePullSecrets = imagePullSecrets
-	deployment.Spec.Template.Spec.Containers[0].Image = fmt.Sprintf("%s:%s", r.FlagdConfig.Image, r.FlagdConfig.Tag)
+	deployment.Spec.Template.Spec.ImagePullSecrets = append(imagePullSecrets, deployment.Spec.Template.Spec.ImagePullSecrets...)
+	deployment.Spec.Template.Spec.Containers[0].Image = featureFlagSourceSpec.ImageReference(r.FlagdConfig.Image, r.FlagdConfig.Tag)
 
 	ofrepPort := int32(r.FlagdConfig.OFREPPort)
-	if featureFlagSource.Spec.OFREPPort != 0 {
-		ofrepPort = featureFlagSource.Spec.OFREPPort
+	if featureFlagSourceSpec.OFREPPort != 0 {
+		ofrepPort = featureFlagSourceSpec.OFREPPort
 	}
 
 	deployment.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
@@ -139,3 +136,37 @@ func (r *FlagdDeployment) GetResource(ctx context.Context, flagd *api.Flagd) (cl
 
 	return deployment, nil
 }
+
+// featureFlagSourceSpec merges the FeatureFlagSources referenced by the flagd instance in their order, a
+// FeatureFlagSource of another namespace has to be granted by a ReferenceGrant
+func (r *FlagdDeployment) featureFlagSourceSpec(ctx context.Context, flagd *api.Flagd) (*api.FeatureFlagSourceSpec, error) {
+	references := flagd.Spec.FeatureFlagSourceReferences()
+	if len(references) == 0 {
+		return nil, errors.New("no feature flag source is referenced by flagd")
+	}
+
+	var featureFlagSourceSpec *api.FeatureFlagSourceSpec
+	for _, reference := range references {
+		ns, name := utils.ParseAnnotation(reference, flagd.Namespace)
+		if err := referencegrant.Check(ctx, r.Client, referencegrant.Reference{
+			FromKind:      referencegrant.KindFlagd,
+			FromNamespace: flagd.Namespace,
+			ToKind:        referencegrant.KindFeatureFlagSource,
+			ToNamespace:   ns,
+			ToName:        name,
+		}); err != nil {
+			return nil, err
+		}
+
+		featureFlagSource := &api.FeatureFlagSource{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, featureFlagSource); err != nil {
+			return nil, fmt.Errorf("could not look up feature flag source %s/%s for flagd: %w", ns, name, err)
+		}
+		if featureFlagSourceSpec == nil {
+			featureFlagSourceSpec = featureFlagSource.Spec.DeepCopy()
+			continue
+		}
+		featureFlagSourceSpec.Merge(&featureFlagSource.Spec)
+	}
+	return featureFlagSourceSpec, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
 	commonfake "github.com/open-feature/open-feature-operator/internal/common/flagdinjector/fake"
 	resources "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/common"
 	"github.com/stretchr/testify/require"
@@ -59,6 +60,11 @@ func TestFlagdDeployment_getFlagdDeployment(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	fakeFlagdInjector := commonfake.NewMockFlagdContainerInjector(ctrl)
+	fakeFlagdInjector.EXPECT().
+		EnsureDependencies(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
 	fakeFlagdInjector.EXPECT().
 		InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Times(1).
@@ -119,6 +125,79 @@ func TestFlagdDeployment_getFlagdDeployment(t *testing.T) {
 	}, deploymentResult.Spec.Template.Spec.Containers[0].Ports)
 }
 
+func TestFlagdDeployment_getFlagdDeployment_ImageOverride(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	flagdObj := &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flagd",
+			Namespace: "my-namespace",
+		},
+		Spec: api.FlagdSpec{
+			FeatureFlagSource: "my-flag-source",
+		},
+	}
+
+	flagSource := &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flag-source",
+			Namespace: "my-namespace",
+		},
+		Spec: api.FeatureFlagSourceSpec{
+			Tag:              "v0.12.0",
+			ImagePullSecrets: []v1.LocalObjectReference{{Name: "mirror"}},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(flagSource, flagdObj).Build()
+
+	ctrl := gomock.NewController(t)
+
+	fakeFlagdInjector := commonfake.NewMockFlagdContainerInjector(ctrl)
+	fakeFlagdInjector.EXPECT().
+		EnsureDependencies(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
+	fakeFlagdInjector.EXPECT().
+		InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(
+			ctx context.Context,
+			objectMeta *metav1.ObjectMeta,
+			podSpec *v1.PodSpec,
+			flagSourceConfig *api.FeatureFlagSourceSpec,
+		) error {
+			// simulate the injection of a container and the pull secrets into the podspec
+			podSpec.Containers = []v1.Container{
+				{
+					Name: "flagd",
+				},
+			}
+			podSpec.ImagePullSecrets = flagSourceConfig.ImagePullSecrets
+			return nil
+		})
+
+	config := testFlagdConfig
+	config.ImagePullSecrets = []string{"operator-registry"}
+
+	r := &FlagdDeployment{
+		Client:        fakeClient,
+		Log:           controllerruntime.Log.WithName("test"),
+		FlagdInjector: fakeFlagdInjector,
+		FlagdConfig:   config,
+	}
+
+	res, err := r.GetResource(context.Background(), flagdObj)
+	require.Nil(t, err)
+
+	deploymentResult := res.(*appsv1.Deployment)
+
+	require.Equal(t, "flagd:v0.12.0", deploymentResult.Spec.Template.Spec.Containers[0].Image)
+	require.Equal(t, []v1.LocalObjectReference{{Name: "operator-registry"}, {Name: "mirror"}}, deploymentResult.Spec.Template.Spec.ImagePullSecrets)
+}
+
 func TestFlagdDeployment_getFlagdDeployment_ErrorInInjector(t *testing.T) {
 	err := api.AddToScheme(scheme.Scheme)
 	require.Nil(t, err)
@@ -189,6 +268,11 @@ func TestFlagdDeployment_getFlagdDeployment_ContainerNotInjected(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	fakeFlagdInjector := commonfake.NewMockFlagdContainerInjector(ctrl)
+	fakeFlagdInjector.EXPECT().
+		EnsureDependencies(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
+
 	fakeFlagdInjector.EXPECT().
 		InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Times(1).
@@ -240,84 +324,143 @@ func TestFlagdDeployment_getFlagdDeployment_FlagSourceNotFound(t *testing.T) {
 	require.Nil(t, deploymentResult)
 }
 
-func Test_areDeploymentsEqual(t *testing.T) {
-	type args struct {
-		old client.Object
-		new client.Object
+func TestFlagdDeployment_getFlagdDeployment_MultipleFlagSources(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	flagdObj := &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flagd",
+			Namespace: "my-namespace",
+		},
+		Spec: api.FlagdSpec{
+			FeatureFlagSource:  "my-flag-source",
+			FeatureFlagSources: []string{"shared/team-flag-source"},
+		},
 	}
-	tests := []struct {
-		name string
-		args args
-		want bool
-	}{
-		{
-			name: "has changed",
-			args: args{
-				old: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{
-						Replicas: intPtr(1),
-					},
-				},
-				new: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{
-						Replicas: intPtr(2),
-					},
-				},
-			},
-			want: false,
+
+	flagSource := &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flag-source",
+			Namespace: "my-namespace",
 		},
-		{
-			name: "has not changed",
-			args: args{
-				old: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{
-						Replicas: intPtr(1),
-					},
-				},
-				new: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{
-						Replicas: intPtr(1),
-					},
-				},
-			},
-			want: true,
+		Spec: api.FeatureFlagSourceSpec{
+			Tag:       "v0.12.0",
+			OFREPPort: 8020,
+			Sources:   []api.Source{{Source: "my-namespace/my-flags", Provider: "kubernetes"}},
 		},
-		{
-			name: "old is not a deployment",
-			args: args{
-				old: &v1.ConfigMap{},
-				new: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{
-						Replicas: intPtr(1),
-					},
-				},
-			},
-			want: false,
+	}
+
+	teamFlagSource := &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "team-flag-source",
+			Namespace: "shared",
 		},
-		{
-			name: "new is not a deployment",
-			args: args{
-				old: &appsv1.Deployment{
-					Spec: appsv1.DeploymentSpec{
-						Replicas: intPtr(1),
-					},
-				},
-				new: &v1.ConfigMap{},
-			},
-			want: false,
+		Spec: api.FeatureFlagSourceSpec{
+			Tag:     "v0.13.0",
+			Sources: []api.Source{{Source: "shared/team-flags", Provider: "kubernetes"}},
+		},
+	}
+
+	grant := &api.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "flagd",
+			Namespace: "shared",
+		},
+		Spec: api.ReferenceGrantSpec{
+			From: []api.ReferenceGrantFrom{{Group: api.GroupVersion.Group, Kind: "Flagd", Namespace: "my-namespace"}},
+			To:   []api.ReferenceGrantTo{{Kind: "FeatureFlagSource"}},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
 
-			d := &FlagdDeployment{}
-			got := d.AreObjectsEqual(tt.args.old, tt.args.new)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(flagSource, teamFlagSource, grant, flagdObj).Build()
+
+	ctrl := gomock.NewController(t)
+
+	fakeFlagdInjector := commonfake.NewMockFlagdContainerInjector(ctrl)
+	fakeFlagdInjector.EXPECT().
+		EnsureDependencies(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(nil)
 
-			require.Equal(t, tt.want, got)
+	fakeFlagdInjector.EXPECT().
+		InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(
+			ctx context.Context,
+			objectMeta *metav1.ObjectMeta,
+			podSpec *v1.PodSpec,
+			flagSourceConfig *api.FeatureFlagSourceSpec,
+		) error {
+			// the sources are merged in the order of the references
+			require.Equal(t, []api.Source{
+				{Source: "my-namespace/my-flags", Provider: "kubernetes"},
+				{Source: "shared/team-flags", Provider: "kubernetes"},
+			}, flagSourceConfig.Sources)
+			podSpec.Containers = []v1.Container{
+				{
+					Name: "flagd",
+				},
+			}
+			return nil
 		})
+
+	r := &FlagdDeployment{
+		Client:        fakeClient,
+		Log:           controllerruntime.Log.WithName("test"),
+		FlagdInjector: fakeFlagdInjector,
+		FlagdConfig:   testFlagdConfig,
 	}
+
+	res, err := r.GetResource(context.Background(), flagdObj)
+	require.Nil(t, err)
+
+	deploymentResult := res.(*appsv1.Deployment)
+
+	require.Equal(t, "flagd:v0.13.0", deploymentResult.Spec.Template.Spec.Containers[0].Image)
+	require.Equal(t, int32(8020), deploymentResult.Spec.Template.Spec.Containers[0].Ports[2].ContainerPort)
+
+	// the FeatureFlagSources of the Flagd resource are not modified by the merge
+	require.Nil(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(flagSource), flagSource))
+	require.Len(t, flagSource.Spec.Sources, 1)
 }
 
-func intPtr(i int32) *int32 {
-	return &i
+func TestFlagdDeployment_getFlagdDeployment_FlagSourceNotGranted(t *testing.T) {
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	flagdObj := &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flagd",
+			Namespace: "my-namespace",
+		},
+		Spec: api.FlagdSpec{
+			FeatureFlagSources: []string{"shared/team-flag-source"},
+		},
+	}
+
+	teamFlagSource := &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "team-flag-source",
+			Namespace: "shared",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(teamFlagSource, flagdObj).Build()
+
+	ctrl := gomock.NewController(t)
+
+	fakeFlagdInjector := commonfake.NewMockFlagdContainerInjector(ctrl)
+
+	r := &FlagdDeployment{
+		Client:        fakeClient,
+		Log:           controllerruntime.Log.WithName("test"),
+		FlagdInjector: fakeFlagdInjector,
+		FlagdConfig:   testFlagdConfig,
+	}
+
+	deploymentResult, err := r.GetResource(context.Background(), flagdObj)
+
+	require.ErrorIs(t, err, common.ErrReferenceNotGranted)
+	require.Nil(t, deploymentResult)
 }
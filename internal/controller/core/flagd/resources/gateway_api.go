@@ -2,12 +2,12 @@ package resources
 
 import (
 	"context"
-	"reflect"
 
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
 	"github.com/open-feature/open-feature-operator/internal/controller/core/flagd/common"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayApiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -16,22 +16,12 @@ type FlagdGatewayApiHttpRoute struct {
 	FlagdConfig resources.FlagdConfiguration
 }
 
-func (r FlagdGatewayApiHttpRoute) AreObjectsEqual(o1 client.Object, o2 client.Object) bool {
-	oldGateway, ok := o1.(*gatewayApiv1.HTTPRoute)
-	if !ok {
-		return false
-	}
-
-	newGateway, ok := o2.(*gatewayApiv1.HTTPRoute)
-	if !ok {
-		return false
-	}
-
-	return reflect.DeepEqual(oldGateway.Spec, newGateway.Spec)
-}
-
 func (r FlagdGatewayApiHttpRoute) GetResource(_ context.Context, flagd *api.Flagd) (client.Object, error) {
 	return &gatewayApiv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HTTPRoute",
+			APIVersion: gatewayApiv1.GroupVersion.String(),
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      flagd.Name,
 			Namespace: flagd.Namespace,
@@ -47,6 +37,7 @@ func (r FlagdGatewayApiHttpRoute) GetResource(_ context.Context, flagd *api.Flag
 				Kind:       flagd.Kind,
 				Name:       flagd.Name,
 				UID:        flagd.UID,
+				Controller: ptr.To(true),
 			}},
 		},
 		Spec: gatewayApiv1.HTTPRouteSpec{
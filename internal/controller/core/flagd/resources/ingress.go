@@ -2,13 +2,13 @@ package resources
 
 import (
 	"context"
-	"reflect"
 
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
 	resources "github.com/open-feature/open-feature-operator/internal/controller/core/flagd/common"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -16,22 +16,12 @@ type FlagdIngress struct {
 	FlagdConfig resources.FlagdConfiguration
 }
 
-func (r FlagdIngress) AreObjectsEqual(o1 client.Object, o2 client.Object) bool {
-	oldIngress, ok := o1.(*networkingv1.Ingress)
-	if !ok {
-		return false
-	}
-
-	newIngress, ok := o2.(*networkingv1.Ingress)
-	if !ok {
-		return false
-	}
-
-	return reflect.DeepEqual(oldIngress.Spec, newIngress.Spec) && reflect.DeepEqual(oldIngress.Annotations, newIngress.Annotations)
-}
-
 func (r FlagdIngress) GetResource(_ context.Context, flagd *api.Flagd) (client.Object, error) {
 	return &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      flagd.Name,
 			Namespace: flagd.Namespace,
@@ -47,6 +37,7 @@ func (r FlagdIngress) GetResource(_ context.Context, flagd *api.Flagd) (client.O
 				Kind:       flagd.Kind,
 				Name:       flagd.Name,
 				UID:        flagd.UID,
+				Controller: ptr.To(true),
 			}},
 		},
 		Spec: networkingv1.IngressSpec{
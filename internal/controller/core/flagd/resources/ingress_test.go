@@ -8,10 +8,8 @@ import (
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
 	"github.com/stretchr/testify/require"
-	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestFlagdIngress_getIngress(t *testing.T) {
@@ -170,104 +168,6 @@ func strPtr(s string) *string {
 	return &s
 }
 
-func Test_areIngressesEqual(t *testing.T) {
-	type args struct {
-		old client.Object
-		new client.Object
-	}
-	tests := []struct {
-		name string
-		args args
-		want bool
-	}{
-		{
-			name: "has spec changes",
-			args: args{
-				old: &networkingv1.Ingress{
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("nginx"),
-					},
-				},
-				new: &networkingv1.Ingress{
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("kong"),
-					},
-				},
-			},
-			want: false,
-		},
-		{
-			name: "has annotation changes",
-			args: args{
-				old: &networkingv1.Ingress{
-					ObjectMeta: metav1.ObjectMeta{
-						Annotations: map[string]string{
-							"foo": "bar",
-						},
-					},
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("nginx"),
-					},
-				},
-				new: &networkingv1.Ingress{
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("nginx"),
-					},
-				},
-			},
-			want: false,
-		},
-		{
-			name: "has not changed",
-			args: args{
-				old: &networkingv1.Ingress{
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("nginx"),
-					},
-				},
-				new: &networkingv1.Ingress{
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("nginx"),
-					},
-				},
-			},
-			want: true,
-		},
-		{
-			name: "old is not a service",
-			args: args{
-				old: &v1.ConfigMap{},
-				new: &networkingv1.Ingress{
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("nginx"),
-					},
-				},
-			},
-			want: false,
-		},
-		{
-			name: "new is not a service",
-			args: args{
-				old: &networkingv1.Ingress{
-					Spec: networkingv1.IngressSpec{
-						IngressClassName: strPtr("nginx"),
-					},
-				},
-				new: &v1.ConfigMap{},
-			},
-			want: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			i := &FlagdIngress{}
-			got := i.AreObjectsEqual(tt.args.old, tt.args.new)
-
-			require.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func Test_getFlagdPath(t *testing.T) {
 	type args struct {
 		i api.IngressSpec
@@ -8,6 +8,6 @@ import (
 )
 
 type IFlagdResource interface {
+	// GetResource returns the desired resource with its type meta, the set fields are applied with server-side apply
 	GetResource(ctx context.Context, flagd *v1beta1.Flagd) (client.Object, error)
-	AreObjectsEqual(o1 client.Object, o2 client.Object) bool
 }
@@ -36,20 +36,6 @@ func (m *MockIFlagdResource) EXPECT() *MockIFlagdResourceMockRecorder {
 	return m.recorder
 }
 
-// AreObjectsEqual mocks base method.
-func (m *MockIFlagdResource) AreObjectsEqual(o1, o2 client.Object) bool {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AreObjectsEqual", o1, o2)
-	ret0, _ := ret[0].(bool)
-	return ret0
-}
-
-// AreObjectsEqual indicates an expected call of AreObjectsEqual.
-func (mr *MockIFlagdResourceMockRecorder) AreObjectsEqual(o1, o2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AreObjectsEqual", reflect.TypeOf((*MockIFlagdResource)(nil).AreObjectsEqual), o1, o2)
-}
-
 // GetResource mocks base method.
 func (m *MockIFlagdResource) GetResource(ctx context.Context, flagd *v1beta1.Flagd) (client.Object, error) {
 	m.ctrl.T.Helper()
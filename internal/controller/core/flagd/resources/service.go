@@ -2,7 +2,6 @@ package resources
 
 import (
 	"context"
-	"reflect"
 
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	"github.com/open-feature/open-feature-operator/internal/common"
@@ -18,22 +17,12 @@ type FlagdService struct {
 	FlagdConfig resources.FlagdConfiguration
 }
 
-func (r FlagdService) AreObjectsEqual(o1 client.Object, o2 client.Object) bool {
-	oldService, ok := o1.(*v1.Service)
-	if !ok {
-		return false
-	}
-
-	newService, ok := o2.(*v1.Service)
-	if !ok {
-		return false
-	}
-
-	return reflect.DeepEqual(oldService.Spec, newService.Spec)
-}
-
 func (r FlagdService) GetResource(_ context.Context, flagd *api.Flagd) (client.Object, error) {
 	return &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      flagd.Name,
 			Namespace: flagd.Namespace,
@@ -49,6 +38,7 @@ func (r FlagdService) GetResource(_ context.Context, flagd *api.Flagd) (client.O
 				Kind:       flagd.Kind,
 				Name:       flagd.Name,
 				UID:        flagd.UID,
+				Controller: ptr.To(true),
 			}},
 		},
 		Spec: v1.ServiceSpec{
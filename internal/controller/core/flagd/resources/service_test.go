@@ -10,7 +10,6 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestFlagdService_getService(t *testing.T) {
@@ -31,6 +30,8 @@ func TestFlagdService_getService(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, svc)
 	require.IsType(t, &v1.Service{}, svc)
+	// the Service is owned by the Flagd, so that its deletion is reconciled
+	require.True(t, *svc.GetOwnerReferences()[0].Controller)
 
 	expectedPorts := map[string]struct {
 		appProtocol string
@@ -53,81 +54,3 @@ func TestFlagdService_getService(t *testing.T) {
 		require.Equal(t, intstr.FromInt(int(expected.port)), port.TargetPort)
 	}
 }
-
-func Test_areServicesEqual(t *testing.T) {
-	type args struct {
-		old client.Object
-		new client.Object
-	}
-	tests := []struct {
-		name string
-		args args
-		want bool
-	}{
-		{
-			name: "has changed",
-			args: args{
-				old: &v1.Service{
-					Spec: v1.ServiceSpec{
-						Type: v1.ServiceTypeNodePort,
-					},
-				},
-				new: &v1.Service{
-					Spec: v1.ServiceSpec{
-						Type: v1.ServiceTypeClusterIP,
-					},
-				},
-			},
-			want: false,
-		},
-		{
-			name: "has not changed",
-			args: args{
-				old: &v1.Service{
-					Spec: v1.ServiceSpec{
-						Type: v1.ServiceTypeClusterIP,
-					},
-				},
-				new: &v1.Service{
-					Spec: v1.ServiceSpec{
-						Type: v1.ServiceTypeClusterIP,
-					},
-				},
-			},
-			want: true,
-		},
-		{
-			name: "old is not a service",
-			args: args{
-				old: &v1.ConfigMap{},
-				new: &v1.Service{
-					Spec: v1.ServiceSpec{
-						Type: v1.ServiceTypeClusterIP,
-					},
-				},
-			},
-			want: false,
-		},
-		{
-			name: "new is not a service",
-			args: args{
-				old: &v1.Service{
-					Spec: v1.ServiceSpec{
-						Type: v1.ServiceTypeClusterIP,
-					},
-				},
-				new: &v1.ConfigMap{},
-			},
-			want: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-			s := &FlagdService{}
-			got := s.AreObjectsEqual(tt.args.old, tt.args.new)
-
-			require.Equal(t, tt.want, got)
-		})
-	}
-}
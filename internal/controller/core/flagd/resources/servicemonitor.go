@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"context"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/monitoring"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type FlagdServiceMonitor struct{}
+
+func (r FlagdServiceMonitor) GetResource(_ context.Context, flagd *api.Flagd) (client.Object, error) {
+	return monitoring.Monitor{
+		GVK:       monitoring.ServiceMonitorGVK,
+		Name:      flagd.Name,
+		Namespace: flagd.Namespace,
+		Owner: metav1.OwnerReference{
+			APIVersion: flagd.APIVersion,
+			Kind:       flagd.Kind,
+			Name:       flagd.Name,
+			UID:        flagd.UID,
+			Controller: ptr.To(true),
+		},
+		Spec: flagd.Spec.Monitoring,
+		Selector: map[string]string{
+			"app":                         flagd.Name,
+			common.ManagedByAnnotationKey: common.ManagedByAnnotationValue,
+		},
+		Port: "metrics",
+	}.ToUnstructured(), nil
+}
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common/monitoring"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestFlagdServiceMonitor_GetResource(t *testing.T) {
+	r := FlagdServiceMonitor{}
+
+	obj, err := r.GetResource(context.TODO(), &api.Flagd{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-flagd",
+			Namespace: "my-namespace",
+		},
+		Spec: api.FlagdSpec{
+			Monitoring: api.MonitoringSpec{
+				Enabled:  true,
+				Labels:   map[string]string{"release": "prometheus"},
+				Interval: "30s",
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	monitor, ok := obj.(*unstructured.Unstructured)
+	require.True(t, ok)
+	require.Equal(t, monitoring.ServiceMonitorGVK, monitor.GroupVersionKind())
+	require.Equal(t, map[string]string{
+		"app.kubernetes.io/managed-by": "open-feature-operator",
+		"release":                      "prometheus",
+	}, monitor.GetLabels())
+	require.Equal(t, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				"app":                          "my-flagd",
+				"app.kubernetes.io/managed-by": "open-feature-operator",
+			},
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{"my-namespace"},
+		},
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"port":     "metrics",
+				"path":     "/metrics",
+				"interval": "30s",
+			},
+		},
+	}, monitor.Object["spec"])
+}
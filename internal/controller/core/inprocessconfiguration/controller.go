@@ -0,0 +1,76 @@
+package inprocessconfiguration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta2"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
+	"github.com/open-feature/open-feature-operator/internal/common/utils"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// InProcessConfigurationReconciler deploys the flagd-proxy for the InProcessConfigurations referencing it as their
+// sync server, the pods using them are admitted once it is ready
+type InProcessConfigurationReconciler struct {
+	client.Client
+	// ReqLogger contains the Logger of this controller
+	Log logr.Logger
+
+	// FlagdProxy is the handler for the flagd-proxy deployment
+	FlagdProxy        *flagdproxy.FlagdProxyHandler
+	FlagdProxyBackoff *utils.ExponentialBackoff
+
+	// Recorder emits events on the InProcessConfiguration
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=core.openfeature.dev,resources=inprocessconfigurations,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *InProcessConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "InProcessConfigurationReconciler.Reconcile", attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	ipConfig := &api.InProcessConfiguration{}
+	if err := r.Client.Get(ctx, req.NamespacedName, ipConfig); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		r.Log.Error(err, fmt.Sprintf("Failed to get the %s", req.NamespacedName))
+		return ctrl.Result{RequeueAfter: common.ReconcileErrorInterval}, err
+	}
+
+	syncServer := ipConfig.Spec.SyncServer
+	if syncServer == nil || syncServer.Kind != api.SyncServerKindFlagdProxy {
+		return ctrl.Result{}, nil
+	}
+
+	r.Log.Info(fmt.Sprintf("inprocessconfiguration %s uses flagd-proxy, checking deployment", req.NamespacedName))
+	if err := r.FlagdProxy.HandleFlagdProxy(ctx); err != nil {
+		r.Log.Error(err, "error handling the flagd-proxy deployment")
+		common.RecordEvent(r.Recorder, ipConfig, corev1.EventTypeWarning, common.EventReasonFlagdProxyFailed, "could not deploy flagd-proxy: %s", err.Error())
+		return ctrl.Result{RequeueAfter: r.FlagdProxyBackoff.Next()}, err
+	}
+	r.FlagdProxyBackoff.Reset()
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *InProcessConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.InProcessConfiguration{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
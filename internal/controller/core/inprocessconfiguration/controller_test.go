@@ -0,0 +1,88 @@
+package inprocessconfiguration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta2"
+	applyfake "github.com/open-feature/open-feature-operator/internal/common/apply/fake"
+	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	commontypes "github.com/open-feature/open-feature-operator/internal/common/types"
+	"github.com/open-feature/open-feature-operator/internal/common/utils"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestInProcessConfigurationReconciler_Reconcile(t *testing.T) {
+	const testNamespace = "test-namespace"
+
+	tests := []struct {
+		name                 string
+		syncServer           *api.SyncServerReference
+		flagdProxyDeployment bool
+	}{
+		{
+			name:                 "flagd-proxy is deployed",
+			syncServer:           &api.SyncServerReference{Kind: api.SyncServerKindFlagdProxy, FeatureFlag: "my-flags"},
+			flagdProxyDeployment: true,
+		},
+		{
+			name:       "flagd-proxy is not deployed for a Flagd",
+			syncServer: &api.SyncServerReference{Kind: api.SyncServerKindFlagd, Name: "central-flagd"},
+		},
+		{
+			name: "flagd-proxy is not deployed without a sync server",
+		},
+	}
+
+	err := api.AddToScheme(scheme.Scheme)
+	require.Nil(t, err)
+
+	ctx := context.TODO()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ipConfig := &api.InProcessConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: testNamespace},
+				Spec:       api.InProcessConfigurationSpec{SyncServer: tt.syncServer},
+			}
+			operator := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "open-feature-operator-controller-manager", Namespace: testNamespace},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(applyfake.Interceptor()).WithObjects(operator, ipConfig).Build()
+
+			kpConfig := flagdproxy.NewFlagdProxyConfiguration(commontypes.EnvConfig{
+				FlagdProxyImage: "ghcr.io/open-feature/flagd-proxy",
+				FlagdProxyTag:   "v0.9.4",
+			}, nil, nil, nil)
+			kpConfig.Namespace = testNamespace
+
+			r := &InProcessConfigurationReconciler{
+				Client:            fakeClient,
+				Log:               ctrl.Log.WithName("inprocessconfiguration-controller"),
+				FlagdProxy:        flagdproxy.NewFlagdProxyHandler(kpConfig, fakeClient, ctrl.Log.WithName("flagd-proxy")),
+				FlagdProxyBackoff: &utils.ExponentialBackoff{StartDelay: time.Duration(0), MaxDelay: time.Duration(0)},
+				Recorder:          record.NewFakeRecorder(10),
+			}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "test-config"}})
+			require.Nil(t, err)
+
+			deployment := &appsv1.Deployment{}
+			err = fakeClient.Get(ctx, types.NamespacedName{Name: flagdproxy.FlagdProxyDeploymentName, Namespace: testNamespace}, deployment)
+			if tt.flagdProxyDeployment {
+				require.Nil(t, err)
+			} else {
+				require.True(t, errors.IsNotFound(err))
+			}
+		})
+	}
+}
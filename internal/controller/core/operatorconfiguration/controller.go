@@ -0,0 +1,205 @@
+package operatorconfiguration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/go-logr/logr"
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	reasonApplied = "Applied"
+	reasonInvalid = "InvalidSpec"
+)
+
+// OperatorConfigurationReconciler applies the OperatorConfiguration of the operator namespace to the components of the
+// operator, without an OperatorConfiguration the environment variables and flags of the operator are in effect
+type OperatorConfigurationReconciler struct {
+	client.Client
+	// ReqLogger contains the Logger of this controller
+	Log logr.Logger
+	// Recorder emits events on the OperatorConfiguration
+	Recorder record.EventRecorder
+	// Name of the applied OperatorConfiguration
+	Name string
+	// Namespace of the operator, OperatorConfigurations of other namespaces are ignored
+	Namespace string
+	// Defaults is the configuration from the environment variables and flags of the operator
+	Defaults operatorconfig.Configuration
+	// Appliers receive the effective configuration whenever it changes
+	Appliers []operatorconfig.Applier
+	// FlagdProxy rolls out the configuration to the flagd-proxy, if it is deployed
+	FlagdProxy *flagdproxy.FlagdProxyHandler
+
+	mu      sync.Mutex
+	applied *operatorconfig.Configuration
+}
+
+//+kubebuilder:rbac:groups=core.openfeature.dev,resources=operatorconfigurations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core.openfeature.dev,resources=operatorconfigurations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile merges the OperatorConfiguration with the defaults of the operator and applies the result to the
+// components of this replica. It runs on every replica, as every replica serves the webhooks, and does not write to
+// the cluster. An invalid OperatorConfiguration leaves the previous configuration in effect
+func (r *OperatorConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "OperatorConfigurationReconciler.Reconcile", attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	config, effective, mergeErr, err := r.merge(ctx, req.NamespacedName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if mergeErr != nil {
+		r.Log.Error(mergeErr, fmt.Sprintf("OperatorConfiguration '%s' is invalid, keeping the previous configuration", req.NamespacedName))
+		return ctrl.Result{}, nil
+	}
+	if config == nil {
+		r.Log.Info(fmt.Sprintf("OperatorConfiguration '%s' not found, applying the defaults of the operator", req.NamespacedName))
+	}
+	r.apply(effective)
+	return ctrl.Result{}, nil
+}
+
+// ReconcileRollout writes the effects of the OperatorConfiguration to the cluster, it only runs on the leader. The
+// configuration is applied to the components of the replica first, so the flagd-proxy is rolled out with it, and the
+// result is reported in the status of the OperatorConfiguration
+func (r *OperatorConfigurationReconciler) ReconcileRollout(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "OperatorConfigurationReconciler.ReconcileRollout", attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	config, effective, mergeErr, err := r.merge(ctx, req.NamespacedName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if mergeErr != nil {
+		status := config.Status.DeepCopy()
+		status.ObservedGeneration = config.Generation
+		if r.setApplied(status, metav1.ConditionFalse, reasonInvalid, mergeErr.Error()) {
+			common.RecordEvent(r.Recorder, config, corev1.EventTypeWarning, common.EventReasonConfigurationInvalid, "could not apply the configuration: %s", mergeErr.Error())
+		}
+		return ctrl.Result{}, r.updateStatus(ctx, config, status)
+	}
+
+	r.apply(effective)
+	if r.FlagdProxy != nil {
+		if err = r.FlagdProxy.UpdateFlagdProxy(ctx); err != nil {
+			r.Log.Error(err, "Failed to roll out the configuration to the flagd-proxy")
+			return ctrl.Result{}, err
+		}
+	}
+	if config == nil {
+		return ctrl.Result{}, nil
+	}
+
+	status := config.Status.DeepCopy()
+	status.ObservedGeneration = config.Generation
+	status.Effective = effective.Spec()
+	if r.setApplied(status, metav1.ConditionTrue, reasonApplied, "the configuration is applied") {
+		common.RecordEvent(r.Recorder, config, corev1.EventTypeNormal, common.EventReasonConfigurationApplied, "applied the configuration of generation %d", config.Generation)
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, config, status)
+}
+
+// merge returns the OperatorConfiguration merged with the defaults of the operator, config is nil and the defaults
+// are returned if it does not exist. mergeErr reports an invalid OperatorConfiguration
+func (r *OperatorConfigurationReconciler) merge(ctx context.Context, key client.ObjectKey) (config *api.OperatorConfiguration, effective operatorconfig.Configuration, mergeErr error, err error) {
+	config = &api.OperatorConfiguration{}
+	if err = r.Client.Get(ctx, key, config); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, r.Defaults, nil, nil
+		}
+		r.Log.Error(err, fmt.Sprintf("Failed to get the OperatorConfiguration '%s'", key))
+		return nil, operatorconfig.Configuration{}, nil, err
+	}
+	effective, mergeErr = operatorconfig.Merge(r.Defaults, config.Spec)
+	return config, effective, mergeErr, nil
+}
+
+// apply hands the configuration to the appliers if it differs from the configuration in effect, it is called by both
+// controllers and only applies a configuration once
+func (r *OperatorConfigurationReconciler) apply(cfg operatorconfig.Configuration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.Defaults
+	if r.applied != nil {
+		current = *r.applied
+	}
+	if reflect.DeepEqual(current, cfg) {
+		return
+	}
+	for _, applier := range r.Appliers {
+		applier.ApplyConfiguration(cfg.DeepCopy())
+	}
+	r.applied = &cfg
+	r.Log.Info("Applied the operator configuration")
+}
+
+// setApplied sets the Applied condition and reports whether its status or message changed
+func (r *OperatorConfigurationReconciler) setApplied(status *api.OperatorConfigurationStatus, conditionStatus metav1.ConditionStatus, reason, message string) bool {
+	previous := meta.FindStatusCondition(status.Conditions, api.OperatorConfigurationConditionApplied)
+	changed := previous == nil || previous.Status != conditionStatus || previous.Message != message ||
+		previous.ObservedGeneration != status.ObservedGeneration
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               api.OperatorConfigurationConditionApplied,
+		Status:             conditionStatus,
+		ObservedGeneration: status.ObservedGeneration,
+		Reason:             reason,
+		Message:            message,
+	})
+	return changed
+}
+
+func (r *OperatorConfigurationReconciler) updateStatus(ctx context.Context, config *api.OperatorConfiguration, status *api.OperatorConfigurationStatus) error {
+	if reflect.DeepEqual(&config.Status, status) {
+		return nil
+	}
+	config.Status = *status
+	if err := r.Client.Status().Update(ctx, config); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to update the status of the OperatorConfiguration '%s/%s'", config.Namespace, config.Name))
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controllers with the Manager. Every replica serves the webhooks and therefore applies
+// the configuration, while the flagd-proxy and the status are only written by the leader
+func (r *OperatorConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	operatorConfiguration := builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.Namespace && obj.GetName() == r.Name
+	}))
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&api.OperatorConfiguration{}, operatorConfiguration).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("operatorconfiguration-rollout").
+		For(&api.OperatorConfiguration{}, operatorConfiguration).
+		Complete(reconcile.Func(r.ReconcileRollout))
+}
@@ -0,0 +1,149 @@
+package operatorconfiguration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/kelseyhightower/envconfig"
+	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
+	"github.com/open-feature/open-feature-operator/internal/common/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	configurationName = "open-feature-operator-configuration"
+	operatorNamespace = "open-feature-operator-system"
+)
+
+type fakeApplier struct {
+	applied []operatorconfig.Configuration
+}
+
+func (f *fakeApplier) ApplyConfiguration(cfg operatorconfig.Configuration) {
+	f.applied = append(f.applied, cfg)
+}
+
+func TestOperatorConfigurationReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, api.AddToScheme(scheme))
+
+	env := types.EnvConfig{}
+	require.Nil(t, envconfig.Process("", &env))
+	defaults := operatorconfig.Configuration{Env: env}
+
+	config := &api.OperatorConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: configurationName, Namespace: operatorNamespace, Generation: 1},
+		Spec: api.OperatorConfigurationSpec{
+			Sidecar: &api.OperatorSidecarConfiguration{Tag: ptr.To("v1.0.0")},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(config).WithStatusSubresource(config).Build()
+	applier := &fakeApplier{}
+	recorder := record.NewFakeRecorder(10)
+	r := &OperatorConfigurationReconciler{
+		Client:    c,
+		Log:       testr.New(t),
+		Recorder:  recorder,
+		Name:      configurationName,
+		Namespace: operatorNamespace,
+		Defaults:  defaults,
+		Appliers:  []operatorconfig.Applier{applier},
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)}
+
+	// every replica applies the configuration without writing to the cluster
+	_, err := r.Reconcile(ctx, req)
+	require.Nil(t, err)
+	require.Len(t, applier.applied, 1)
+	require.Equal(t, "v1.0.0", applier.applied[0].Env.SidecarTag)
+	require.Empty(t, recorder.Events)
+	require.Nil(t, c.Get(ctx, req.NamespacedName, config))
+	require.Nil(t, config.Status.Effective)
+
+	// the leader reports the applied configuration
+	_, err = r.ReconcileRollout(ctx, req)
+	require.Nil(t, err)
+	require.Len(t, applier.applied, 1)
+	require.Equal(t, "Normal ConfigurationApplied applied the configuration of generation 1", <-recorder.Events)
+
+	require.Nil(t, c.Get(ctx, req.NamespacedName, config))
+	require.Equal(t, int64(1), config.Status.ObservedGeneration)
+	require.Equal(t, "v1.0.0", *config.Status.Effective.Sidecar.Tag)
+	require.Equal(t, env.SidecarImage, *config.Status.Effective.Sidecar.Image)
+	require.True(t, meta.IsStatusConditionTrue(config.Status.Conditions, api.OperatorConfigurationConditionApplied))
+
+	// an unchanged configuration is not applied again
+	_, err = r.Reconcile(ctx, req)
+	require.Nil(t, err)
+	require.Len(t, applier.applied, 1)
+
+	// an invalid configuration is reported and the previous configuration stays in effect
+	config.Generation = 2
+	config.Spec.Sidecar.ManagementPort = ptr.To(int32(env.SidecarPort))
+	require.Nil(t, c.Update(ctx, config))
+
+	_, err = r.Reconcile(ctx, req)
+	require.Nil(t, err)
+	_, err = r.ReconcileRollout(ctx, req)
+	require.Nil(t, err)
+	require.Len(t, applier.applied, 1)
+	require.Contains(t, <-recorder.Events, "Warning ConfigurationInvalid could not apply the configuration: sidecar port and management port must differ")
+
+	require.Nil(t, c.Get(ctx, req.NamespacedName, config))
+	require.Equal(t, int64(2), config.Status.ObservedGeneration)
+	require.Equal(t, "v1.0.0", *config.Status.Effective.Sidecar.Tag)
+	condition := meta.FindStatusCondition(config.Status.Conditions, api.OperatorConfigurationConditionApplied)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, reasonInvalid, condition.Reason)
+
+	// the defaults of the operator are applied once the configuration is deleted
+	require.Nil(t, c.Delete(ctx, config))
+
+	_, err = r.Reconcile(ctx, req)
+	require.Nil(t, err)
+	require.Len(t, applier.applied, 2)
+	require.Equal(t, defaults, applier.applied[1])
+}
+
+func TestOperatorConfigurationReconciler_ReconcileRollout_AppliesFirst(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.Nil(t, api.AddToScheme(scheme))
+
+	env := types.EnvConfig{}
+	require.Nil(t, envconfig.Process("", &env))
+
+	config := &api.OperatorConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: configurationName, Namespace: operatorNamespace, Generation: 1},
+		Spec: api.OperatorConfigurationSpec{
+			FlagdProxy: &api.OperatorFlagdProxyConfiguration{Tag: ptr.To("v0.9.0")},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(config).WithStatusSubresource(config).Build()
+	applier := &fakeApplier{}
+	r := &OperatorConfigurationReconciler{
+		Client:    c,
+		Log:       testr.New(t),
+		Recorder:  record.NewFakeRecorder(10),
+		Name:      configurationName,
+		Namespace: operatorNamespace,
+		Defaults:  operatorconfig.Configuration{Env: env},
+		Appliers:  []operatorconfig.Applier{applier},
+	}
+
+	// the leader may reconcile before the controller of all replicas, the rollout uses the new configuration
+	_, err := r.ReconcileRollout(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+	require.Nil(t, err)
+	require.Len(t, applier.applied, 1)
+	require.Equal(t, "v0.9.0", applier.applied[0].Env.FlagdProxyTag)
+}
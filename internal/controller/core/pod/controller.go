@@ -0,0 +1,165 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/flagdinjector"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// PodReconciler creates the objects the flagd containers injected by the mutating webhook depend on, the webhook
+// only computes the patch of the pod
+type PodReconciler struct {
+	client.Client
+	// ReqLogger contains the Logger of this controller
+	Log logr.Logger
+
+	FlagdInjector flagdinjector.IFlagdContainerInjector
+
+	// Recorder emits events on the pods
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods/status,verbs=get;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile ensures the permissions and ConfigMaps of the flagd container of the pod exist and reports the outcome in
+// the FlagdDependenciesReady condition of the pod. Errors are retried with the backoff of the controller.
+func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "PodReconciler.Reconcile", attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	pod := &corev1.Pod{}
+	if err = r.Client.Get(ctx, req.NamespacedName, pod); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		r.Log.Error(err, fmt.Sprintf("Failed to get the pod %s", req.NamespacedName))
+		return ctrl.Result{}, err
+	}
+	if !isInjected(pod) || !isRunning(pod) {
+		return ctrl.Result{}, nil
+	}
+
+	depErr := r.FlagdInjector.EnsureDependencies(ctx, &pod.ObjectMeta, &pod.Spec)
+	if depErr != nil {
+		r.Log.Error(depErr, fmt.Sprintf("Failed to create the flagd dependencies of pod %s", req.NamespacedName))
+	}
+	if err = r.setCondition(ctx, pod, depErr); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to update the conditions of pod %s", req.NamespacedName))
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, depErr
+}
+
+// setCondition patches the FlagdDependenciesReady condition of the pod, an event is emitted whenever its status changes
+func (r *PodReconciler) setCondition(ctx context.Context, pod *corev1.Pod, depErr error) error {
+	condition := corev1.PodCondition{
+		Type:   common.PodConditionFlagdDependenciesReady,
+		Status: corev1.ConditionTrue,
+		Reason: common.EventReasonDependenciesReady,
+	}
+	if depErr != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = common.EventReasonDependenciesFailed
+		condition.Message = depErr.Error()
+	}
+
+	existing := findCondition(pod)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil
+	}
+	if existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	} else {
+		condition.LastTransitionTime = metav1.Now()
+		if depErr != nil {
+			common.RecordEvent(r.Recorder, pod, corev1.EventTypeWarning, common.EventReasonDependenciesFailed, "could not create the dependencies of the flagd container: %s", depErr.Error())
+		} else {
+			common.RecordEvent(r.Recorder, pod, corev1.EventTypeNormal, common.EventReasonDependenciesReady, "created the dependencies of the flagd container")
+		}
+	}
+
+	patch := client.StrategicMergeFrom(pod.DeepCopy())
+	if existing != nil {
+		*existing = condition
+	} else {
+		pod.Status.Conditions = append(pod.Status.Conditions, condition)
+	}
+	return r.Client.Status().Patch(ctx, pod, patch)
+}
+
+func findCondition(pod *corev1.Pod) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == common.PodConditionFlagdDependenciesReady {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// isInjected reports whether the mutating webhook injects a flagd container into the pod, or mounts the flag
+// configuration of a FeatureFlag into its containers for offline in-process evaluation
+func isInjected(obj client.Object) bool {
+	annotations := obj.GetAnnotations()
+	if annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation)] != "true" {
+		return false
+	}
+	if _, ok := annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation)]; ok {
+		return true
+	}
+	if _, ok := annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation)]; !ok {
+		return false
+	}
+	pod, ok := obj.(*corev1.Pod)
+	return ok && len(flagdinjector.FileSyncFeatureFlags(&pod.Spec)) > 0
+}
+
+func isRunning(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil &&
+		pod.Status.Phase != corev1.PodSucceeded &&
+		pod.Status.Phase != corev1.PodFailed
+}
+
+func isReady(obj client.Object) bool {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	condition := findCondition(pod)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pod").
+		For(&corev1.Pod{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(isInjected),
+			// failed attempts are retried with backoff, updates are only relevant until the dependencies are ready
+			predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool { return !isReady(e.ObjectNew) },
+				DeleteFunc: func(event.DeleteEvent) bool { return false },
+			},
+		)).
+		Complete(r)
+}
@@ -0,0 +1,159 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/golang/mock/gomock"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	commonfake "github.com/open-feature/open-feature-operator/internal/common/flagdinjector/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPodReconciler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		reconciled    bool
+		ensureErr     error
+		wantErr       bool
+		wantCondition corev1.ConditionStatus
+		wantEvent     string
+	}{
+		{
+			name:          "dependencies created",
+			pod:           injectedPod(corev1.PodRunning),
+			reconciled:    true,
+			wantCondition: corev1.ConditionTrue,
+			wantEvent:     common.EventReasonDependenciesReady,
+		},
+		{
+			name:          "dependencies failed",
+			pod:           injectedPod(corev1.PodPending),
+			reconciled:    true,
+			ensureErr:     errors.New("service account not found"),
+			wantErr:       true,
+			wantCondition: corev1.ConditionFalse,
+			wantEvent:     common.EventReasonDependenciesFailed,
+		},
+		{
+			name: "pod without flagd",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "app"},
+			},
+		},
+		{
+			name: "completed pod",
+			pod:  injectedPod(corev1.PodSucceeded),
+		},
+		{
+			name:          "in-process pod with offline flags",
+			pod:           inProcessPod("/etc/flagd/app_offline-flags"),
+			reconciled:    true,
+			wantCondition: corev1.ConditionTrue,
+			wantEvent:     common.EventReasonDependenciesReady,
+		},
+		{
+			name: "in-process pod without offline flags",
+			pod:  inProcessPod("/var/run/flagd"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tt.pod).
+				WithStatusSubresource(&corev1.Pod{}).
+				Build()
+
+			mockCtrl := gomock.NewController(t)
+			injector := commonfake.NewMockFlagdContainerInjector(mockCtrl)
+			calls := 0
+			if tt.reconciled {
+				// reconciled twice to verify the events
+				calls = 2
+			}
+			injector.EXPECT().
+				EnsureDependencies(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(calls).
+				Return(tt.ensureErr)
+
+			recorder := record.NewFakeRecorder(10)
+			r := &PodReconciler{
+				Client:        c,
+				Log:           testr.New(t),
+				FlagdInjector: injector,
+				Recorder:      recorder,
+			}
+
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tt.pod)})
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+
+			pod := &corev1.Pod{}
+			require.Nil(t, c.Get(ctx, client.ObjectKeyFromObject(tt.pod), pod))
+			condition := findCondition(pod)
+			if tt.wantCondition == "" {
+				require.Nil(t, condition)
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantCondition, condition.Status)
+			require.Len(t, recorder.Events, 1)
+			require.Contains(t, <-recorder.Events, tt.wantEvent)
+
+			// the event is only emitted on transitions
+			_, _ = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tt.pod)})
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func injectedPod(phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: "app",
+			Annotations: map[string]string{
+				"openfeature.dev/enabled":           "true",
+				"openfeature.dev/featureflagsource": "my-source",
+			},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func inProcessPod(mountPath string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: "app",
+			Annotations: map[string]string{
+				"openfeature.dev/enabled":                "true",
+				"openfeature.dev/inprocessconfiguration": "my-config",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:         "app",
+				VolumeMounts: []corev1.VolumeMount{{Name: "flags", MountPath: mountPath}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
@@ -0,0 +1,165 @@
+package kubernetessync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/kubernetessync"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// readyInterval is the interval in which the readiness of the cache is checked
+const readyInterval = 5 * time.Second
+
+// KubernetesSyncReconciler garbage-collects the Roles and RoleBindings granting service accounts read access to
+// FeatureFlags once no pod using the service account syncs FeatureFlags of the namespace anymore
+type KubernetesSyncReconciler struct {
+	client.Client
+	// ReqLogger contains the Logger of this controller
+	Log logr.Logger
+	// Now returns the current time, defaults to time.Now
+	Now func() time.Time
+	// Ready reports whether all pods using kubernetes sync are cached, permissions are only revoked once they are
+	Ready func() bool
+}
+
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+// Reconcile removes the RoleBinding and its Role if it is not used by any pod
+func (r *KubernetesSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "KubernetesSyncReconciler.Reconcile", attribute.String("namespace", req.Namespace), attribute.String("name", req.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	if r.Ready != nil && !r.Ready() {
+		return ctrl.Result{RequeueAfter: readyInterval}, nil
+	}
+
+	rb := &rbacv1.RoleBinding{}
+	if err = r.Client.Get(ctx, req.NamespacedName, rb); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		r.Log.Error(err, fmt.Sprintf("Failed to get the %s", req.NamespacedName))
+		return ctrl.Result{RequeueAfter: common.ReconcileErrorInterval}, err
+	}
+	serviceAccount, ok := kubernetessync.ServiceAccountOf(rb)
+	if !common.IsManagedByOFO(rb) || !ok {
+		return ctrl.Result{}, nil
+	}
+
+	inUse, err := r.isInUse(ctx, serviceAccount, rb.Namespace)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to list the pods of service account %s", serviceAccount))
+		return ctrl.Result{RequeueAfter: common.ReconcileErrorInterval}, err
+	}
+	if inUse {
+		return ctrl.Result{}, nil
+	}
+
+	// the permissions are granted while the pod starts or before the pods of a Flagd are created, recently granted
+	// permissions are kept
+	if grantedAt, ok := kubernetessync.GrantedAt(rb); ok {
+		if remaining := grantedAt.Add(kubernetessync.GracePeriod).Sub(r.now()); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	r.Log.Info(fmt.Sprintf("Revoking kubernetes sync permissions of unused service account %s in namespace %s", serviceAccount, rb.Namespace))
+	if err = kubernetessync.Revoke(ctx, r.Client, serviceAccount, rb.Namespace); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Failed to revoke the kubernetes sync permissions of %s", serviceAccount))
+		return ctrl.Result{RequeueAfter: common.ReconcileErrorInterval}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// isInUse reports whether a running pod of the service account syncs FeatureFlags of the given namespace
+func (r *KubernetesSyncReconciler) isInUse(ctx context.Context, serviceAccount client.ObjectKey, namespace string) (bool, error) {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.InNamespace(serviceAccount.Namespace)); err != nil {
+		return false, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isRunning(pod) || podServiceAccount(pod) != serviceAccount {
+			continue
+		}
+		for _, ff := range kubernetessync.PodFeatureFlags(pod) {
+			if ff.Namespace == namespace {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// podRoleBindings maps a pod to the RoleBindings of its service account in the namespaces of its FeatureFlags
+func (r *KubernetesSyncReconciler) podRoleBindings(_ context.Context, obj client.Object) []reconcile.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	name := kubernetessync.Name(podServiceAccount(pod))
+	requests := []reconcile.Request{}
+	seen := map[string]bool{}
+	for _, ff := range kubernetessync.PodFeatureFlags(pod) {
+		if seen[ff.Namespace] {
+			continue
+		}
+		seen[ff.Namespace] = true
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: ff.Namespace, Name: name}})
+	}
+	return requests
+}
+
+func (r *KubernetesSyncReconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func podServiceAccount(pod *corev1.Pod) client.ObjectKey {
+	name := pod.Spec.ServiceAccountName
+	if name == "" {
+		name = "default"
+	}
+	return client.ObjectKey{Namespace: pod.Namespace, Name: name}
+}
+
+func isRunning(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil &&
+		pod.Status.Phase != corev1.PodSucceeded &&
+		pod.Status.Phase != corev1.PodFailed
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KubernetesSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("kubernetessync").
+		For(&rbacv1.RoleBinding{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			_, ok := obj.GetLabels()[kubernetessync.Label]
+			return ok
+		}))).
+		// pods are only relevant once they stop using their service account
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podRoleBindings), builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(event.CreateEvent) bool { return false },
+		})).
+		Complete(r)
+}
@@ -0,0 +1,157 @@
+package kubernetessync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/kubernetessync"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var serviceAccount = client.ObjectKey{Namespace: "app", Name: "my-sa"}
+
+func TestKubernetesSyncReconciler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name        string
+		pods        []client.Object
+		grantedAgo  time.Duration
+		wantDeleted bool
+		wantRequeue bool
+	}{
+		{
+			name:        "service account used by a running pod",
+			pods:        []client.Object{syncingPod("running", "my-sa", corev1.PodRunning)},
+			grantedAgo:  time.Hour,
+			wantDeleted: false,
+		},
+		{
+			name:        "no pod uses the service account",
+			grantedAgo:  time.Hour,
+			wantDeleted: true,
+		},
+		{
+			name: "only completed pods or pods of other service accounts",
+			pods: []client.Object{
+				syncingPod("completed", "my-sa", corev1.PodSucceeded),
+				syncingPod("other", "other-sa", corev1.PodRunning),
+			},
+			grantedAgo:  time.Hour,
+			wantDeleted: true,
+		},
+		{
+			name:        "recently granted for a pod being admitted",
+			grantedAgo:  time.Minute,
+			wantDeleted: false,
+			wantRequeue: true,
+		},
+	}
+
+	now := time.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tt.pods...).Build()
+			ctx := context.Background()
+			require.Nil(t, kubernetessync.Grant(ctx, c, serviceAccount, []client.ObjectKey{{Namespace: "flags", Name: "shared"}}))
+
+			key := client.ObjectKey{Namespace: "flags", Name: kubernetessync.Name(serviceAccount)}
+			rb := &rbacv1.RoleBinding{}
+			require.Nil(t, c.Get(ctx, key, rb))
+			rb.Annotations[kubernetessync.GrantedAtAnnotation] = now.Add(-tt.grantedAgo).UTC().Format(time.RFC3339)
+			require.Nil(t, c.Update(ctx, rb))
+
+			r := &KubernetesSyncReconciler{
+				Client: c,
+				Log:    testr.New(t),
+				Now:    func() time.Time { return now },
+			}
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			require.Nil(t, err)
+			require.Equal(t, tt.wantRequeue, result.RequeueAfter > 0)
+
+			err = c.Get(ctx, key, &rbacv1.RoleBinding{})
+			require.Equal(t, tt.wantDeleted, errors.IsNotFound(err))
+			err = c.Get(ctx, key, &rbacv1.Role{})
+			require.Equal(t, tt.wantDeleted, errors.IsNotFound(err))
+		})
+	}
+}
+
+func TestKubernetesSyncReconciler_Reconcile_NotManaged(t *testing.T) {
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "flags",
+			Name:      kubernetessync.Name(serviceAccount),
+			Annotations: map[string]string{
+				kubernetessync.ServiceAccountAnnotation: serviceAccount.String(),
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(rb).Build()
+	r := &KubernetesSyncReconciler{Client: c, Log: testr.New(t)}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rb)})
+	require.Nil(t, err)
+	require.Nil(t, c.Get(context.Background(), client.ObjectKeyFromObject(rb), &rbacv1.RoleBinding{}))
+}
+
+func TestKubernetesSyncReconciler_Reconcile_NotReady(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	ctx := context.Background()
+	require.Nil(t, kubernetessync.Grant(ctx, c, serviceAccount, []client.ObjectKey{{Namespace: "flags", Name: "shared"}}))
+	key := client.ObjectKey{Namespace: "flags", Name: kubernetessync.Name(serviceAccount)}
+
+	// the permissions are not revoked before all pods using kubernetes sync are cached
+	r := &KubernetesSyncReconciler{
+		Client: c,
+		Log:    testr.New(t),
+		Now:    func() time.Time { return time.Now().Add(time.Hour) },
+		Ready:  func() bool { return false },
+	}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.Nil(t, err)
+	require.Equal(t, readyInterval, result.RequeueAfter)
+	require.Nil(t, c.Get(ctx, key, &rbacv1.RoleBinding{}))
+}
+
+func TestKubernetesSyncReconciler_podRoleBindings(t *testing.T) {
+	r := &KubernetesSyncReconciler{}
+	requests := r.podRoleBindings(context.Background(), syncingPod("running", "my-sa", corev1.PodRunning))
+	require.Equal(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "flags", Name: kubernetessync.Name(serviceAccount)}},
+	}, requests)
+
+	require.Empty(t, r.podRoleBindings(context.Background(), &corev1.Pod{}))
+}
+
+func syncingPod(name, serviceAccountName string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "app",
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: serviceAccountName,
+			InitContainers: []corev1.Container{{
+				Name: "flagd",
+				Args: []string{
+					"start",
+					common.SourceConfigParam,
+					`[{"uri":"flags/shared","provider":"kubernetes"},{"uri":"flags/other","provider":"kubernetes"}]`,
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
@@ -2,38 +2,24 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	"github.com/open-feature/open-feature-operator/api/core/v1beta2"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/metrics"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
 	"github.com/open-feature/open-feature-operator/internal/common/types"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func OpenFeatureEnabledAnnotationIndex(o client.Object) []string {
-	pod, ok := o.(*corev1.Pod)
-	if !ok {
-		return []string{"false"}
-	}
-	if pod.ObjectMeta.Annotations == nil {
-		return []string{
-			"false",
-		}
-	}
-	val, ok := pod.ObjectMeta.Annotations[fmt.Sprintf("openfeature.dev/%s", common.AllowKubernetesSyncAnnotation)]
-	if ok && val == "true" {
-		return []string{
-			"true",
-		}
-	}
-	return []string{
-		"false",
-	}
-}
-
 func parseList(s string) []string {
 	out := []string{}
 	ss := strings.Split(s, ",")
@@ -46,6 +32,14 @@ func parseList(s string) []string {
 	return out
 }
 
+// referenceErrorCode denies the admission if a cross-namespace reference is not granted
+func referenceErrorCode(err error) int32 {
+	if errors.Is(err, common.ErrReferenceNotGranted) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
 func containsK8sProvider(sources []api.Source) bool {
 	for _, source := range sources {
 		if source.Provider.IsKubernetes() {
@@ -55,6 +49,19 @@ func containsK8sProvider(sources []api.Source) bool {
 	return false
 }
 
+// setFlagdMonitorLabel selects the pod for the PodMonitor of the given FeatureFlagSource, like the other
+// settings the monitoring settings of the last FeatureFlagSource defining them are used
+func setFlagdMonitorLabel(pod *corev1.Pod, fs *api.FeatureFlagSource) {
+	if !fs.Spec.Monitoring.Enabled {
+		delete(pod.Labels, common.FlagdMonitorLabel)
+		return
+	}
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[common.FlagdMonitorLabel] = string(fs.UID)
+}
+
 func checkOFEnabled(annotations map[string]string) bool {
 	val, ok := annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation)]
 	return ok && val == "true"
@@ -85,17 +92,19 @@ func NewFeatureFlagSourceSpec(env types.EnvConfig) *api.FeatureFlagSourceSpec {
 	}
 }
 
-func NewInProcessConfigurationSpec(env types.EnvConfig) *api.InProcessConfigurationSpec {
-	return &api.InProcessConfigurationSpec{
-		Port:                  int32(env.InProcessPort),
-		SocketPath:            env.InProcessSocketPath,
-		Host:                  env.InProcessHost,
-		TLS:                   env.InProcessTLS,
-		OfflineFlagSourcePath: env.InProcessOfflineFlagSourcePath,
-		Selector:              env.InProcessSelector,
-		Cache:                 env.InProcessCache,
-		CacheMaxSize:          int(env.InProcessCacheMaxSize),
-		EnvVarPrefix:          env.InProcessEnvVarPrefix,
+// NewInProcessConfigurationSpec returns the bottom layer of the in-process configuration of pods, the
+// InProcessConfigurations referenced by a pod are merged on top of it
+func NewInProcessConfigurationSpec(env types.EnvConfig) *v1beta2.InProcessConfigurationSpec {
+	return &v1beta2.InProcessConfigurationSpec{
+		Port:                  ptr.To(int32(env.InProcessPort)),
+		SocketPath:            ptr.To(env.InProcessSocketPath),
+		Host:                  ptr.To(env.InProcessHost),
+		TLS:                   ptr.To(env.InProcessTLS),
+		OfflineFlagSourcePath: ptr.To(env.InProcessOfflineFlagSourcePath),
+		Selector:              ptr.To(env.InProcessSelector),
+		Cache:                 ptr.To(env.InProcessCache),
+		CacheMaxSize:          ptr.To(int32(env.InProcessCacheMaxSize)),
+		EnvVarPrefix:          ptr.To(env.InProcessEnvVarPrefix),
 	}
 }
 
@@ -109,17 +118,34 @@ func shouldUseInProcess(annotations map[string]string) bool {
 	return ok
 }
 
+// admissionMode returns the evaluation mode requested by the annotations, the sidecar takes precedence
+func admissionMode(annotations map[string]string) string {
+	if shouldUseSidecar(annotations) {
+		return metrics.ModeRPC
+	}
+	if shouldUseInProcess(annotations) {
+		return metrics.ModeInProcess
+	}
+	return metrics.ModeNone
+}
+
 func (m *PodMutator) getFeatureFlagSource(ctx context.Context, namespace string, name string) (*api.FeatureFlagSource, error) {
+	ctx, span := tracing.Start(ctx, "get FeatureFlagSource", attribute.String("namespace", namespace), attribute.String("name", name))
 	fcConfig := &api.FeatureFlagSource{}
-	if err := m.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, fcConfig); err != nil {
+	err := m.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, fcConfig)
+	tracing.End(span, err)
+	if err != nil {
 		return nil, err
 	}
 	return fcConfig, nil
 }
 
-func (m *PodMutator) getInProcessConfiguration(ctx context.Context, namespace string, name string) (*api.InProcessConfiguration, error) {
-	fcConfig := &api.InProcessConfiguration{}
-	if err := m.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, fcConfig); err != nil {
+func (m *PodMutator) getInProcessConfiguration(ctx context.Context, namespace string, name string) (*v1beta2.InProcessConfiguration, error) {
+	ctx, span := tracing.Start(ctx, "get InProcessConfiguration", attribute.String("namespace", namespace), attribute.String("name", name))
+	fcConfig := &v1beta2.InProcessConfiguration{}
+	err := m.Client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, fcConfig)
+	tracing.End(span, err)
+	if err != nil {
 		return nil, err
 	}
 	return fcConfig, nil
@@ -7,48 +7,15 @@ import (
 
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	apiv1beta2 "github.com/open-feature/open-feature-operator/api/core/v1beta2"
 	"github.com/open-feature/open-feature-operator/internal/common"
 	"github.com/open-feature/open-feature-operator/internal/common/types"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"k8s.io/utils/ptr"
 )
 
-func TestOpenFeatureEnabledAnnotationIndex(t *testing.T) {
-
-	tests := []struct {
-		name string
-		o    client.Object
-		want []string
-	}{
-		{
-			name: "not a pod",
-			o:    &corev1.ConfigMap{},
-			want: []string{"false"},
-		}, {
-			name: "no annotations",
-			o:    &corev1.Pod{},
-			want: []string{"false"},
-		}, {
-			name: "annotated wrong",
-			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"test/ann": "nope", "openfeature.dev/allowkubernetessync": "false"}}},
-			want: []string{"false"},
-		}, {
-			name: "annotated with enabled index",
-			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"openfeature.dev/allowkubernetessync": "true"}}},
-			want: []string{"true"},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := OpenFeatureEnabledAnnotationIndex(tt.o); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("OpenFeatureEnabledAnnotationIndex() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestPodMutator_checkOFEnabled(t *testing.T) {
 
 	tests := []struct {
@@ -179,6 +146,32 @@ func Test_NewFeatureFlagSourceSpec(t *testing.T) {
 	require.Equal(t, expected, NewFeatureFlagSourceSpec(env))
 }
 
+func Test_NewInProcessConfigurationSpec(t *testing.T) {
+	env := types.EnvConfig{
+		InProcessPort:         8016,
+		InProcessHost:         "host",
+		InProcessTLS:          false,
+		InProcessCache:        "lru",
+		InProcessCacheMaxSize: 10,
+		InProcessEnvVarPrefix: "pre",
+	}
+
+	// the operator defaults form the bottom layer of the merge, every field is set
+	expected := &apiv1beta2.InProcessConfigurationSpec{
+		Port:                  ptr.To(int32(8016)),
+		SocketPath:            ptr.To(""),
+		Host:                  ptr.To("host"),
+		TLS:                   ptr.To(false),
+		OfflineFlagSourcePath: ptr.To(""),
+		Selector:              ptr.To(""),
+		Cache:                 ptr.To("lru"),
+		CacheMaxSize:          ptr.To(int32(10)),
+		EnvVarPrefix:          ptr.To("pre"),
+	}
+
+	require.Equal(t, expected, NewInProcessConfigurationSpec(env))
+}
+
 func Test_shouldUseSidecar(t *testing.T) {
 	require.True(t, shouldUseSidecar(map[string]string{
 		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation): "value",
@@ -188,3 +181,22 @@ func Test_shouldUseSidecar(t *testing.T) {
 		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation): "value",
 	}))
 }
+
+func Test_setFlagdMonitorLabel(t *testing.T) {
+	pod := &corev1.Pod{}
+	setFlagdMonitorLabel(pod, &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{UID: "first"},
+		Spec: api.FeatureFlagSourceSpec{
+			Monitoring: &api.MonitoringSpec{Enabled: true},
+		},
+	})
+	require.Equal(t, map[string]string{common.FlagdMonitorLabel: "first"}, pod.Labels)
+
+	setFlagdMonitorLabel(pod, &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{UID: "second"},
+		Spec: api.FeatureFlagSourceSpec{
+			Monitoring: &api.MonitoringSpec{Enabled: false},
+		},
+	})
+	require.Empty(t, pod.Labels)
+}
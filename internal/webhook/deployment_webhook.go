@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
+	appsV1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/mutate-apps-v1-deployment,mutating=true,failurePolicy=Ignore,groups=apps,resources=deployments,verbs=create;update,versions=v1,name=mutate-deployment.openfeature.dev,admissionReviewVersions=v1,sideEffects=None
+
+// DeploymentMutator labels the Deployments using FeatureFlagSources, so that they are cached by the operator and
+// restarted on changes of their FeatureFlagSources
+type DeploymentMutator struct {
+	decoder admission.Decoder
+	Log     logr.Logger
+	// Namespaces the operator is restricted to, Deployments of other namespaces are admitted unchanged. All namespaces
+	// are watched if empty.
+	Namespaces []string
+}
+
+// Handle adds the cache label to the Deployments selected by the operator, the label is never removed
+func (m *DeploymentMutator) Handle(_ context.Context, req admission.Request) admission.Response {
+	deployment := &appsV1.Deployment{}
+	if err := m.decoder.Decode(req, deployment); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if len(m.Namespaces) > 0 && !slices.Contains(m.Namespaces, req.Namespace) {
+		return admission.Allowed("namespace is not watched by the operator")
+	}
+
+	if !cachelabel.DeploymentSelected(deployment) {
+		return admission.Allowed("Deployment does not use OpenFeature")
+	}
+	if !cachelabel.Set(deployment) {
+		return admission.Allowed("Deployment is labeled")
+	}
+
+	m.Log.V(2).Info(fmt.Sprintf("labeling deployment %s/%s", req.Namespace, deployment.Name))
+	marshaledDeployment, err := json.Marshal(deployment)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledDeployment)
+}
+
+// InjectDecoder injects the decoder.
+func (m *DeploymentMutator) InjectDecoder(d admission.Decoder) error {
+	m.decoder = d
+	return nil
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsV1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestDeploymentMutator_Handle(t *testing.T) {
+	deployment := func(labels, templateAnnotations map[string]string) []byte {
+		raw, err := json.Marshal(appsV1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-deployment", Namespace: mutatePodNamespace, Labels: labels},
+			Spec: appsV1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: templateAnnotations},
+				},
+			},
+		})
+		require.Nil(t, err)
+		return raw
+	}
+	featureFlagSourceAnnotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation): featureFlagSourceName,
+	}
+
+	tests := []struct {
+		name       string
+		namespaces []string
+		raw        []byte
+		wantCode   int32
+		labeled    bool
+	}{
+		{
+			name:     "deployment without FeatureFlagSource",
+			raw:      deployment(nil, nil),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:    "deployment with FeatureFlagSource",
+			raw:     deployment(nil, featureFlagSourceAnnotations),
+			labeled: true,
+		},
+		{
+			name:    "deployment managed by the operator",
+			raw:     deployment(map[string]string{common.ManagedByAnnotationKey: common.ManagedByAnnotationValue}, nil),
+			labeled: true,
+		},
+		{
+			name:     "labeled deployment",
+			raw:      deployment(map[string]string{cachelabel.Label: cachelabel.Value}, featureFlagSourceAnnotations),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:       "namespace is not watched",
+			namespaces: []string{"other"},
+			raw:        deployment(nil, featureFlagSourceAnnotations),
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:     "wrong request",
+			raw:      []byte{'1'},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &DeploymentMutator{
+				Log:        testr.New(t),
+				Namespaces: tt.namespaces,
+			}
+			require.Nil(t, m.InjectDecoder(admission.NewDecoder(scheme.Scheme)))
+
+			got := m.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UID:       "123",
+					Namespace: mutatePodNamespace,
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: tt.raw},
+				},
+			})
+
+			if tt.wantCode != 0 {
+				require.Equal(t, tt.wantCode, got.Result.Code)
+			}
+			labeled := false
+			for _, patch := range got.Patches {
+				labeled = labeled || patch.Path == "/metadata/labels" || patch.Path == "/metadata/labels/openfeature.dev~1cache"
+			}
+			require.Equal(t, tt.labeled, labeled)
+		})
+	}
+}
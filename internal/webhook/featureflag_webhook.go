@@ -25,6 +25,7 @@ import (
 
 	schema "github.com/open-feature/flagd-schemas/json"
 	"github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	"github.com/open-feature/open-feature-operator/internal/common/metrics"
 	"github.com/xeipuuv/gojsonschema"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -64,6 +65,7 @@ func (v *FeatureFlagCustomValidator) ValidateCreate(ctx context.Context, obj run
 	featureFlagLog.Info("validate create", "name", featureFlag.Name)
 
 	if err := validateFeatureFlagFlags(featureFlag.Spec.FlagSpec.Flags); err != nil {
+		metrics.FeatureFlagValidationFailures.WithLabelValues("create").Inc()
 		return []string{}, err
 	}
 
@@ -81,6 +83,7 @@ func (v *FeatureFlagCustomValidator) ValidateUpdate(ctx context.Context, oldObj
 	featureFlagLog.Info("validate update", "name", featureFlag.Name)
 
 	if err := validateFeatureFlagFlags(featureFlag.Spec.FlagSpec.Flags); err != nil {
+		metrics.FeatureFlagValidationFailures.WithLabelValues("update").Inc()
 		return []string{}, err
 	}
 
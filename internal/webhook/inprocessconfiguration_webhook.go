@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-feature/open-feature-operator/api/core/v1beta2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// InProcessConfigurationCustomDefaulter defaults the fields of InProcessConfigurations which are derived from other
+// fields. Other unset fields are left unset, they are inherited from the InProcessConfigurations referenced before
+// and the defaults of the operator when the configurations of a pod are merged.
+type InProcessConfigurationCustomDefaulter struct{}
+
+// SetupWebhookWithManager registers the defaulting webhook and the conversion webhook of InProcessConfigurations
+func (d *InProcessConfigurationCustomDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1beta2.InProcessConfiguration{}).
+		WithDefaulter(d).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-core-openfeature-dev-v1beta2-inprocessconfiguration,mutating=true,failurePolicy=fail,sideEffects=None,groups=core.openfeature.dev,resources=inprocessconfigurations,verbs=create;update,versions=v1beta2,name=minprocessconfiguration.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomDefaulter = &InProcessConfigurationCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (d *InProcessConfigurationCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	inProcessConfiguration, ok := obj.(*v1beta2.InProcessConfiguration)
+	if !ok {
+		return fmt.Errorf("expected an InProcessConfiguration object but got %T", obj)
+	}
+
+	defaultTLS(&inProcessConfiguration.Spec)
+	return nil
+}
+
+// defaultTLS enables TLS if the spec has a TLS configuration, unless TLS is disabled explicitly
+func defaultTLS(spec *v1beta2.InProcessConfigurationSpec) {
+	if spec.TLSConfig != nil && spec.TLS == nil {
+		spec.TLS = ptr.To(true)
+	}
+}
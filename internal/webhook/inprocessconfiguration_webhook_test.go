@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/open-feature-operator/api/core/v1beta2"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestInProcessConfigurationCustomDefaulter_Default(t *testing.T) {
+	tests := []struct {
+		name string
+		in   v1beta2.InProcessConfigurationSpec
+		want v1beta2.InProcessConfigurationSpec
+	}{
+		{
+			name: "unset fields are left unset",
+			in:   v1beta2.InProcessConfigurationSpec{},
+			want: v1beta2.InProcessConfigurationSpec{},
+		},
+		{
+			name: "TLS configuration enables TLS",
+			in: v1beta2.InProcessConfigurationSpec{
+				TLSConfig: &v1beta2.InProcessTLSConfig{CASecret: "sync-ca"},
+			},
+			want: v1beta2.InProcessConfigurationSpec{
+				TLS:       ptr.To(true),
+				TLSConfig: &v1beta2.InProcessTLSConfig{CASecret: "sync-ca"},
+			},
+		},
+		{
+			name: "TLS disabled explicitly",
+			in: v1beta2.InProcessConfigurationSpec{
+				TLS:       ptr.To(false),
+				TLSConfig: &v1beta2.InProcessTLSConfig{CASecret: "sync-ca"},
+			},
+			want: v1beta2.InProcessConfigurationSpec{
+				TLS:       ptr.To(false),
+				TLSConfig: &v1beta2.InProcessTLSConfig{CASecret: "sync-ca"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &v1beta2.InProcessConfiguration{Spec: tt.in}
+			require.Nil(t, (&InProcessConfigurationCustomDefaulter{}).Default(context.TODO(), obj))
+			require.Equal(t, tt.want, obj.Spec)
+		})
+	}
+
+	err := (&InProcessConfigurationCustomDefaulter{}).Default(context.TODO(), &corev1.Pod{})
+	require.NotNil(t, err)
+}
@@ -6,18 +6,32 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
+	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	"github.com/open-feature/open-feature-operator/api/core/v1beta2"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdinjector"
 	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/kubernetessync"
+	"github.com/open-feature/open-feature-operator/internal/common/metrics"
+	"github.com/open-feature/open-feature-operator/internal/common/operatorconfig"
+	"github.com/open-feature/open-feature-operator/internal/common/referencegrant"
+	"github.com/open-feature/open-feature-operator/internal/common/syncserver"
+	"github.com/open-feature/open-feature-operator/internal/common/tracing"
 	"github.com/open-feature/open-feature-operator/internal/common/types"
 	"github.com/open-feature/open-feature-operator/internal/common/utils"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	"sigs.k8s.io/controller-runtime/pkg/cache"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -26,10 +40,13 @@ import (
 
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:webhook:path=/mutate-v1-pod,mutating=true,failurePolicy=Ignore,groups="",resources=pods,verbs=create;update,versions=v1,name=mutate.openfeature.dev,admissionReviewVersions=v1,sideEffects=NoneOnDryRun
+//+kubebuilder:webhook:path=/mutate-v1-pod,mutating=true,failurePolicy=Ignore,groups="",resources=pods,verbs=create;update,versions=v1,name=mutate.openfeature.dev,admissionReviewVersions=v1,sideEffects=None
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=core.openfeature.dev,resources=inprocessconfigurations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core.openfeature.dev,resources=referencegrants,verbs=get;list;watch
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;update,resourceNames=open-feature-operator-flagd-kubernetes-sync;
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // PodMutator annotates Pods
 type PodMutator struct {
@@ -40,15 +57,62 @@ type PodMutator struct {
 	FlagdProxyConfig *flagdproxy.FlagdProxyConfiguration
 	FlagdInjector    flagdinjector.IFlagdContainerInjector
 	Env              types.EnvConfig
+	Recorder         record.EventRecorder
+	// Namespaces the operator is restricted to, pods of other namespaces are admitted unchanged. All namespaces are
+	// watched if empty.
+	Namespaces []string
+	// Reader reads the pods of the cluster without the cache, which only holds the pods related to OpenFeature.
+	// Defaults to the Client.
+	Reader client.Reader
+
+	// mu guards the configuration against changes of the OperatorConfiguration
+	mu sync.RWMutex
+}
+
+// ApplyConfiguration replaces the defaults of the FeatureFlagSources and InProcessConfigurations of admitted pods
+func (m *PodMutator) ApplyConfiguration(cfg operatorconfig.Configuration) {
+	proxyConfig := flagdproxy.NewFlagdProxyConfiguration(cfg.Env, cfg.ImagePullSecrets, cfg.Labels, cfg.Annotations)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Env = cfg.Env
+	m.FlagdProxyConfig = proxyConfig
+}
+
+// watches reports whether the operator manages the pods of the given namespace
+func (m *PodMutator) watches(namespace string) bool {
+	return len(m.Namespaces) == 0 || slices.Contains(m.Namespaces, namespace)
+}
+
+func (m *PodMutator) env() types.EnvConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.Env
+}
+
+func (m *PodMutator) flagdProxyConfig() *flagdproxy.FlagdProxyConfiguration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.FlagdProxyConfig
 }
 
 // Handle injects the flagd sidecar (if the prerequisites are all met)
 //
 //nolint:gocyclo
-func (m *PodMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+func (m *PodMutator) Handle(ctx context.Context, req admission.Request) (response admission.Response) {
+	start := time.Now()
+	mode := metrics.ModeNone
+	defer func() {
+		metrics.RecordPodAdmission(mode, response, time.Since(start))
+	}()
+	ctx, span := tracing.Start(ctx, "PodMutator.Handle", attribute.String("namespace", req.Namespace), attribute.Bool("dryRun", req.DryRun != nil && *req.DryRun))
+	defer func() {
+		span.SetAttributes(attribute.String("mode", mode), attribute.Bool("allowed", response.Allowed))
+		span.End()
+	}()
 	defer func() {
 		if err := recover(); err != nil {
-			admission.Errored(http.StatusInternalServerError, fmt.Errorf("%v", err))
+			response = admission.Errored(http.StatusInternalServerError, fmt.Errorf("%v", err))
 		}
 	}()
 	pod := &corev1.Pod{}
@@ -64,34 +128,51 @@ func (m *PodMutator) Handle(ctx context.Context, req admission.Request) admissio
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	// the webhook configuration may be shared by operators watching other namespaces
+	if !m.watches(pod.Namespace) {
+		m.Log.V(2).Info(fmt.Sprintf("namespace %s is not watched by the operator", pod.Namespace))
+		return admission.Allowed("namespace is not watched by the operator")
+	}
+
 	annotations := pod.GetAnnotations()
 	// Check enablement
 	if !checkOFEnabled(annotations) {
 		m.Log.V(2).Info(`openfeature.dev/enabled annotation is not set to "true"`)
 		return admission.Allowed("OpenFeature is disabled")
 	}
+	mode = admissionMode(annotations)
 
 	// Check if the pod is static or orphaned
 	if len(pod.GetOwnerReferences()) == 0 {
+		m.recordEvent(req, pod, corev1.EventTypeWarning, common.EventReasonInjectionFailed, "static or orphaned pods cannot be mutated")
 		return admission.Denied("static or orphaned pods cannot be mutated")
 	}
 
-	if shouldUseSidecar(annotations) {
+	switch mode {
+	case metrics.ModeRPC:
 		if code, err := m.handleRPCConfiguration(ctx, req, annotations, pod); err != nil {
+			m.recordEvent(req, pod, corev1.EventTypeWarning, common.EventReasonInjectionFailed, "could not inject flagd sidecar into pod %s: %s", podName(pod), err.Error())
 			if code == http.StatusForbidden {
 				return admission.Denied(err.Error())
 			} else {
 				return admission.Errored(code, err)
 			}
 		}
-	} else if shouldUseInProcess(annotations) { // use in-process evaluation
+		m.recordEvent(req, pod, corev1.EventTypeNormal, common.EventReasonFlagdInjected, "injected flagd sidecar into pod %s", podName(pod))
+	case metrics.ModeInProcess: // use in-process evaluation
 		if code, err := m.handleInProcessConfiguration(ctx, req, annotations, pod); err != nil {
+			m.recordEvent(req, pod, corev1.EventTypeWarning, common.EventReasonInjectionFailed, "could not apply in-process configuration to pod %s: %s", podName(pod), err.Error())
 			return admission.Errored(code, err)
 		}
-	} else {
+		m.recordEvent(req, pod, corev1.EventTypeNormal, common.EventReasonInProcessConfigured, "applied in-process configuration to pod %s", podName(pod))
+	default:
+		m.recordEvent(req, pod, corev1.EventTypeWarning, common.EventReasonInjectionFailed, "pod %s has neither a 'featureflagsource' nor an 'inprocessconfiguration' annotation", podName(pod))
 		return admission.Denied("cannot mutate pods without a 'featureflagsource' or 'inprocessconfiguration' annotation as openfeature.dev/enabled annotation is present with a value true")
 	}
 
+	// the cache of the operator is restricted to the labeled pods
+	cachelabel.Set(pod)
+
 	marshaledPod, err := json.Marshal(pod)
 	if err != nil {
 		return admission.Errored(http.StatusInternalServerError, err)
@@ -105,11 +186,38 @@ func (m *PodMutator) handleInProcessConfiguration(ctx context.Context, req admis
 	if err != nil {
 		return code, err
 	}
+	if code, err := m.resolveSyncServer(ctx, pod, inProcessConfigurationSpec); err != nil {
+		return code, err
+	}
+	if code, err := m.mountOfflineFeatureFlag(ctx, pod, inProcessConfigurationSpec); err != nil {
+		return code, err
+	}
 
 	envVars := inProcessConfigurationSpec.ToEnvVars()
 	for i := 0; i < len(pod.Spec.Containers); i++ {
 		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envVars...)
 	}
+
+	// share the socket directory between the containers of the pod
+	if socketPath := ptr.Deref(inProcessConfigurationSpec.SocketPath, ""); socketPath != "" {
+		if err := flagdinjector.MountSocketVolume(&pod.Spec, socketPath); err != nil {
+			return http.StatusBadRequest, err
+		}
+	}
+
+	// mount the certificates of the sync connection, the paths are set by ToEnvVars
+	if tlsConfig := inProcessConfigurationSpec.TLSConfig; tlsConfig != nil {
+		if tlsConfig.CASecret != "" {
+			if err := flagdinjector.MountSecretVolume(&pod.Spec, common.InProcessCAVolumeName, tlsConfig.CASecret, apicommon.InProcessCAMountPath); err != nil {
+				return http.StatusBadRequest, err
+			}
+		}
+		if tlsConfig.ClientCertSecret != "" {
+			if err := flagdinjector.MountSecretVolume(&pod.Spec, common.InProcessClientCertVolumeName, tlsConfig.ClientCertSecret, apicommon.InProcessClientCertMountPath); err != nil {
+				return http.StatusBadRequest, err
+			}
+		}
+	}
 	return 0, nil
 }
 
@@ -120,17 +228,18 @@ func (m *PodMutator) handleRPCConfiguration(ctx context.Context, req admission.R
 		return code, err
 	}
 
-	// Check for the correct clusterrolebinding for the pod if we use the Kubernetes mode
-	if containsK8sProvider(featureFlagSourceSpec.Sources) {
-		if err := m.FlagdInjector.EnableClusterRoleBinding(ctx, pod.Namespace, pod.Spec.ServiceAccountName); err != nil {
-			return http.StatusForbidden, err
-		}
-	}
-
+	// only the patch is computed during the admission, the permissions and ConfigMaps the flagd container depends on
+	// are created asynchronously by the pod controller
 	if err := m.FlagdInjector.InjectFlagd(ctx, &pod.ObjectMeta, &pod.Spec, featureFlagSourceSpec); err != nil {
-		if errors.Is(err, common.ErrFlagdProxyNotReady) {
+		if errors.Is(err, common.ErrFlagdProxyNotReady) || errors.Is(err, common.ErrFlagdNotReady) ||
+			errors.Is(err, common.ErrReferenceNotGranted) {
 			return http.StatusForbidden, err
 		}
+		if errors.Is(err, common.ErrInvalidSidecarResources) || errors.Is(err, common.ErrInvalidSocketPath) ||
+			errors.Is(err, common.ErrInvalidTelemetryTLS) || errors.Is(err, common.ErrCrossNamespaceFileSource) ||
+			errors.Is(err, common.ErrVolumeConflict) {
+			return http.StatusBadRequest, err
+		}
 		//test
 		m.Log.Error(err, "unable to inject flagd sidecar")
 		return http.StatusInternalServerError, err
@@ -140,6 +249,9 @@ func (m *PodMutator) handleRPCConfiguration(ctx context.Context, req admission.R
 
 // nolint:dupl
 func (m *PodMutator) createFSConfigSpec(ctx context.Context, req admission.Request, annotations map[string]string, pod *corev1.Pod) (*api.FeatureFlagSourceSpec, int32, error) {
+	ctx, span := tracing.Start(ctx, "PodMutator.createFSConfigSpec")
+	defer span.End()
+
 	// Check configuration
 	fscNames := []string{}
 	val, ok := annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation)]
@@ -147,11 +259,22 @@ func (m *PodMutator) createFSConfigSpec(ctx context.Context, req admission.Reque
 		fscNames = parseList(val)
 	}
 
-	featureFlagSourceSpec := NewFeatureFlagSourceSpec(m.Env)
+	featureFlagSourceSpec := NewFeatureFlagSourceSpec(m.env())
 
 	for _, fscName := range fscNames {
 		ns, name := utils.ParseAnnotation(fscName, req.Namespace)
 
+		if err := referencegrant.Check(ctx, m.Client, referencegrant.Reference{
+			FromKind:      referencegrant.KindPod,
+			FromNamespace: pod.Namespace,
+			ToKind:        referencegrant.KindFeatureFlagSource,
+			ToNamespace:   ns,
+			ToName:        name,
+		}); err != nil {
+			m.Log.V(1).Info(fmt.Sprintf("FeatureFlagSource %s cannot be referenced from namespace %s: %s", fscName, pod.Namespace, err.Error()))
+			return nil, referenceErrorCode(err), err
+		}
+
 		fc, err := m.getFeatureFlagSource(ctx, ns, name)
 		if err != nil {
 			m.Log.V(1).Info(fmt.Sprintf("FeatureFlagSource could not be retrieved for %s in namespace %s: %s", fscName, req.Namespace, err.Error()))
@@ -161,13 +284,19 @@ func (m *PodMutator) createFSConfigSpec(ctx context.Context, req admission.Reque
 			return nil, http.StatusInternalServerError, err
 		}
 		featureFlagSourceSpec.Merge(&fc.Spec)
+		if fc.Spec.Monitoring != nil {
+			setFlagdMonitorLabel(pod, fc)
+		}
 	}
 
 	return featureFlagSourceSpec, 0, nil
 }
 
 // nolint:dupl
-func (m *PodMutator) createFSInProcessConfigSpec(ctx context.Context, req admission.Request, annotations map[string]string, pod *corev1.Pod) (*api.InProcessConfigurationSpec, int32, error) {
+func (m *PodMutator) createFSInProcessConfigSpec(ctx context.Context, req admission.Request, annotations map[string]string, pod *corev1.Pod) (*v1beta2.InProcessConfigurationSpec, int32, error) {
+	ctx, span := tracing.Start(ctx, "PodMutator.createFSInProcessConfigSpec")
+	defer span.End()
+
 	// Check configuration
 	fscNames := []string{}
 	val, ok := annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation)]
@@ -175,11 +304,23 @@ func (m *PodMutator) createFSInProcessConfigSpec(ctx context.Context, req admiss
 		fscNames = parseList(val)
 	}
 
-	featureFlagSourceSpec := NewInProcessConfigurationSpec(m.Env)
-
+	// the InProcessConfigurations are merged before the defaults of the operator, so that a TLS configuration enables
+	// TLS unless one of them sets TLS explicitly
+	inProcessConfigurationSpec := &v1beta2.InProcessConfigurationSpec{}
 	for _, fscName := range fscNames {
 		ns, name := utils.ParseAnnotation(fscName, req.Namespace)
 
+		if err := referencegrant.Check(ctx, m.Client, referencegrant.Reference{
+			FromKind:      referencegrant.KindPod,
+			FromNamespace: pod.Namespace,
+			ToKind:        referencegrant.KindInProcessConfiguration,
+			ToNamespace:   ns,
+			ToName:        name,
+		}); err != nil {
+			m.Log.V(1).Info(fmt.Sprintf("InProcessConfiguration %s cannot be referenced from namespace %s: %s", fscName, pod.Namespace, err.Error()))
+			return nil, referenceErrorCode(err), err
+		}
+
 		fc, err := m.getInProcessConfiguration(ctx, ns, name)
 		if err != nil {
 			m.Log.V(1).Info(fmt.Sprintf("InProcessConfiguration could not be retrieved for %s in namespace %s: %s", fscName, req.Namespace, err.Error()))
@@ -188,46 +329,271 @@ func (m *PodMutator) createFSInProcessConfigSpec(ctx context.Context, req admiss
 			}
 			return nil, http.StatusInternalServerError, err
 		}
-		featureFlagSourceSpec.Merge(&fc.Spec)
+		inProcessConfigurationSpec.Merge(&fc.Spec)
 	}
+	defaultTLS(inProcessConfigurationSpec)
 
+	featureFlagSourceSpec := NewInProcessConfigurationSpec(m.env())
+	featureFlagSourceSpec.Merge(inProcessConfigurationSpec)
 	return featureFlagSourceSpec, 0, nil
 }
 
-// BackfillPermissions recovers the state of the flagd-kubernetes-sync role binding in the event of upgrade
+// resolveSyncServer replaces the host and the port of the in-process configuration with the Service of the referenced
+// sync server, the selector is set to the FeatureFlag synced from the flagd-proxy. The pod is not admitted as long as
+// the sync server is not ready.
+func (m *PodMutator) resolveSyncServer(ctx context.Context, pod *corev1.Pod, spec *v1beta2.InProcessConfigurationSpec) (int32, error) {
+	ref := spec.SyncServer
+	if ref == nil {
+		return 0, nil
+	}
+	ctx, span := tracing.Start(ctx, "PodMutator.resolveSyncServer", attribute.String("kind", ref.Kind))
+	defer span.End()
+
+	var toKind, reference string
+	switch ref.Kind {
+	case v1beta2.SyncServerKindFlagd:
+		toKind, reference = referencegrant.KindFlagd, ref.Name
+	case v1beta2.SyncServerKindFlagdProxy:
+		toKind, reference = referencegrant.KindFeatureFlag, ref.FeatureFlag
+	default:
+		return http.StatusBadRequest, fmt.Errorf("unknown sync server kind %q", ref.Kind)
+	}
+	if reference == "" {
+		return http.StatusBadRequest, fmt.Errorf("the %s referenced as sync server is not named", toKind)
+	}
+
+	ns, name := utils.ParseAnnotation(reference, pod.Namespace)
+	if err := referencegrant.Check(ctx, m.Client, referencegrant.Reference{
+		FromKind:      referencegrant.KindPod,
+		FromNamespace: pod.Namespace,
+		ToKind:        toKind,
+		ToNamespace:   ns,
+		ToName:        name,
+	}); err != nil {
+		return referenceErrorCode(err), err
+	}
+
+	proxyConfig := m.flagdProxyConfig()
+	var address syncserver.Address
+	var err error
+	if ref.Kind == v1beta2.SyncServerKindFlagd {
+		address, err = syncserver.Flagd(ctx, m.Client, proxyConfig.ClusterDomain, client.ObjectKey{Namespace: ns, Name: name})
+	} else {
+		address, err = syncserver.FlagdProxy(ctx, m.Client, proxyConfig)
+		spec.Selector = ptr.To(fmt.Sprintf("core.openfeature.dev/%s/%s", ns, name))
+	}
+	if err != nil {
+		m.Log.V(1).Info(fmt.Sprintf("sync server %s %s could not be resolved: %s", ref.Kind, reference, err.Error()))
+		switch {
+		case errors.Is(err, common.ErrFlagdNotReady) || errors.Is(err, common.ErrFlagdProxyNotReady):
+			return http.StatusForbidden, err
+		case k8serrors.IsNotFound(err):
+			return http.StatusNotFound, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	spec.Host = ptr.To(address.Host)
+	spec.Port = ptr.To(address.Port)
+	return 0, nil
+}
+
+// mountOfflineFeatureFlag mounts the flag configuration of the FeatureFlag referenced by OfflineFeatureFlag into the
+// app containers and points OfflineFlagSourcePath to it. The ConfigMap is created asynchronously by the pod controller.
+func (m *PodMutator) mountOfflineFeatureFlag(ctx context.Context, pod *corev1.Pod, spec *v1beta2.InProcessConfigurationSpec) (int32, error) {
+	offlineFeatureFlag := ptr.Deref(spec.OfflineFeatureFlag, "")
+	if offlineFeatureFlag == "" {
+		return 0, nil
+	}
+	ctx, span := tracing.Start(ctx, "PodMutator.mountOfflineFeatureFlag")
+	defer span.End()
+
+	// the ConfigMap is created next to the FeatureFlag, volumes cannot mount ConfigMaps of another namespace
+	ns, name := utils.ParseAnnotation(offlineFeatureFlag, pod.Namespace)
+	if ns != pod.Namespace {
+		return http.StatusBadRequest, fmt.Errorf("offline FeatureFlag %s/%s is not in the namespace of the pod", ns, name)
+	}
+
+	containers := make([]*corev1.Container, 0, len(pod.Spec.Containers))
+	for i := range pod.Spec.Containers {
+		containers = append(containers, &pod.Spec.Containers[i])
+	}
+	path, err := flagdinjector.MountFeatureFlag(ctx, m.Client, &pod.Spec, client.ObjectKey{Namespace: ns, Name: name}, containers...)
+	if err != nil {
+		m.Log.V(1).Info(fmt.Sprintf("offline FeatureFlag %s could not be mounted: %s", offlineFeatureFlag, err.Error()))
+		if k8serrors.IsNotFound(err) {
+			return http.StatusNotFound, err
+		}
+		if errors.Is(err, common.ErrVolumeConflict) {
+			return http.StatusBadRequest, err
+		}
+		return http.StatusInternalServerError, err
+	}
+	spec.OfflineFlagSourcePath = ptr.To(path)
+	return 0, nil
+}
+
+// grantedFeatureFlags filters the FeatureFlags synced by the pod, references to other namespaces are dropped unless a
+// ReferenceGrant allows them
+func (m *PodMutator) grantedFeatureFlags(ctx context.Context, pod *corev1.Pod, featureFlags []client.ObjectKey) ([]client.ObjectKey, error) {
+	granted := []client.ObjectKey{}
+	for _, featureFlag := range featureFlags {
+		err := referencegrant.Check(ctx, m.Client, referencegrant.Reference{
+			FromKind:      referencegrant.KindPod,
+			FromNamespace: pod.Namespace,
+			ToKind:        referencegrant.KindFeatureFlag,
+			ToNamespace:   featureFlag.Namespace,
+			ToName:        featureFlag.Name,
+		})
+		if errors.Is(err, common.ErrReferenceNotGranted) {
+			m.Log.Info(fmt.Sprintf("not backfilling permissions of pod %s/%s: %s", pod.Namespace, pod.Name, err.Error()))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		granted = append(granted, featureFlag)
+	}
+	return granted, nil
+}
+
+// BackfillPermissions recovers the state of the flagd-kubernetes-sync role bindings in the event of upgrade. Service
+// accounts bound to the shared flagd-kubernetes-sync cluster role binding by previous versions are migrated to
+// namespaced role bindings granting access to the FeatureFlags of their pods only.
 func (m *PodMutator) BackfillPermissions(ctx context.Context) error {
 	defer func() {
 		m.ready = true
 	}()
-	for i := 0; i < 5; i++ {
-		// fetch all pods with the fmt.Sprintf("%s/%s", OpenFeatureAnnotationPath, EnabledAnnotation) annotation set to "true"
-		podList := &corev1.PodList{}
-		err := m.Client.List(ctx, podList, client.MatchingFields{
-			fmt.Sprintf("%s/%s", common.PodOpenFeatureAnnotationPath, common.AllowKubernetesSyncAnnotation): "true",
-		})
+	pods, err := m.kubernetesSyncPods(ctx)
+	if err != nil {
+		return err
+	}
+
+	// grant each service account access to the FeatureFlags of its pods
+	migrated := []client.ObjectKey{}
+	failed := []client.ObjectKey{}
+	for _, pod := range pods {
+		m.Log.V(1).Info(fmt.Sprintf("backfilling permissions for pod %s/%s", pod.Namespace, pod.Name))
+		serviceAccount := client.ObjectKey{Namespace: pod.Namespace, Name: pod.Spec.ServiceAccountName}
+		if serviceAccount.Name == "" {
+			serviceAccount.Name = "default"
+		}
+		featureFlags := kubernetessync.PodFeatureFlags(&pod)
+		if len(featureFlags) == 0 {
+			// the FeatureFlags of the pod are unknown, its service account keeps the cluster wide permissions
+			failed = append(failed, serviceAccount)
+			continue
+		}
+		// the references of the pod were not checked by previous versions, ungranted ones lose their access
+		featureFlags, err := m.grantedFeatureFlags(ctx, &pod, featureFlags)
 		if err != nil {
-			if !errors.Is(err, &cache.ErrCacheNotStarted{}) {
-				return err
-			}
-			time.Sleep(1 * time.Second)
+			m.Log.Error(err, fmt.Sprintf("unable to check the ReferenceGrants of pod %s/%s", pod.Namespace, pod.Name))
+			failed = append(failed, serviceAccount)
+			continue
+		}
+		if len(featureFlags) == 0 {
+			migrated = append(migrated, serviceAccount)
 			continue
 		}
+		if err := m.FlagdInjector.EnableKubernetesSyncPermissions(ctx, pod.Namespace, pod.Spec.ServiceAccountName, featureFlags); err != nil {
+			m.Log.Error(
+				err,
+				fmt.Sprintf("unable backfill permissions for pod %s/%s", pod.Namespace, pod.Name),
+				"webhook",
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation),
+			)
+			failed = append(failed, serviceAccount)
+			continue
+		}
+		migrated = append(migrated, serviceAccount)
+	}
+
+	// an operator restricted to namespaces has no access to the shared cluster role binding
+	if len(m.Namespaces) > 0 {
+		return nil
+	}
+
+	// drop the migrated service accounts from the shared cluster role binding
+	migrated = slices.DeleteFunc(migrated, func(serviceAccount client.ObjectKey) bool {
+		return slices.Contains(failed, serviceAccount)
+	})
+	if err := kubernetessync.RemoveClusterRoleBindingSubjects(ctx, m.Client, migrated); err != nil {
+		m.Log.Error(err, fmt.Sprintf("unable to remove migrated service accounts from %s", common.ClusterRoleBindingName))
+	}
+	return nil
+}
 
-		// add each new service account to the flagd-kubernetes-sync role binding
-		for _, pod := range podList.Items {
-			m.Log.V(1).Info(fmt.Sprintf("backfilling permissions for pod %s/%s", pod.Namespace, pod.Name))
-			if err := m.FlagdInjector.EnableClusterRoleBinding(ctx, pod.Namespace, pod.Spec.ServiceAccountName); err != nil {
-				m.Log.Error(
-					err,
-					fmt.Sprintf("unable backfill permissions for pod %s/%s", pod.Namespace, pod.Name),
-					"webhook",
-					fmt.Sprintf("%s/%s", common.PodOpenFeatureAnnotationPath, common.AllowKubernetesSyncAnnotation),
-				)
+// kubernetesSyncPods returns the pods with the openfeature.dev/allowkubernetessync annotation set to "true". The pods
+// are listed page by page as metadata only, without the cache, and only the annotated pods are read entirely.
+func (m *PodMutator) kubernetesSyncPods(ctx context.Context) ([]corev1.Pod, error) {
+	reader := m.Reader
+	if reader == nil {
+		reader = m.Client
+	}
+	namespaces := m.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	pods := []corev1.Pod{}
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PodList"))
+	for _, namespace := range namespaces {
+		if err := utils.ForEachPage(ctx, reader, list, namespace, func() error {
+			for _, item := range list.Items {
+				if item.Annotations[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation)] != "true" {
+					continue
+				}
+				pod := corev1.Pod{}
+				if err := reader.Get(ctx, client.ObjectKey{Namespace: item.Namespace, Name: item.Name}, &pod); err != nil {
+					if k8serrors.IsNotFound(err) {
+						continue
+					}
+					return err
+				}
+				pods = append(pods, pod)
 			}
+			return nil
+		}); err != nil {
+			return nil, err
 		}
-		return nil
 	}
-	return errors.New("unable to backfill permissions for the flagd-kubernetes-sync role binding: timeout")
+	return pods, nil
+}
+
+// recordEvent emits an event on the controlling owner of the pod, as the pod itself does not exist yet.
+// Static and orphaned pods are referenced directly. No events are emitted for dry-run requests.
+func (m *PodMutator) recordEvent(req admission.Request, pod *corev1.Pod, eventType, reason, messageFmt string, args ...interface{}) {
+	if req.DryRun != nil && *req.DryRun {
+		return
+	}
+	ref := &corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  pod.Namespace,
+		Name:       podName(pod),
+	}
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil && len(pod.OwnerReferences) > 0 {
+		owner = &pod.OwnerReferences[0]
+	}
+	if owner != nil {
+		ref = &corev1.ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  pod.Namespace,
+			Name:       owner.Name,
+			UID:        owner.UID,
+		}
+	}
+	common.RecordEvent(m.Recorder, ref, eventType, reason, messageFmt, args...)
+}
+
+// podName returns the name of the pod or its generate name if the name has not been assigned yet
+func podName(pod *corev1.Pod) string {
+	if pod.Name != "" {
+		return pod.Name
+	}
+	return pod.GenerateName
 }
 
 // InjectDecoder injects the decoder.
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
 	"reflect"
 	"testing"
 
@@ -13,16 +14,27 @@ import (
 	"github.com/golang/mock/gomock"
 	api "github.com/open-feature/open-feature-operator/api/core/v1beta1"
 	apicommon "github.com/open-feature/open-feature-operator/api/core/v1beta1/common"
+	apiv1beta2 "github.com/open-feature/open-feature-operator/api/core/v1beta2"
 	"github.com/open-feature/open-feature-operator/internal/common"
+	"github.com/open-feature/open-feature-operator/internal/common/cachelabel"
 	flagdinjectorfake "github.com/open-feature/open-feature-operator/internal/common/flagdinjector/fake"
+	"github.com/open-feature/open-feature-operator/internal/common/flagdproxy"
+	"github.com/open-feature/open-feature-operator/internal/common/types"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -51,18 +63,18 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 		{
 			name: "no annotated pod",
 			mutator: &PodMutator{
-				Client:  NewClient(false),
+				Client:  NewClient(),
 				decoder: nil,
 				Log:     testr.New(t),
 				ready:   false,
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name: "pod is annotated",
 			mutator: &PodMutator{
 				Log: testr.New(t),
-				Client: NewClient(true,
+				Client: NewClient(
 					&corev1.Pod{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pod,
@@ -72,14 +84,16 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation):   fmt.Sprintf("%s/%s", mutatePodNamespace, featureFlagSourceName),
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
 							}},
+						Spec: kubernetesSyncPodSpec(""),
 					},
 				),
 			},
 			setup: func(injector *flagdinjectorfake.MockFlagdContainerInjector) {
-				injector.EXPECT().EnableClusterRoleBinding(
+				injector.EXPECT().EnableKubernetesSyncPermissions(
 					gomock.Any(),
 					ns,
 					"",
+					[]client.ObjectKey{{Namespace: ns, Name: "my-flags"}},
 				).Return(nil).Times(1)
 			},
 			wantErr: false,
@@ -88,7 +102,7 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 			name: "pod is annotated, ClusterRoleBinding cannot be enabled; continue with other pods",
 			mutator: &PodMutator{
 				Log: testr.New(t),
-				Client: NewClient(true,
+				Client: NewClient(
 					&corev1.Pod{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pod + "-1",
@@ -98,6 +112,7 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation):   fmt.Sprintf("%s/%s", mutatePodNamespace, featureFlagSourceName),
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
 							}},
+						Spec: kubernetesSyncPodSpec(""),
 					},
 					&corev1.Pod{
 						ObjectMeta: metav1.ObjectMeta{
@@ -108,25 +123,44 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation):   fmt.Sprintf("%s/%s", mutatePodNamespace, featureFlagSourceName),
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
 							}},
+						Spec: kubernetesSyncPodSpec(""),
 					},
 				),
 			},
 			setup: func(injector *flagdinjectorfake.MockFlagdContainerInjector) {
 				// make the mock return an error - in this case we still expect the number of invocations
 				// to match the number of pods
-				injector.EXPECT().EnableClusterRoleBinding(
+				injector.EXPECT().EnableKubernetesSyncPermissions(
 					gomock.Any(),
 					ns,
 					"",
+					gomock.Any(),
 				).Return(errors.New("error")).Times(2)
 			},
 			wantErr: false,
 		},
+		{
+			name: "pod without kubernetes sources: permissions are not migrated",
+			mutator: &PodMutator{
+				Log: testr.New(t),
+				Client: NewClient(
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      pod,
+							Namespace: ns,
+							Annotations: map[string]string{
+								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
+							}},
+					},
+				),
+			},
+			wantErr: false,
+		},
 		{
 			name: "Subjects exists: no backfill",
 			mutator: &PodMutator{
 				Log: testr.New(t),
-				Client: NewClient(true,
+				Client: NewClient(
 					&corev1.Pod{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pod,
@@ -136,7 +170,7 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation):   fmt.Sprintf("%s/%s", mutatePodNamespace, featureFlagSourceName),
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
 							}},
-						Spec: corev1.PodSpec{ServiceAccountName: "my-service-account"},
+						Spec: kubernetesSyncPodSpec("my-service-account"),
 					},
 					&corev1.ServiceAccount{
 						ObjectMeta: metav1.ObjectMeta{
@@ -156,7 +190,7 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 				),
 			},
 			setup: func(injector *flagdinjectorfake.MockFlagdContainerInjector) {
-				injector.EXPECT().EnableClusterRoleBinding(context.TODO(), ns, "my-service-account").Times(1)
+				injector.EXPECT().EnableKubernetesSyncPermissions(context.TODO(), ns, "my-service-account", gomock.Any()).Times(1)
 			},
 			wantErr: false,
 		},
@@ -164,7 +198,7 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 			name: "Subjects does not exist: backfill",
 			mutator: &PodMutator{
 				Log: testr.New(t),
-				Client: NewClient(true,
+				Client: NewClient(
 					&corev1.Pod{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pod,
@@ -174,6 +208,7 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation):   fmt.Sprintf("%s/%s", mutatePodNamespace, featureFlagSourceName),
 								fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
 							}},
+						Spec: kubernetesSyncPodSpec(""),
 					},
 					&corev1.ServiceAccount{
 						ObjectMeta: metav1.ObjectMeta{
@@ -201,7 +236,7 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 			},
 			wantErr: false,
 			setup: func(injector *flagdinjectorfake.MockFlagdContainerInjector) {
-				injector.EXPECT().EnableClusterRoleBinding(context.TODO(), ns, "").Times(1)
+				injector.EXPECT().EnableKubernetesSyncPermissions(context.TODO(), ns, "", gomock.Any()).Times(1)
 			},
 		},
 	}
@@ -224,6 +259,162 @@ func TestPodMutator_BackfillPermissions(t *testing.T) {
 	}
 }
 
+func TestPodMutator_BackfillPermissions_MigratesClusterRoleBinding(t *testing.T) {
+	const ns = "mynamespace"
+
+	c := NewClient(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "migrated",
+				Namespace: ns,
+				Annotations: map[string]string{
+					fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
+				}},
+			Spec: kubernetesSyncPodSpec("migrated"),
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "unknown",
+				Namespace: ns,
+				Annotations: map[string]string{
+					fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
+				}},
+			Spec: corev1.PodSpec{ServiceAccountName: "unknown"},
+		},
+		&rbac.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: common.ClusterRoleBindingName,
+			},
+			Subjects: []rbac.Subject{
+				{Kind: "ServiceAccount", Name: "controller-manager", Namespace: "open-feature-operator-system"},
+				{Kind: "ServiceAccount", Name: "migrated", Namespace: ns},
+				{Kind: "ServiceAccount", Name: "unknown", Namespace: ns},
+			},
+		},
+	)
+
+	ctrl := gomock.NewController(t)
+	mockInjector := flagdinjectorfake.NewMockFlagdContainerInjector(ctrl)
+	mockInjector.EXPECT().EnableKubernetesSyncPermissions(gomock.Any(), ns, "migrated", []client.ObjectKey{{Namespace: ns, Name: "my-flags"}}).Return(nil).Times(1)
+
+	m := &PodMutator{
+		Client:        c,
+		Log:           testr.New(t),
+		FlagdInjector: mockInjector,
+	}
+	require.Nil(t, m.BackfillPermissions(context.TODO()))
+
+	crb := &rbac.ClusterRoleBinding{}
+	require.Nil(t, c.Get(context.TODO(), client.ObjectKey{Name: common.ClusterRoleBindingName}, crb))
+	require.Equal(t, []rbac.Subject{
+		{Kind: "ServiceAccount", Name: "controller-manager", Namespace: "open-feature-operator-system"},
+		{Kind: "ServiceAccount", Name: "unknown", Namespace: ns},
+	}, crb.Subjects)
+}
+
+func TestPodMutator_BackfillPermissions_WatchNamespaces(t *testing.T) {
+	const ns = "mynamespace"
+
+	subjects := []rbac.Subject{{Kind: "ServiceAccount", Name: "migrated", Namespace: ns}}
+	c := NewClient(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "migrated",
+				Namespace: ns,
+				Annotations: map[string]string{
+					fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
+				}},
+			Spec: kubernetesSyncPodSpec("migrated"),
+		},
+		&rbac.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: common.ClusterRoleBindingName,
+			},
+			Subjects: subjects,
+		},
+	)
+
+	ctrl := gomock.NewController(t)
+	mockInjector := flagdinjectorfake.NewMockFlagdContainerInjector(ctrl)
+	mockInjector.EXPECT().EnableKubernetesSyncPermissions(gomock.Any(), ns, "migrated", []client.ObjectKey{{Namespace: ns, Name: "my-flags"}}).Return(nil).Times(1)
+
+	m := &PodMutator{
+		Client:        c,
+		Log:           testr.New(t),
+		FlagdInjector: mockInjector,
+		Namespaces:    []string{ns},
+	}
+	require.Nil(t, m.BackfillPermissions(context.TODO()))
+
+	// the cluster role binding is left untouched without cluster-scoped permissions
+	crb := &rbac.ClusterRoleBinding{}
+	require.Nil(t, c.Get(context.TODO(), client.ObjectKey{Name: common.ClusterRoleBindingName}, crb))
+	require.Equal(t, subjects, crb.Subjects)
+}
+
+func TestPodMutator_BackfillPermissions_ReferenceGrants(t *testing.T) {
+	const ns = "mynamespace"
+
+	annotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation): "true",
+	}
+	grantedSpec := kubernetesSyncPodSpec("granted")
+	grantedSpec.InitContainers[0].Args[2] = `[{"uri":"my-flags","provider":"kubernetes"},{"uri":"flags/shared","provider":"kubernetes"},{"uri":"flags/private","provider":"kubernetes"}]`
+	deniedSpec := kubernetesSyncPodSpec("denied")
+	deniedSpec.InitContainers[0].Args[2] = `[{"uri":"flags/private","provider":"kubernetes"}]`
+	c := NewClient(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "granted", Namespace: ns, Annotations: annotations}, Spec: grantedSpec},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "denied", Namespace: ns, Annotations: annotations}, Spec: deniedSpec},
+		&api.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-shared", Namespace: "flags"},
+			Spec: api.ReferenceGrantSpec{
+				From: []api.ReferenceGrantFrom{{Kind: "Pod", Namespace: ns}},
+				To:   []api.ReferenceGrantTo{{Kind: "FeatureFlag", Name: ptr.To("shared")}},
+			},
+		},
+		&rbac.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: common.ClusterRoleBindingName,
+			},
+			Subjects: []rbac.Subject{
+				{Kind: "ServiceAccount", Name: "granted", Namespace: ns},
+				{Kind: "ServiceAccount", Name: "denied", Namespace: ns},
+			},
+		},
+	)
+
+	// references to other namespaces without a ReferenceGrant are not granted
+	ctrl := gomock.NewController(t)
+	mockInjector := flagdinjectorfake.NewMockFlagdContainerInjector(ctrl)
+	mockInjector.EXPECT().EnableKubernetesSyncPermissions(gomock.Any(), ns, "granted", []client.ObjectKey{
+		{Namespace: ns, Name: "my-flags"},
+		{Namespace: "flags", Name: "shared"},
+	}).Return(nil).Times(1)
+
+	m := &PodMutator{
+		Client:        c,
+		Log:           testr.New(t),
+		FlagdInjector: mockInjector,
+	}
+	require.Nil(t, m.BackfillPermissions(context.TODO()))
+
+	// both service accounts lose the cluster wide permissions
+	crb := &rbac.ClusterRoleBinding{}
+	require.Nil(t, c.Get(context.TODO(), client.ObjectKey{Name: common.ClusterRoleBindingName}, crb))
+	require.Empty(t, crb.Subjects)
+}
+
+// kubernetesSyncPodSpec returns the spec of a pod injected with a flagd sidecar syncing a FeatureFlag of its namespace
+func kubernetesSyncPodSpec(serviceAccountName string) corev1.PodSpec {
+	return corev1.PodSpec{
+		ServiceAccountName: serviceAccountName,
+		InitContainers: []corev1.Container{{
+			Name: "flagd",
+			Args: []string{"start", common.SourceConfigParam, `[{"uri":"my-flags","provider":"kubernetes"}]`},
+		}},
+	}
+}
+
 func TestPodMutator_Handle(t *testing.T) {
 	decoder := admission.NewDecoder(scheme.Scheme)
 
@@ -300,7 +491,7 @@ func TestPodMutator_Handle(t *testing.T) {
 		{
 			name: "successful request pod not annotated",
 			mutator: &PodMutator{
-				Client:  NewClient(false),
+				Client:  NewClient(),
 				decoder: decoder,
 				Log:     testr.New(t),
 				ready:   false,
@@ -320,7 +511,7 @@ func TestPodMutator_Handle(t *testing.T) {
 		{
 			name: "forbidden request pod annotated but without owner",
 			mutator: &PodMutator{
-				Client:  NewClient(false),
+				Client:  NewClient(),
 				decoder: decoder,
 				Log:     testr.New(t),
 				ready:   false,
@@ -338,9 +529,9 @@ func TestPodMutator_Handle(t *testing.T) {
 			allow:    false,
 		},
 		{
-			name: "forbidden request pod annotated with owner, but cluster role binding cannot be enabled",
+			name: "allowed request pod annotated with owner, kubernetes sync permissions are not granted during admission",
 			mutator: &PodMutator{
-				Client: NewClient(false,
+				Client: NewClient(
 					&api.FeatureFlagSource{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      featureFlagSourceName,
@@ -368,19 +559,25 @@ func TestPodMutator_Handle(t *testing.T) {
 			},
 			setup: func(mockInjector *flagdinjectorfake.MockFlagdContainerInjector) {
 				mockInjector.EXPECT().
-					EnableClusterRoleBinding(
+					EnableKubernetesSyncPermissions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				mockInjector.EXPECT().
+					EnsureDependencies(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+				mockInjector.EXPECT().
+					InjectFlagd(
 						gomock.Any(),
-						antPod.Namespace,
-						antPod.Spec.ServiceAccountName,
-					).Return(errors.New("error")).Times(1)
+						gomock.AssignableToTypeOf(&antPod.ObjectMeta),
+						gomock.AssignableToTypeOf(&antPod.Spec),
+						gomock.AssignableToTypeOf(&api.FeatureFlagSourceSpec{}),
+					).Return(nil).Times(1)
 			},
-			wantCode: http.StatusForbidden,
-			allow:    false,
+			allow: true,
 		},
 		{
 			name: "forbidden request pod annotated with owner, but flagd proxy is not ready",
 			mutator: &PodMutator{
-				Client: NewClient(false,
+				Client: NewClient(
 					&api.FeatureFlagSource{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      featureFlagSourceName,
@@ -416,7 +613,7 @@ func TestPodMutator_Handle(t *testing.T) {
 		{
 			name: "forbidden request pod annotated with owner, but FeatureFlagSource is not available",
 			mutator: &PodMutator{
-				Client:  NewClient(false),
+				Client:  NewClient(),
 				decoder: decoder,
 				Log:     testr.New(t),
 				ready:   false,
@@ -432,10 +629,30 @@ func TestPodMutator_Handle(t *testing.T) {
 			},
 			wantCode: http.StatusNotFound,
 		},
+		{
+			name: "allowed request pod annotated with owner, but namespace is not watched",
+			mutator: &PodMutator{
+				Client:     NewClient(),
+				decoder:    decoder,
+				Log:        testr.New(t),
+				Namespaces: []string{"other-namespace"},
+			},
+			req: admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UID: "123",
+					Object: runtime.RawExtension{
+						Raw:    goodAnnotatedPod,
+						Object: &corev1.Pod{},
+					},
+				},
+			},
+			wantCode: http.StatusOK,
+			allow:    true,
+		},
 		{
 			name: "happy path rpc: request pod annotated configured for env var",
 			mutator: &PodMutator{
-				Client: NewClient(true,
+				Client: NewClient(
 					&antPod,
 					&corev1.ServiceAccount{
 						ObjectMeta: metav1.ObjectMeta{
@@ -482,7 +699,7 @@ func TestPodMutator_Handle(t *testing.T) {
 		{
 			name: "happy path in-process: request pod annotated configured for env var",
 			mutator: &PodMutator{
-				Client: NewClient(true,
+				Client: NewClient(
 					&inProcessPod,
 					&corev1.ServiceAccount{
 						ObjectMeta: metav1.ObjectMeta{
@@ -495,12 +712,12 @@ func TestPodMutator_Handle(t *testing.T) {
 						Subjects:   nil,
 						RoleRef:    rbac.RoleRef{},
 					},
-					&api.InProcessConfiguration{
+					&apiv1beta2.InProcessConfiguration{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      inProcessConfigurationName,
 							Namespace: mutatePodNamespace,
 						},
-						Spec: api.InProcessConfigurationSpec{
+						Spec: apiv1beta2.InProcessConfigurationSpec{
 							EnvVars: []corev1.EnvVar{
 								{
 									Name:  "env1",
@@ -540,7 +757,7 @@ func TestPodMutator_Handle(t *testing.T) {
 		{
 			name: "ofo enabled but annotation missing",
 			mutator: &PodMutator{
-				Client: NewClient(true,
+				Client: NewClient(
 					&inProcessPod,
 					&corev1.ServiceAccount{
 						ObjectMeta: metav1.ObjectMeta{
@@ -581,7 +798,7 @@ func TestPodMutator_Handle(t *testing.T) {
 		{
 			name: "wrong request",
 			mutator: &PodMutator{
-				Client:  NewClient(false),
+				Client:  NewClient(),
 				decoder: decoder,
 				Log:     testr.New(t),
 				ready:   false,
@@ -622,37 +839,741 @@ func TestPodMutator_Handle(t *testing.T) {
 	}
 }
 
-func NewClient(withIndexes bool, objs ...client.Object) client.Client {
-	utilruntime.Must(scheme.AddToScheme(scheme.Scheme))
-	utilruntime.Must(api.AddToScheme(scheme.Scheme))
+func TestPodMutator_Handle_CacheLabel(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myAnnotatedPod",
+			Namespace: mutatePodNamespace,
+			Annotations: map[string]string{
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):           "true",
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation): featureFlagSourceName,
+			},
+			OwnerReferences: []metav1.OwnerReference{{UID: "123"}},
+		},
+	}
+	rawPod, err := json.Marshal(pod)
+	require.Nil(t, err)
+
+	mockFlagdInjector := flagdinjectorfake.NewMockFlagdContainerInjector(gomock.NewController(t))
+	mockFlagdInjector.EXPECT().InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	m := &PodMutator{
+		Client: NewClient(&api.FeatureFlagSource{
+			ObjectMeta: metav1.ObjectMeta{Name: featureFlagSourceName, Namespace: mutatePodNamespace},
+		}),
+		decoder:       admission.NewDecoder(scheme.Scheme),
+		Log:           testr.New(t),
+		FlagdInjector: mockFlagdInjector,
+	}
+	got := m.Handle(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "123",
+			Namespace: mutatePodNamespace,
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: rawPod},
+		},
+	})
+	require.True(t, got.Allowed)
+
+	labeled := false
+	for _, patch := range got.Patches {
+		if patch.Path == "/metadata/labels" {
+			require.Equal(t, map[string]interface{}{cachelabel.Label: cachelabel.Value}, patch.Value)
+			labeled = true
+		}
+	}
+	require.True(t, labeled, "the pod is not labeled for the cache")
+}
+
+func TestPodMutator_Handle_ReferenceGrant(t *testing.T) {
+	decoder := admission.NewDecoder(scheme.Scheme)
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myAnnotatedPod",
+			Namespace: mutatePodNamespace,
+			Annotations: map[string]string{
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):           "true",
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation): fmt.Sprintf("flags/%s", featureFlagSourceName),
+			},
+			OwnerReferences: []metav1.OwnerReference{{UID: "123"}},
+		},
+	}
+	rawPod, err := json.Marshal(pod)
+	require.Nil(t, err)
 
-	annotationsSyncIndexer := func(obj client.Object) []string {
-		res := obj.GetAnnotations()[fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.AllowKubernetesSyncAnnotation)]
-		return []string{res}
+	featureFlagSource := &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      featureFlagSourceName,
+			Namespace: "flags",
+		},
+	}
+	grant := &api.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "allow-pods",
+			Namespace: "flags",
+		},
+		Spec: api.ReferenceGrantSpec{
+			From: []api.ReferenceGrantFrom{{Kind: "Pod", Namespace: mutatePodNamespace}},
+			To:   []api.ReferenceGrantTo{{Kind: "FeatureFlagSource", Name: ptr.To(featureFlagSourceName)}},
+		},
 	}
 
-	featureflagIndexer := func(obj client.Object) []string {
-		res := obj.GetAnnotations()["openfeature.dev/featureflag"]
-		return []string{res}
+	tests := []struct {
+		name    string
+		objects []client.Object
+		allow   bool
+	}{
+		{
+			name:    "cross-namespace reference without grant is denied",
+			objects: []client.Object{featureFlagSource},
+			allow:   false,
+		},
+		{
+			name:    "cross-namespace reference with grant is allowed",
+			objects: []client.Object{featureFlagSource, grant},
+			allow:   true,
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFlagdInjector := flagdinjectorfake.NewMockFlagdContainerInjector(ctrl)
+			if tt.allow {
+				mockFlagdInjector.EXPECT().InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+
+			m := &PodMutator{
+				Client: NewClient(
+					tt.objects...),
+				decoder:       decoder,
+				Log:           testr.New(t),
+				FlagdInjector: mockFlagdInjector,
+			}
+			got := m.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UID:       "123",
+					Namespace: mutatePodNamespace,
+					Object: runtime.RawExtension{
+						Raw:    rawPod,
+						Object: &corev1.Pod{},
+					},
+				},
+			})
+
+			require.Equal(t, tt.allow, got.Allowed)
+			if !tt.allow {
+				require.Equal(t, int32(http.StatusForbidden), got.Result.Code)
+				require.Contains(t, got.Result.Message, "without a ReferenceGrant in namespace flags")
+			}
+		})
+	}
+}
+
+func TestPodMutator_Handle_Events(t *testing.T) {
+	decoder := admission.NewDecoder(scheme.Scheme)
+
+	annotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):           "true",
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation): featureFlagSourceName,
+	}
+	owner := metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "my-app-7d9f",
+		UID:        "123",
+		Controller: ptr.To(true),
+	}
+	ownedPod, err := json.Marshal(corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName:    "my-app-7d9f-",
+			Namespace:       mutatePodNamespace,
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+	})
+	require.Nil(t, err)
+	orphanedPod, err := json.Marshal(corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-pod",
+			Namespace:   mutatePodNamespace,
+			Annotations: annotations,
+		},
+	})
+	require.Nil(t, err)
+
+	featureFlagSource := &api.FeatureFlagSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      featureFlagSourceName,
+			Namespace: mutatePodNamespace,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		client     client.Client
+		raw        []byte
+		dryRun     bool
+		injectErr  error
+		wantObject string
+		wantEvents []string
+	}{
+		{
+			name: "flagd injected",
+			client: NewClient(
+				featureFlagSource),
+			raw:        ownedPod,
+			wantObject: "involvedObject{kind=ReplicaSet,apiVersion=apps/v1}",
+			wantEvents: []string{"Normal FlagdInjected injected flagd sidecar into pod my-app-7d9f-"},
+		},
+		{
+			name: "flagd-proxy not ready",
+			client: NewClient(
+				featureFlagSource),
+			raw:        ownedPod,
+			injectErr:  common.ErrFlagdProxyNotReady,
+			wantObject: "involvedObject{kind=ReplicaSet,apiVersion=apps/v1}",
+			wantEvents: []string{"Warning InjectionFailed could not inject flagd sidecar into pod my-app-7d9f-: " + common.ErrFlagdProxyNotReady.Error()},
+		},
+		{
+			name:       "FeatureFlagSource not found",
+			client:     NewClient(),
+			raw:        ownedPod,
+			wantObject: "involvedObject{kind=ReplicaSet,apiVersion=apps/v1}",
+			wantEvents: []string{
+				fmt.Sprintf("Warning InjectionFailed could not inject flagd sidecar into pod my-app-7d9f-: featureflagsources.core.openfeature.dev \"%s\" not found", featureFlagSourceName),
+			},
+		},
+		{
+			name: "orphaned pod",
+			client: NewClient(
+				featureFlagSource),
+			raw:        orphanedPod,
+			wantObject: "involvedObject{kind=Pod,apiVersion=v1}",
+			wantEvents: []string{"Warning InjectionFailed static or orphaned pods cannot be mutated"},
+		},
+		{
+			name: "dry run",
+			client: NewClient(
+				featureFlagSource),
+			raw:    ownedPod,
+			dryRun: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFlagdInjector := flagdinjectorfake.NewMockFlagdContainerInjector(ctrl)
+			mockFlagdInjector.EXPECT().
+				InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(tt.injectErr).
+				AnyTimes()
+
+			recorder := record.NewFakeRecorder(10)
+			recorder.IncludeObject = true
+			m := &PodMutator{
+				Client:        tt.client,
+				decoder:       decoder,
+				Log:           testr.New(t),
+				FlagdInjector: mockFlagdInjector,
+				Recorder:      recorder,
+			}
+
+			m.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UID:       "123",
+					Namespace: mutatePodNamespace,
+					DryRun:    &tt.dryRun,
+					Object: runtime.RawExtension{
+						Raw:    tt.raw,
+						Object: &corev1.Pod{},
+					},
+				},
+			})
+
+			close(recorder.Events)
+			events := []string{}
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			require.Len(t, events, len(tt.wantEvents))
+			for i, want := range tt.wantEvents {
+				require.Contains(t, events[i], want)
+				require.Contains(t, events[i], tt.wantObject)
+			}
+		})
+	}
+}
+
+func TestPodMutator_Handle_Tracing(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	pod, err := json.Marshal(corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: mutatePodNamespace,
+			Annotations: map[string]string{
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):           "true",
+				fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.FeatureFlagSourceAnnotation): featureFlagSourceName,
+			},
+			OwnerReferences: []metav1.OwnerReference{{Name: "my-app", UID: "123"}},
+		},
+	})
+	require.Nil(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockFlagdInjector := flagdinjectorfake.NewMockFlagdContainerInjector(ctrl)
+	mockFlagdInjector.EXPECT().InjectFlagd(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	m := &PodMutator{
+		Client:        NewClient(),
+		decoder:       admission.NewDecoder(scheme.Scheme),
+		Log:           testr.New(t),
+		FlagdInjector: mockFlagdInjector,
+	}
+	m.Handle(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "123",
+			Namespace: mutatePodNamespace,
+			Object: runtime.RawExtension{
+				Raw:    pod,
+				Object: &corev1.Pod{},
+			},
+		},
+	})
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range spanRecorder.Ended() {
+		spans[span.Name()] = span
+	}
+	require.Contains(t, spans, "PodMutator.Handle")
+	require.Contains(t, spans, "PodMutator.createFSConfigSpec")
+	require.Contains(t, spans, "get FeatureFlagSource")
+
+	// the lookup is a child of the admission span and records the missing FeatureFlagSource
+	lookup := spans["get FeatureFlagSource"]
+	require.Equal(t, codes.Error, lookup.Status().Code)
+	require.Equal(t, spans["PodMutator.Handle"].SpanContext().TraceID(), lookup.SpanContext().TraceID())
+	require.Equal(t, spans["PodMutator.createFSConfigSpec"].SpanContext().SpanID(), lookup.Parent().SpanID())
+}
+
+func TestPodMutator_handleInProcessConfiguration_SocketPath(t *testing.T) {
+	annotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):                "true",
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation): inProcessConfigurationName,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "myAnnotatedPod",
+			Namespace:   mutatePodNamespace,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "sync"}},
+		},
+	}
+
+	m := &PodMutator{
+		Client: NewClient(
+			&apiv1beta2.InProcessConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      inProcessConfigurationName,
+					Namespace: mutatePodNamespace,
+				},
+				Spec: apiv1beta2.InProcessConfigurationSpec{
+					SocketPath: ptr.To("/tmp/sockets/flagd.sock"),
+				},
+			}),
+		Log: testr.New(t),
+		Env: types.EnvConfig{},
+	}
+
+	code, err := m.handleInProcessConfiguration(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Namespace: mutatePodNamespace},
+	}, annotations, pod)
+	require.Nil(t, err)
+	require.Equal(t, int32(0), code)
+
+	require.Len(t, pod.Spec.Volumes, 1)
+	require.Equal(t, common.SocketVolumeName, pod.Spec.Volumes[0].Name)
+	require.NotNil(t, pod.Spec.Volumes[0].EmptyDir)
+	for _, container := range pod.Spec.Containers {
+		require.Equal(t, []corev1.VolumeMount{{Name: common.SocketVolumeName, MountPath: "/tmp/sockets"}}, container.VolumeMounts)
+	}
+}
+
+func TestPodMutator_handleInProcessConfiguration_CrossNamespace(t *testing.T) {
+	annotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):                "true",
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation): "other/" + inProcessConfigurationName,
+	}
+	inProcessConfiguration := &apiv1beta2.InProcessConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inProcessConfigurationName,
+			Namespace: "other",
+		},
+		Spec: apiv1beta2.InProcessConfigurationSpec{
+			Host: ptr.To("sync.other.svc"),
+		},
+	}
+	grant := &api.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-pods", Namespace: "other"},
+		Spec: api.ReferenceGrantSpec{
+			From: []api.ReferenceGrantFrom{{Kind: "Pod", Namespace: mutatePodNamespace}},
+			To:   []api.ReferenceGrantTo{{Kind: "InProcessConfiguration", Name: ptr.To(inProcessConfigurationName)}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		objs     []client.Object
+		wantCode int32
+	}{
+		{
+			name:     "without ReferenceGrant",
+			objs:     []client.Object{inProcessConfiguration},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "with ReferenceGrant",
+			objs: []client.Object{inProcessConfiguration, grant},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "myAnnotatedPod",
+					Namespace:   mutatePodNamespace,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			}
+			m := &PodMutator{
+				Client: NewClient(tt.objs...),
+				Log:    testr.New(t),
+				Env:    types.EnvConfig{},
+			}
+
+			code, err := m.handleInProcessConfiguration(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Namespace: mutatePodNamespace},
+			}, annotations, pod)
+			require.Equal(t, tt.wantCode, code)
+			if tt.wantCode != 0 {
+				require.ErrorIs(t, err, common.ErrReferenceNotGranted)
+				require.Empty(t, pod.Spec.Containers[0].Env)
+				return
+			}
+			require.Nil(t, err)
+			require.Contains(t, pod.Spec.Containers[0].Env, corev1.EnvVar{Name: "HOST", Value: "sync.other.svc"})
+		})
+	}
+}
+
+func TestPodMutator_handleInProcessConfiguration_SyncServer(t *testing.T) {
+	annotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):                "true",
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation): inProcessConfigurationName,
+	}
+	deployment := func(namespace, name string, readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+		}
+	}
+	flagd := func(namespace string) []client.Object {
+		return []client.Object{
+			&api.Flagd{ObjectMeta: metav1.ObjectMeta{Name: "central-flagd", Namespace: namespace}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "central-flagd", Namespace: namespace},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "sync", Port: 8015}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		syncServer *apiv1beta2.SyncServerReference
+		objs       []client.Object
+		wantCode   int32
+		wantEnv    map[string]string
+	}{
+		{
+			name:       "ready flagd",
+			syncServer: &apiv1beta2.SyncServerReference{Kind: apiv1beta2.SyncServerKindFlagd, Name: "central-flagd"},
+			objs:       append(flagd(mutatePodNamespace), deployment(mutatePodNamespace, "central-flagd", 1)),
+			wantEnv: map[string]string{
+				"HOST": fmt.Sprintf("central-flagd.%s.svc.cluster.local", mutatePodNamespace),
+				"PORT": "8015",
+			},
+		},
+		{
+			name:       "flagd not ready",
+			syncServer: &apiv1beta2.SyncServerReference{Kind: apiv1beta2.SyncServerKindFlagd, Name: "central-flagd"},
+			objs:       append(flagd(mutatePodNamespace), deployment(mutatePodNamespace, "central-flagd", 0)),
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "flagd not found",
+			syncServer: &apiv1beta2.SyncServerReference{Kind: apiv1beta2.SyncServerKindFlagd, Name: "central-flagd"},
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "flagd of another namespace without a ReferenceGrant",
+			syncServer: &apiv1beta2.SyncServerReference{Kind: apiv1beta2.SyncServerKindFlagd, Name: "flags/central-flagd"},
+			objs:       append(flagd("flags"), deployment("flags", "central-flagd", 1)),
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "ready flagd-proxy",
+			syncServer: &apiv1beta2.SyncServerReference{Kind: apiv1beta2.SyncServerKindFlagdProxy, FeatureFlag: "my-flags"},
+			objs:       []client.Object{deployment("open-feature-operator-system", flagdproxy.FlagdProxyDeploymentName, 1)},
+			wantEnv: map[string]string{
+				"HOST":            "flagd-proxy-svc.open-feature-operator-system.svc.cluster.local",
+				"PORT":            "8015",
+				"SOURCE_SELECTOR": fmt.Sprintf("core.openfeature.dev/%s/my-flags", mutatePodNamespace),
+			},
+		},
+		{
+			name:       "flagd-proxy without a FeatureFlag",
+			syncServer: &apiv1beta2.SyncServerReference{Kind: apiv1beta2.SyncServerKindFlagdProxy},
+			objs:       []client.Object{deployment("open-feature-operator-system", flagdproxy.FlagdProxyDeploymentName, 1)},
+			wantCode:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "myAnnotatedPod",
+					Namespace:   mutatePodNamespace,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			}
+			objs := append([]client.Object{&apiv1beta2.InProcessConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      inProcessConfigurationName,
+					Namespace: mutatePodNamespace,
+				},
+				Spec: apiv1beta2.InProcessConfigurationSpec{
+					Host:       ptr.To("localhost"),
+					Port:       ptr.To(int32(8015)),
+					SyncServer: tt.syncServer,
+				},
+			}}, tt.objs...)
+
+			m := &PodMutator{
+				Client: NewClient(objs...),
+				Log:    testr.New(t),
+				Env:    types.EnvConfig{},
+				FlagdProxyConfig: flagdproxy.NewFlagdProxyConfiguration(types.EnvConfig{
+					PodNamespace:       "open-feature-operator-system",
+					FlagdProxyPort:     8015,
+					FlagdClusterDomain: "cluster.local",
+				}, nil, nil, nil),
+			}
+
+			code, err := m.handleInProcessConfiguration(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Namespace: mutatePodNamespace},
+			}, annotations, pod)
+			require.Equal(t, tt.wantCode, code)
+			if tt.wantCode != 0 {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+
+			env := map[string]string{}
+			for _, envVar := range pod.Spec.Containers[0].Env {
+				env[envVar.Name] = envVar.Value
+			}
+			for name, value := range tt.wantEnv {
+				require.Equal(t, value, env[name], name)
+			}
+		})
+	}
+}
+
+func TestPodMutator_handleInProcessConfiguration_OfflineFeatureFlag(t *testing.T) {
+	annotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):                "true",
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation): inProcessConfigurationName,
+	}
+	featureFlag := func(namespace string) *api.FeatureFlag {
+		return &api.FeatureFlag{ObjectMeta: metav1.ObjectMeta{Name: "offline-flags", Namespace: namespace}}
+	}
+
+	tests := []struct {
+		name        string
+		featureFlag string
+		objs        []client.Object
+		wantCode    int32
+		wantPath    string
+	}{
+		{
+			name:        "FeatureFlag of the pod namespace",
+			featureFlag: "offline-flags",
+			objs:        []client.Object{featureFlag(mutatePodNamespace)},
+			wantPath:    fmt.Sprintf("/etc/flagd/%[1]s_offline-flags/%[1]s_offline-flags.flagd.json", mutatePodNamespace),
+		},
+		{
+			name:        "FeatureFlag not found",
+			featureFlag: "offline-flags",
+			wantCode:    http.StatusNotFound,
+		},
+		{
+			name:        "FeatureFlag of another namespace",
+			featureFlag: "flags/offline-flags",
+			objs:        []client.Object{featureFlag("flags")},
+			wantCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "myAnnotatedPod",
+					Namespace:   mutatePodNamespace,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}, {Name: "worker"}},
+				},
+			}
+			objs := append([]client.Object{&apiv1beta2.InProcessConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      inProcessConfigurationName,
+					Namespace: mutatePodNamespace,
+				},
+				Spec: apiv1beta2.InProcessConfigurationSpec{
+					OfflineFeatureFlag: ptr.To(tt.featureFlag),
+				},
+			}}, tt.objs...)
+
+			m := &PodMutator{
+				Client: NewClient(objs...),
+				Log:    testr.New(t),
+				Env:    types.EnvConfig{},
+			}
+
+			code, err := m.handleInProcessConfiguration(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Namespace: mutatePodNamespace},
+			}, annotations, pod)
+			require.Equal(t, tt.wantCode, code)
+			if tt.wantCode != 0 {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+
+			require.Equal(t, []corev1.Volume{{
+				Name: "offline-flags",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "offline-flags"},
+					},
+				},
+			}}, pod.Spec.Volumes)
+			for _, container := range pod.Spec.Containers {
+				require.Equal(t, []corev1.VolumeMount{{
+					Name:      "offline-flags",
+					MountPath: path.Dir(tt.wantPath),
+				}}, container.VolumeMounts)
+				require.Contains(t, container.Env, corev1.EnvVar{Name: "OFFLINE_FLAG_SOURCE_PATH", Value: tt.wantPath})
+			}
+		})
+	}
+}
+
+func TestPodMutator_handleInProcessConfiguration_Credentials(t *testing.T) {
+	annotations := map[string]string{
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.EnabledAnnotation):                "true",
+		fmt.Sprintf("%s/%s", common.OpenFeatureAnnotationPrefix, common.InProcessConfigurationAnnotation): inProcessConfigurationName,
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "myAnnotatedPod",
+			Namespace:   mutatePodNamespace,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+	}
+	bearerToken := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "sync-token"},
+		Key:                  "token",
+	}
+
+	m := &PodMutator{
+		Client: NewClient(&apiv1beta2.InProcessConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inProcessConfigurationName,
+				Namespace: mutatePodNamespace,
+			},
+			Spec: apiv1beta2.InProcessConfigurationSpec{
+				TLSConfig: &apiv1beta2.InProcessTLSConfig{
+					CASecret:         "sync-ca",
+					ClientCertSecret: "sync-client",
+				},
+				BearerToken: bearerToken,
+			},
+		}),
+		Log: testr.New(t),
+		Env: types.EnvConfig{},
+	}
+
+	code, err := m.handleInProcessConfiguration(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Namespace: mutatePodNamespace},
+	}, annotations, pod)
+	require.Nil(t, err)
+	require.Equal(t, int32(0), code)
+
+	require.Equal(t, []corev1.Volume{
+		{
+			Name:         common.InProcessCAVolumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "sync-ca"}},
+		},
+		{
+			Name:         common.InProcessClientCertVolumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "sync-client"}},
+		},
+	}, pod.Spec.Volumes)
+	require.Equal(t, []corev1.VolumeMount{
+		{Name: common.InProcessCAVolumeName, MountPath: apicommon.InProcessCAMountPath, ReadOnly: true},
+		{Name: common.InProcessClientCertVolumeName, MountPath: apicommon.InProcessClientCertMountPath, ReadOnly: true},
+	}, pod.Spec.Containers[0].VolumeMounts)
+
+	env := pod.Spec.Containers[0].Env
+	require.Contains(t, env, corev1.EnvVar{Name: "TLS", Value: "true"})
+	require.Contains(t, env, corev1.EnvVar{Name: "SERVER_CERT_PATH", Value: "/etc/flagd-tls/ca/ca.crt"})
+	require.Contains(t, env, corev1.EnvVar{Name: "CLIENT_CERT_PATH", Value: "/etc/flagd-tls/client/tls.crt"})
+	require.Contains(t, env, corev1.EnvVar{Name: "CLIENT_KEY_PATH", Value: "/etc/flagd-tls/client/tls.key"})
+	require.Contains(t, env, corev1.EnvVar{
+		Name:      "BEARER_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: bearerToken},
+	})
+}
+
+func NewClient(objs ...client.Object) client.Client {
+	utilruntime.Must(scheme.AddToScheme(scheme.Scheme))
+	utilruntime.Must(api.AddToScheme(scheme.Scheme))
+	utilruntime.Must(apiv1beta2.AddToScheme(scheme.Scheme))
 
-	fakeClient := fake.NewClientBuilder().
+	return fake.NewClientBuilder().
 		WithScheme(scheme.Scheme).
-		WithObjects(objs...)
-
-	if withIndexes {
-		return fakeClient.
-			WithIndex(
-				&corev1.Pod{},
-				"metadata.annotations.openfeature.dev/allowkubernetessync",
-				annotationsSyncIndexer).
-			WithIndex(
-				&corev1.Pod{},
-				"metadata.annotations.openfeature.dev/featureflag",
-				featureflagIndexer).
-			Build()
-	}
-	return fakeClient.Build()
+		WithObjects(objs...).
+		Build()
 }
 
 func TestPodMutator_IsReady(t *testing.T) {